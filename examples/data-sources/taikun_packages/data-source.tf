@@ -0,0 +1,12 @@
+data "taikun_packages" "ingress" {
+  repository         = "taikun-managed-apps"
+  name_regex         = "^ingress-nginx$"
+  version_constraint = ">= 4.0, < 5.0"
+}
+
+resource "taikun_catalog_application" "ingress" {
+  catalog_id = taikun_catalog.foo.id
+  name       = data.taikun_packages.ingress.packages[0].name
+  repository = data.taikun_packages.ingress.packages[0].repository
+  version    = data.taikun_packages.ingress.packages[0].matching_version
+}
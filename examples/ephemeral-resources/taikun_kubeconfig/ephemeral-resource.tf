@@ -0,0 +1,23 @@
+# Requires Terraform 1.10 or later, the kubeconfig is never written to the plan or the state
+ephemeral "taikun_kubeconfig" "admin" {
+  project_id = "1234"
+
+  role            = "cluster-admin"
+  validity_period = 30 # minutes, the kubeconfig is deleted at the end of the run anyway
+}
+
+locals {
+  kubeconfig = provider::taikun::parse_kubeconfig(ephemeral.taikun_kubeconfig.admin.content)
+}
+
+provider "kubernetes" {
+  host                   = local.kubeconfig.host
+  cluster_ca_certificate = local.kubeconfig.cluster_ca_certificate
+  token                  = local.kubeconfig.token
+}
+
+# Fetch an existing kubeconfig instead of creating one
+ephemeral "taikun_kubeconfig" "existing" {
+  project_id = "1234"
+  id         = "42"
+}
@@ -0,0 +1,4 @@
+# Requires Terraform 1.8 or later
+output "continent" {
+  value = provider::taikun::continent_shorthand("Europe") # "eu"
+}
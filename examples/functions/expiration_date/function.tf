@@ -0,0 +1,8 @@
+# Requires Terraform 1.8 or later
+resource "taikun_project" "foo" {
+  name                = "foo"
+  cloud_credential_id = "1234"
+
+  # Expires 30 days after the plan, plantimestamp() keeps the date stable between the plan and the apply
+  expiration_date = provider::taikun::expiration_date("30d", plantimestamp())
+}
@@ -0,0 +1,15 @@
+# Requires Terraform 1.8 or later
+ephemeral "taikun_kubeconfig" "admin" {
+  project_id = "1234"
+  role       = "cluster-admin"
+}
+
+locals {
+  kubeconfig = provider::taikun::parse_kubeconfig(ephemeral.taikun_kubeconfig.admin.content)
+}
+
+provider "kubernetes" {
+  host                   = local.kubeconfig.host
+  cluster_ca_certificate = local.kubeconfig.cluster_ca_certificate
+  token                  = local.kubeconfig.token
+}
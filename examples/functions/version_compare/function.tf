@@ -0,0 +1,9 @@
+# Requires Terraform 1.8 or later
+variable "kubernetes_version" {
+  type = string
+
+  validation {
+    condition     = provider::taikun::version_compare(var.kubernetes_version, "v1.28.0") >= 0
+    error_message = "Kubernetes 1.28 or later is required."
+  }
+}
@@ -0,0 +1,14 @@
+provider "taikun" {
+  alias                   = "customer_a"
+  default_organization_id = "42" # Can be set with env var TAIKUN_DEFAULT_ORGANIZATION_ID
+}
+
+# Created in organization 42
+resource "taikun_slack_configuration" "alerts" {
+  provider = taikun.customer_a
+
+  name    = "alerts"
+  channel = "alerts"
+  url     = "https://hooks.myapp.example/alerts"
+  type    = "Alert"
+}
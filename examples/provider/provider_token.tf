@@ -0,0 +1,19 @@
+# Short-lived token written by the CI system
+provider "taikun" {
+  token_file = "/var/run/secrets/taikun/token" # Can be set with env var TAIKUN_TOKEN_FILE
+}
+
+# Token minted by an OIDC identity provider
+provider "taikun" {
+  alias              = "oidc"
+  oidc_token_url     = "https://idp.example/realms/ci/protocol/openid-connect/token" # Can be set with env var TAIKUN_OIDC_TOKEN_URL
+  oidc_client_id     = "terraform"                                                   # Can be set with env var TAIKUN_OIDC_CLIENT_ID
+  oidc_client_secret = "asdfasdf"                                                    # Can be set with env var TAIKUN_OIDC_CLIENT_SECRET
+}
+
+# Robot user created with taikun_robot
+provider "taikun" {
+  alias      = "robot"
+  access_key = taikun_robot.ci.access_key
+  secret_key = taikun_robot.ci.secret_key
+}
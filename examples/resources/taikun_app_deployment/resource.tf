@@ -0,0 +1,38 @@
+resource "taikun_catalog" "foo" {
+  name        = "new-catalog"
+  description = "Created by Terraform"
+  projects    = ["37415", "37416", "37417"]
+
+  application {
+    name       = "ingress-nginx"
+    repository = "taikun-managed-apps"
+  }
+}
+
+resource "taikun_app_deployment" "ingress" {
+  name           = "ingress"
+  namespace      = "ingress-nginx"
+  catalog_app_id = local.app_id
+  project_ids    = ["37415", "37416", "37417"]
+  parallelism    = 3
+
+  values = yamlencode({
+    controller = {
+      replicaCount = 2
+    }
+  })
+}
+
+resource "taikun_app_deployment" "staging" {
+  name           = "ingress-staging"
+  namespace      = "ingress-nginx"
+  catalog_app_id = local.app_id
+
+  project_selector {
+    name_regex = "^staging-"
+  }
+}
+
+locals {
+  app_id = [for app in tolist(taikun_catalog.foo.application) : app.id if app.name == "ingress-nginx" && app.repository == "taikun-managed-apps"][0]
+}
@@ -0,0 +1,5 @@
+# Import with the ID of the application instance
+terraform import taikun_app_instance.myappinstance 42
+
+# Or with the ID of its project and its name
+terraform import taikun_app_instance.myappinstance 42/my-app-instance
@@ -6,6 +6,7 @@ resource "taikun_catalog" "foo" {
   application {
     name       = "wordpress"
     repository = "taikun-managed-apps"
+    version    = "24.1.7"
   }
 }
 
@@ -14,6 +15,23 @@ resource "taikun_app_instance" "foo" {
   namespace      = "wordpress01-ns"
   project_id     = "37415"
   catalog_app_id = local.app_id
+  version        = "24.1.7"
+
+  values = yamlencode({
+    wordpressUsername = "admin"
+    service = {
+      type = "ClusterIP"
+    }
+  })
+
+  sensitive_values = yamlencode({
+    wordpressPassword = var.wordpress_password
+  })
+}
+
+variable "wordpress_password" {
+  type      = string
+  sensitive = true
 }
 
 
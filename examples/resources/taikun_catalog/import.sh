@@ -0,0 +1,5 @@
+# Import with the ID of the catalog
+terraform import taikun_catalog.mycatalog 42
+
+# Or with the ID of its organization and its name
+terraform import taikun_catalog.mycatalog 42/my-catalog
@@ -6,5 +6,6 @@ resource "taikun_catalog" "foo" {
   application {
     name       = "wordpress"
     repository = "taikun-managed-apps"
+    version    = "24.1.7"
   }
 }
\ No newline at end of file
@@ -0,0 +1,5 @@
+# Import with the ID of the catalog application
+terraform import taikun_catalog_application.wordpress 42
+
+# Or with the ID of its catalog, the name of its repository and its name
+terraform import taikun_catalog_application.wordpress 42/taikun-managed-apps/wordpress
@@ -0,0 +1,18 @@
+resource "taikun_catalog" "shared" {
+  name                  = "shared-catalog"
+  description           = "Applications are published by each team"
+  external_applications = true
+}
+
+resource "taikun_catalog_application" "wordpress" {
+  catalog_id = taikun_catalog.shared.id
+  name       = "wordpress"
+  repository = "taikun-managed-apps"
+  version    = "24.1.7"
+  lock       = true
+
+  parameter {
+    key   = "service.type"
+    value = "ClusterIP"
+  }
+}
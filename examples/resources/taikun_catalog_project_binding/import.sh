@@ -0,0 +1,2 @@
+# Import with the ID of the catalog and the ID of the project
+terraform import taikun_catalog_project_binding.mybinding 42/43
@@ -0,0 +1 @@
+terraform import taikun_cloud_credential_aws.myawscredential 42
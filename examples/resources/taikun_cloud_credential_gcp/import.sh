@@ -0,0 +1 @@
+terraform import taikun_cloud_credential_gcp.mygcpcredential 42
@@ -0,0 +1,5 @@
+# Import with the ID of the Taikun organization and the name of the repository
+terraform import taikun_repository.myrepository 42/taikun-managed-apps
+
+# If several organizations of the registry have a repository with this name, add the organization of the registry
+terraform import taikun_repository.myrepository 42/taikun/taikun-managed-apps
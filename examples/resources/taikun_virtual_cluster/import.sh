@@ -1 +1,2 @@
-terraform import taikun_virtual_cluster.myvirtualcluster 42
+# Import with the ID of the virtual cluster project, prefixed with the ID of its parent project
+terraform import taikun_virtual_cluster.myvirtualcluster 42/43
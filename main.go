@@ -1,8 +1,11 @@
 package main
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 	"github.com/itera-io/terraform-provider-taikun/taikun/provider"
 )
 
@@ -20,10 +23,21 @@ import (
 //go:generate ./scripts/docs_cleanup.sh
 
 func main() {
-	plugin.Serve(
-		&plugin.ServeOpts{
-			ProviderFunc: func() *schema.Provider {
-				return provider.Provider()
-			},
-		})
+	ctx := context.Background()
+
+	// The SDKv2 and plugin framework providers are served together as a single provider
+	muxServer, err := provider.NewMuxServer(ctx, provider.Provider())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = tf5server.Serve(
+		"registry.terraform.io/itera-io/taikun",
+		func() tfprotov5.ProviderServer {
+			return muxServer
+		},
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
 }
@@ -53,7 +53,7 @@ func dataSourceTaikunAccessProfilesRead(ctx context.Context, d *schema.ResourceD
 	for {
 		response, res, err := params.Offset(offset).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		accessProfilesList = append(accessProfilesList, response.Data...)
 		if len(accessProfilesList) == int(response.GetTotalCount()) {
@@ -67,7 +67,7 @@ func dataSourceTaikunAccessProfilesRead(ctx context.Context, d *schema.ResourceD
 
 		sshResponse, res, err := apiClient.Client.SshUsersAPI.SshusersList(ctx, rawAccessProfile.GetId()).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 
 		accessProfiles[i] = flattenTaikunAccessProfile(&rawAccessProfile, sshResponse)
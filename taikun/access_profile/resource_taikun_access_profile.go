@@ -202,6 +202,7 @@ func ResourceTaikunAccessProfile() *schema.Resource {
 		UpdateContext: resourceTaikunAccessProfileUpdate,
 		DeleteContext: resourceTaikunAccessProfileDelete,
 		Schema:        resourceTaikunAccessProfileSchema(),
+		CustomizeDiff: utils.SetDefaultOrganizationID,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -230,7 +231,7 @@ func resourceTaikunAccessProfileCreate(ctx context.Context, d *schema.ResourceDa
 
 	response, res, err := apiClient.Client.AccessProfilesAPI.AccessprofilesCreate(ctx).CreateAccessProfileCommand(*body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	id, _ := utils.Atoi32(response.GetId())
@@ -239,7 +240,7 @@ func resourceTaikunAccessProfileCreate(ctx context.Context, d *schema.ResourceDa
 
 	err = resourceTaikunAccessProfileCreateLock(ctx, d, id, apiClient)
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	return utils.ReadAfterCreateWithRetries(generateResourceTaikunAccessProfileReadWithRetries(), ctx, d, meta)
@@ -333,20 +334,16 @@ func generateResourceTaikunAccessProfileRead(withRetries bool) schema.ReadContex
 		id, err := utils.Atoi32(d.Id())
 		d.SetId("")
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		response, res, err := apiClient.Client.AccessProfilesAPI.AccessprofilesList(ctx).Id(id).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 
 		if len(response.Data) != 1 {
-			if withRetries {
-				d.SetId(utils.I32toa(id))
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-			}
-			return nil
+			return utils.ReadNotFound(d, utils.I32toa(id), withRetries)
 		}
 
 		sshResponse, res, err := apiClient.Client.SshUsersAPI.SshusersList(ctx, id).Execute()
@@ -357,14 +354,14 @@ func generateResourceTaikunAccessProfileRead(withRetries bool) schema.ReadContex
 					return diag.Errorf(notFoundAfterCreateOrUpdateError)
 				}
 			*/
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 
 		rawAccessProfile := response.Data[0]
 
 		err = utils.SetResourceDataFromMap(d, flattenTaikunAccessProfile(&rawAccessProfile, sshResponse))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		d.SetId(utils.I32toa(id))
@@ -382,42 +379,42 @@ func resourceTaikunAccessProfileUpdate(ctx context.Context, d *schema.ResourceDa
 
 	id, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	if isLocked, _ := d.GetChange("lock"); isLocked.(bool) {
 		if err := resourceTaikunAccessProfileLock(ctx, id, false, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
 	if err := resourceTaikunAccessProfileUpdateHttpProxy(ctx, d, id, apiClient); err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	if err := resourceTaikunAccessProfileUpdateAllowedHosts(ctx, d, id, apiClient); err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	if err := resourceTaikunAccessProfileUpdateDnsServers(ctx, d, id, apiClient); err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	if err := resourceTaikunAccessProfileUpdateNtpServers(ctx, d, id, apiClient); err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	if err := resourceTaikunAccessProfileUpdateSshUsers(ctx, d, id, apiClient); err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	if err := resourceTaikunAccessProfileUpdateTrustedRegistries(ctx, d, id, apiClient); err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	if d.Get("lock").(bool) {
 		if err := resourceTaikunAccessProfileLock(ctx, id, true, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -438,7 +435,7 @@ func resourceTaikunAccessProfileUpdateHttpProxy(ctx context.Context, d *schema.R
 		}
 		res, newErr := apiClient.Client.AccessProfilesAPI.AccessprofilesUpdate(ctx, id).UpdateAccessProfileDto(body).Execute()
 		if newErr != nil {
-			return tk.CreateError(res, newErr)
+			return utils.NewApiError(res, newErr)
 		}
 	}
 	return err
@@ -457,7 +454,7 @@ func resourceTaikunAccessProfileUpdateAllowedHosts(ctx context.Context, d *schem
 		oldAllowedHost := rawOldAllowedHost.(map[string]interface{})
 		id, _ := utils.Atoi32(oldAllowedHost["id"].(string))
 		if res, err := apiClient.Client.AllowedHostAPI.AllowedhostDelete(ctx, id).Execute(); err != nil {
-			err = tk.CreateError(res, err)
+			err = utils.NewApiError(res, err)
 			return err
 		}
 	}
@@ -473,7 +470,7 @@ func resourceTaikunAccessProfileUpdateAllowedHosts(ctx context.Context, d *schem
 		body.SetMaskBits(int32(newAllowedHost["mask_bits"].(int)))
 
 		if _, res, err := apiClient.Client.AllowedHostAPI.AllowedhostCreate(ctx).CreateAllowedHostCommand(body).Execute(); err != nil {
-			err = tk.CreateError(res, err)
+			err = utils.NewApiError(res, err)
 			return err
 		}
 	}
@@ -494,7 +491,7 @@ func resourceTaikunAccessProfileUpdateDnsServers(ctx context.Context, d *schema.
 		oldDnsServer := rawOldDnsServer.(map[string]interface{})
 		id, _ := utils.Atoi32(oldDnsServer["id"].(string))
 		if res, err2 := apiClient.Client.DnsServersAPI.DnsserversDelete(ctx, id).Execute(); err2 != nil {
-			err = tk.CreateError(res, err2)
+			err = utils.NewApiError(res, err2)
 			return err
 		}
 	}
@@ -527,7 +524,7 @@ func resourceTaikunAccessProfileUpdateNtpServers(ctx context.Context, d *schema.
 		oldNtpServer := rawOldNtpServer.(map[string]interface{})
 		id, _ := utils.Atoi32(oldNtpServer["id"].(string))
 		if res, err := apiClient.Client.NtpServersAPI.NtpserversDelete(ctx, id).Execute(); err != nil {
-			err = tk.CreateError(res, err)
+			err = utils.NewApiError(res, err)
 			return err
 		}
 	}
@@ -540,7 +537,7 @@ func resourceTaikunAccessProfileUpdateNtpServers(ctx context.Context, d *schema.
 		body.SetAccessProfileId(accessProfileId)
 		body.SetAddress(newNtpServer["address"].(string))
 		if _, res, err := apiClient.Client.NtpServersAPI.NtpserversCreate(ctx).CreateNtpServerCommand(body).Execute(); err != nil {
-			err = tk.CreateError(res, err)
+			err = utils.NewApiError(res, err)
 			return err
 		}
 	}
@@ -563,7 +560,7 @@ func resourceTaikunAccessProfileUpdateSshUsers(ctx context.Context, d *schema.Re
 		body := tkcore.DeleteSshUserCommand{}
 		body.SetId(id)
 		if res, err := apiClient.Client.SshUsersAPI.SshusersDelete(ctx).DeleteSshUserCommand(body).Execute(); err != nil {
-			err = tk.CreateError(res, err)
+			err = utils.NewApiError(res, err)
 			return err
 		}
 	}
@@ -578,7 +575,7 @@ func resourceTaikunAccessProfileUpdateSshUsers(ctx context.Context, d *schema.Re
 		body.SetSshPublicKey(newSshUser["public_key"].(string))
 
 		if _, res, err := apiClient.Client.SshUsersAPI.SshusersCreate(ctx).CreateSshUserCommand(body).Execute(); err != nil {
-			err = tk.CreateError(res, err)
+			err = utils.NewApiError(res, err)
 			return err
 		}
 	}
@@ -599,7 +596,7 @@ func resourceTaikunAccessProfileUpdateTrustedRegistries(ctx context.Context, d *
 		oldTrustedRegistry := rawOldTrustedRegistry.(map[string]interface{})
 		id, _ := utils.Atoi32(oldTrustedRegistry["id"].(string))
 		if res, err2 := apiClient.Client.TrustedRegistriesAPI.TrustedregistriesDelete(ctx, id).Execute(); err2 != nil {
-			err = tk.CreateError(res, err2)
+			err = utils.NewApiError(res, err2)
 			return err
 		}
 	}
@@ -612,7 +609,7 @@ func resourceTaikunAccessProfileUpdateTrustedRegistries(ctx context.Context, d *
 		body.SetAccessProfileId(accessProfileId)
 		body.SetRegistry(newTrustedRegistry["registry"].(string))
 		if _, res, err2 := apiClient.Client.TrustedRegistriesAPI.TrustedregistriesCreate(ctx).CreateTrustedRegistriesCommand(body).Execute(); err2 != nil {
-			err = tk.CreateError(res, err2)
+			err = utils.NewApiError(res, err2)
 			return err
 		}
 	}
@@ -624,12 +621,12 @@ func resourceTaikunAccessProfileDelete(ctx context.Context, d *schema.ResourceDa
 	apiClient := meta.(*tk.Client)
 	id, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	res, err := apiClient.Client.AccessProfilesAPI.AccessprofilesDelete(ctx, id).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	d.SetId("")
@@ -706,5 +703,5 @@ func resourceTaikunAccessProfileLock(ctx context.Context, id int32, lock bool, a
 	body.SetMode(utils.GetLockMode(lock))
 
 	res, err := apiClient.Client.AccessProfilesAPI.AccessprofilesLockManager(ctx).AccessProfilesLockManagementCommand(body).Execute()
-	return tk.CreateError(res, err)
+	return utils.NewApiError(res, err)
 }
@@ -0,0 +1,28 @@
+//go:build sweep
+
+package access_profile
+
+import (
+	"context"
+
+	tk "github.com/itera-io/taikungoclient"
+	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+)
+
+// Delete the access profiles left behind by the acceptance tests, once the projects using them are deleted
+func AddTestSweepers(client utils.SweeperClientFunc) {
+	utils.AddListTestSweeper("taikun_access_profile", client, []string{"taikun_project"},
+		func(ctx context.Context, apiClient *tk.Client, offset int32) ([]tkcore.AccessProfilesListDto, int, error) {
+			response, res, err := apiClient.Client.AccessProfilesAPI.AccessprofilesList(ctx).Offset(offset).Execute()
+			if err != nil {
+				return nil, 0, utils.NewApiError(res, err)
+			}
+			return response.GetData(), int(response.GetTotalCount()), nil
+		},
+		(*tkcore.AccessProfilesListDto).GetName,
+		func(ctx context.Context, apiClient *tk.Client, accessProfile *tkcore.AccessProfilesListDto) error {
+			return utils.SweepLockableResource(ctx, ResourceTaikunAccessProfile(), accessProfile.GetId(), accessProfile.GetIsLocked(), resourceTaikunAccessProfileLock, apiClient)
+		},
+	)
+}
@@ -74,7 +74,7 @@ func dataSourceTaikunAccountRead(ctx context.Context, d *schema.ResourceData, me
 
 	response, res, err := apiClient.Client.AccountsAPI.AccountsDetails(ctx, id).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	err = utils.SetResourceDataFromMap(d, flattenTaikunAccount(response))
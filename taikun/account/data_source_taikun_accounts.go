@@ -74,7 +74,7 @@ func dataSourceTaikunAccountsRead(ctx context.Context, d *schema.ResourceData, m
 
 		response, res, err := request.Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 
 		accounts = append(accounts, response.Data...)
@@ -87,7 +87,7 @@ func resourceTaikunAccountCreate(ctx context.Context, d *schema.ResourceData, me
 
 	id, res, err := apiClient.Client.AccountsAPI.AccountsCreate(ctx).CreateAccountCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	d.SetId(utils.I32toa(id))
@@ -113,14 +113,7 @@ func generateResourceTaikunAccountRead(withRetries bool) schema.ReadContextFunc
 
 		response, res, err := apiClient.Client.AccountsAPI.AccountsDetails(ctx, id).Execute()
 		if err != nil {
-			if res != nil && res.StatusCode == 404 {
-				if withRetries {
-					return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-				}
-				d.SetId("")
-				return nil
-			}
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.ReadApiError(d, d.Id(), withRetries, res, err)
 		}
 
 		err = utils.SetResourceDataFromMap(d, flattenTaikunAccount(response))
@@ -147,7 +140,7 @@ func resourceTaikunAccountUpdate(ctx context.Context, d *schema.ResourceData, me
 
 	_, res, err := apiClient.Client.AccountsAPI.AccountsUpdate(ctx).UpdateAccountCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	return utils.ReadAfterUpdateWithRetries(generateResourceTaikunAccountReadWithRetries(), ctx, d, meta)
@@ -165,7 +158,7 @@ func resourceTaikunAccountDelete(ctx context.Context, d *schema.ResourceData, me
 		if res != nil && res.StatusCode == 404 {
 			return nil
 		}
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	d.SetId("")
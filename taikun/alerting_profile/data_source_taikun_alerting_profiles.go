@@ -53,7 +53,7 @@ func dataSourceTaikunAlertingProfilesRead(ctx context.Context, d *schema.Resourc
 	for {
 		response, res, err := params.Offset(offset).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		alertingProfileDTOs = append(alertingProfileDTOs, response.Data...)
 		if len(alertingProfileDTOs) == int(response.GetTotalCount()) {
@@ -67,7 +67,7 @@ func dataSourceTaikunAlertingProfilesRead(ctx context.Context, d *schema.Resourc
 
 		alertingIntegrationsResponse, res, err := apiClient.Client.AlertingIntegrationsAPI.AlertingintegrationsList(ctx, alertingProfileDTO.GetId()).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 
 		alertingProfiles[i] = flattenTaikunAlertingProfile(&alertingProfileDTO, alertingIntegrationsResponse)
@@ -179,6 +179,7 @@ func ResourceTaikunAlertingProfile() *schema.Resource {
 		UpdateContext: resourceTaikunAlertingProfileUpdate,
 		DeleteContext: resourceTaikunAlertingProfileDelete,
 		Schema:        resourceTaikunAlertingProfileSchema(),
+		CustomizeDiff: utils.SetDefaultOrganizationID,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -201,7 +202,7 @@ func resourceTaikunAlertingProfileCreate(ctx context.Context, d *schema.Resource
 	if organizationIDData, organizationIDIsSet := d.GetOk("organization_id"); organizationIDIsSet {
 		organizationID, err := utils.Atoi32(organizationIDData.(string))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 		body.SetOrganizationId(organizationID)
 	}
@@ -213,7 +214,7 @@ func resourceTaikunAlertingProfileCreate(ctx context.Context, d *schema.Resource
 	if slackConfigIDData, slackConfigIDIsSet := d.GetOk("slack_configuration_id"); slackConfigIDIsSet {
 		slackConfigID, err := utils.Atoi32(slackConfigIDData.(string))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 		if slackConfigID != 0 {
 			body.SetSlackConfigurationId(slackConfigID)
@@ -230,18 +231,18 @@ func resourceTaikunAlertingProfileCreate(ctx context.Context, d *schema.Resource
 
 	response, bodyResponse, err := apiClient.Client.AlertingProfilesAPI.AlertingprofilesCreate(ctx).CreateAlertingProfileCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(bodyResponse, err))
+		return utils.DiagnosticsFromApiError(bodyResponse, err)
 	}
 	id, err := utils.Atoi32(response.GetId())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	d.SetId(response.GetId())
 
 	if d.Get("lock").(bool) {
 		if err := resourceTaikunAlertingProfileLock(ctx, id, true, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -260,30 +261,26 @@ func generateResourceTaikunAlertingProfileRead(withRetries bool) schema.ReadCont
 		id, err := utils.Atoi32(d.Id())
 		d.SetId("")
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		response, res, err := apiClient.Client.AlertingProfilesAPI.AlertingprofilesList(ctx).Id(id).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		if len(response.Data) != 1 {
-			if withRetries {
-				d.SetId(utils.I32toa(id))
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-			}
-			return nil
+			return utils.ReadNotFound(d, utils.I32toa(id), withRetries)
 		}
 		alertingProfileDTO := response.Data[0]
 
 		alertingIntegrationsResponse, res, err := apiClient.Client.AlertingIntegrationsAPI.AlertingintegrationsList(ctx, alertingProfileDTO.GetId()).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 
 		err = utils.SetResourceDataFromMap(d, flattenTaikunAlertingProfile(&alertingProfileDTO, alertingIntegrationsResponse))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		d.SetId(utils.I32toa(alertingProfileDTO.GetId()))
@@ -297,12 +294,12 @@ func resourceTaikunAlertingProfileUpdate(ctx context.Context, d *schema.Resource
 
 	id, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	if locked, _ := d.GetChange("lock"); locked.(bool) {
 		if err := resourceTaikunAlertingProfileLock(ctx, id, false, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -317,7 +314,7 @@ func resourceTaikunAlertingProfileUpdate(ctx context.Context, d *schema.Resource
 		if slackConfigIDData, slackConfigIDIsSet := d.GetOk("slack_configuration_id"); slackConfigIDIsSet {
 			slackConfigID, err := utils.Atoi32(slackConfigIDData.(string))
 			if err != nil {
-				return diag.FromErr(err)
+				return utils.DiagnosticsFromError(err)
 			}
 			if slackConfigID != 0 {
 				body.SetSlackConfigurationId(slackConfigID)
@@ -326,7 +323,7 @@ func resourceTaikunAlertingProfileUpdate(ctx context.Context, d *schema.Resource
 
 		_, res, err := apiClient.Client.AlertingProfilesAPI.AlertingprofilesEdit(ctx).UpdateAlertingProfileCommand(body).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 	}
 
@@ -334,7 +331,7 @@ func resourceTaikunAlertingProfileUpdate(ctx context.Context, d *schema.Resource
 		alertEmails := getEmailDTOsFromAlertingProfileResourceData(d)
 		res, err := apiClient.Client.AlertingProfilesAPI.AlertingprofilesAssignEmail(ctx, id).AlertingEmailDto(alertEmails).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 	}
 
@@ -342,17 +339,17 @@ func resourceTaikunAlertingProfileUpdate(ctx context.Context, d *schema.Resource
 		webhooks := getWebhookDTOsFromAlertingProfileResourceData(d)
 		res, err := apiClient.Client.AlertingProfilesAPI.AlertingprofilesAssignWebhooks(ctx, id).AlertingWebhookDto(webhooks).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 	}
 
 	if err := resourceTaikunAlertingProfileUpdateIntegrations(ctx, d, id, apiClient); err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	if d.Get("lock").(bool) {
 		if err := resourceTaikunAlertingProfileLock(ctx, id, true, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -372,7 +369,7 @@ func resourceTaikunAlertingProfileUpdateIntegrations(ctx context.Context, d *sch
 		oldIntegrationID, _ := utils.Atoi32(oldIntegration["id"].(string))
 		res, err := apiClient.Client.AlertingIntegrationsAPI.AlertingintegrationsDelete(ctx, oldIntegrationID).Execute()
 		if err != nil {
-			err = tk.CreateError(res, err)
+			err = utils.NewApiError(res, err)
 			return err
 		}
 	}
@@ -389,7 +386,7 @@ func resourceTaikunAlertingProfileUpdateIntegrations(ctx context.Context, d *sch
 
 			_, res, err := apiClient.Client.AlertingIntegrationsAPI.AlertingintegrationsCreate(ctx).CreateAlertingIntegrationCommand(alertingIntegrationCreateBody).Execute()
 			if err != nil {
-				err = tk.CreateError(res, err)
+				err = utils.NewApiError(res, err)
 				return err
 			}
 		}
@@ -402,11 +399,11 @@ func resourceTaikunAlertingProfileDelete(ctx context.Context, d *schema.Resource
 
 	id, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	if res, err := apiClient.Client.AlertingProfilesAPI.AlertingprofilesDelete(ctx, id).Execute(); err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	d.SetId("")
@@ -537,5 +534,5 @@ func resourceTaikunAlertingProfileLock(ctx context.Context, id int32, lock bool,
 	body.SetMode(utils.GetLockMode(lock))
 
 	res, err := apiClient.Client.AlertingProfilesAPI.AlertingprofilesLockManager(ctx).AlertingProfilesLockManagerCommand(body).Execute()
-	return tk.CreateError(res, err)
+	return utils.NewApiError(res, err)
 }
@@ -0,0 +1,28 @@
+//go:build sweep
+
+package alerting_profile
+
+import (
+	"context"
+
+	tk "github.com/itera-io/taikungoclient"
+	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+)
+
+// Delete the alerting profiles left behind by the acceptance tests, once the projects using them are deleted
+func AddTestSweepers(client utils.SweeperClientFunc) {
+	utils.AddListTestSweeper("taikun_alerting_profile", client, []string{"taikun_project"},
+		func(ctx context.Context, apiClient *tk.Client, offset int32) ([]tkcore.AlertingProfilesListDto, int, error) {
+			response, res, err := apiClient.Client.AlertingProfilesAPI.AlertingprofilesList(ctx).Offset(offset).Execute()
+			if err != nil {
+				return nil, 0, utils.NewApiError(res, err)
+			}
+			return response.GetData(), int(response.GetTotalCount()), nil
+		},
+		(*tkcore.AlertingProfilesListDto).GetName,
+		func(ctx context.Context, apiClient *tk.Client, alertingProfile *tkcore.AlertingProfilesListDto) error {
+			return utils.SweepLockableResource(ctx, ResourceTaikunAlertingProfile(), alertingProfile.GetId(), alertingProfile.GetIsLocked(), resourceTaikunAlertingProfileLock, apiClient)
+		},
+	)
+}
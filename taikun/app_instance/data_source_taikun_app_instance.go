@@ -9,6 +9,7 @@ import (
 
 func dataSourceTaikunAppInstanceSchema() map[string]*schema.Schema {
 	dsSchema := utils.DataSourceSchemaFromResourceSchema(resourceTaikunAppInstanceSchema())
+	utils.DeleteFieldsFromSchema(dsSchema, "sensitive_values", "sensitive_values_wo", "sensitive_values_wo_version", "sensitive_value_keys", "sync_trigger", "rollback_to_revision", "rolled_back_values")
 	utils.AddRequiredFieldsToSchema(dsSchema, "id")
 	utils.SetValidateDiagFuncToSchema(dsSchema, "id", utils.StringIsInt)
 	return dsSchema
@@ -52,7 +52,7 @@ func dataSourceTaikunAppInstancesRead(ctx context.Context, d *schema.ResourceDat
 	for {
 		response, res, err := params.Offset(offset).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		appInstancesList = append(appInstancesList, response.Data...)
 		if len(appInstancesList) == int(response.GetTotalCount()) {
@@ -66,11 +66,11 @@ func dataSourceTaikunAppInstancesRead(ctx context.Context, d *schema.ResourceDat
 
 		data, response, err := apiClient.Client.ProjectAppsAPI.ProjectappDetails(ctx, rawAppInstance.GetId()).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(response, err))
+			return utils.DiagnosticsFromApiError(response, err)
 		}
 
 		// We have no idea if the user originally set with file or base64 literal, so we just display base64 either way
-		appInstances[i] = flattenTaikunAppInstance(false, data)
+		appInstances[i] = flattenTaikunAppInstance("parameters_base64", data)
 	}
 	if err := d.Set("application_instances", appInstances); err != nil {
 		return diag.FromErr(err)
@@ -0,0 +1,657 @@
+package app_instance
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	tk "github.com/itera-io/taikungoclient"
+	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+)
+
+func resourceTaikunAppDeploymentSchema() map[string]*schema.Schema {
+	// The application is deployed in each project like taikun_app_instance deploys it in one
+	appInstanceSchema := resourceTaikunAppInstanceSchema()
+	for _, key := range []string{"values", "values_files"} {
+		appInstanceSchema[key].ConflictsWith = nil
+		appInstanceSchema[key].DiffSuppressFunc = nil
+	}
+	appInstanceSchema["name"].Description = "The name of the application instance in each project."
+	appInstanceSchema["merged_values"].Description = "The values of the application as canonical YAML, read from Taikun. The plan shows the values which will be sent, read from `values` and `values_files`."
+
+	resourceSchema := map[string]*schema.Schema{
+		"id": {
+			Description: "The ID of the deployment, catalog_app_id/namespace/name.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"project_ids": {
+			Description: "The IDs of the projects where the application is deployed. Found with `project_selector` if it is set.",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: utils.StringIsInt,
+			},
+			ConflictsWith: []string{"project_selector"},
+			AtLeastOneOf:  []string{"project_ids", "project_selector"},
+		},
+		"project_selector": {
+			Description: "Deploy the application in the projects selected by their name, the projects created later are added on the next apply.",
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name_regex": {
+						Description:  "The projects whose name matches this regular expression.",
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringIsValidRegExp,
+					},
+					"organization_id": {
+						Description:      "The projects of this organization only.",
+						Type:             schema.TypeString,
+						Optional:         true,
+						ValidateDiagFunc: utils.StringIsInt,
+					},
+				},
+			},
+		},
+		"parallelism": {
+			Description:  "The number of projects in which the application is installed, synced or uninstalled at the same time.",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      5,
+			ValidateFunc: validation.IntBetween(1, 50),
+		},
+		"project": {
+			Description: "The application instance deployed in each project.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"project_id": {
+						Description: "The ID of the project.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"app_instance_id": {
+						Description: "The ID of the application instance in the project.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"status": {
+						Description: "The status of the application instance.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+	for _, key := range []string{"name", "namespace", "catalog_app_id", "values", "values_files", "merged_values", "autosync", "wait_for", "timeout"} {
+		resourceSchema[key] = appInstanceSchema[key]
+	}
+	return resourceSchema
+}
+
+func ResourceTaikunAppDeployment() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Taikun Application deployed in several projects.",
+		CreateContext: resourceTaikunAppDeploymentCreate,
+		ReadContext:   generateResourceTaikunAppDeploymentReadWithoutRetries(),
+		UpdateContext: resourceTaikunAppDeploymentUpdate,
+		DeleteContext: resourceTaikunAppDeploymentDelete,
+		Schema:        resourceTaikunAppDeploymentSchema(),
+		CustomizeDiff: customdiff.All(
+			customizeDiffMergedValues,
+			customizeDiffAppDeploymentProjects,
+		),
+	}
+}
+
+// Find the projects of project_selector, then plan the application instances again if anything they depend on changes
+func customizeDiffAppDeploymentProjects(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if selectors := d.Get("project_selector").([]interface{}); len(selectors) == 1 && selectors[0] != nil {
+		rawSelector := d.GetRawConfig().GetAttr("project_selector")
+		if !rawSelector.IsWhollyKnown() {
+			return d.SetNewComputed("project_ids")
+		}
+
+		projectIds, err := selectAppDeploymentProjects(ctx, meta.(*tk.Client), selectors[0].(map[string]interface{}))
+		if err != nil {
+			return err
+		}
+		if !d.Get("project_ids").(*schema.Set).Equal(schema.NewSet(schema.HashString, projectIds)) {
+			if err := d.SetNew("project_ids", projectIds); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.Id() != "" && (d.HasChanges("project_ids", "merged_values", "autosync") || appDeploymentHasRemovedInstances(d)) {
+		return d.SetNewComputed("project")
+	}
+	return nil
+}
+
+// Whether application instances are left in projects which are not in project_ids, such as a failed project removed from the configuration
+func appDeploymentHasRemovedInstances(d *schema.ResourceDiff) bool {
+	if !d.NewValueKnown("project_ids") {
+		return false
+	}
+	projectIds := d.Get("project_ids").(*schema.Set)
+	for _, project := range d.Get("project").([]interface{}) {
+		if !projectIds.Contains(project.(map[string]interface{})["project_id"].(string)) {
+			return true
+		}
+	}
+	return false
+}
+
+func selectAppDeploymentProjects(ctx context.Context, apiClient *tk.Client, selector map[string]interface{}) ([]interface{}, error) {
+	nameRegex := regexp.MustCompile(selector["name_regex"].(string))
+	params := apiClient.Client.ProjectsAPI.ProjectsList(ctx)
+	if organizationId := selector["organization_id"].(string); organizationId != "" {
+		orgId, err := utils.Atoi32(organizationId)
+		if err != nil {
+			return nil, err
+		}
+		params = params.OrganizationId(orgId)
+	}
+
+	projectIds := make([]interface{}, 0)
+	var offset int32 = 0
+	for {
+		response, res, err := params.Offset(offset).Execute()
+		if err != nil {
+			return nil, utils.NewApiError(res, err)
+		}
+		for _, project := range response.GetData() {
+			if nameRegex.MatchString(project.GetName()) {
+				projectIds = append(projectIds, utils.I32toa(project.GetId()))
+			}
+		}
+		offset += int32(len(response.GetData()))
+		if len(response.GetData()) == 0 || int(offset) >= int(response.GetTotalCount()) {
+			break
+		}
+	}
+	return projectIds, nil
+}
+
+// Application instance deployed in a project
+type appDeploymentInstance struct {
+	projectId int32
+	appId     int32
+	status    string
+}
+
+// Run f in each project, at most parallelism at the same time, and return the errors by project
+func forEachAppDeploymentProject(ctx context.Context, projectIds []int32, parallelism int, f func(projectId int32) error) map[int32]error {
+	errs := make(map[int32]error)
+	var mutex sync.Mutex
+	var waitGroup sync.WaitGroup
+	semaphore := make(chan struct{}, parallelism)
+
+	for _, projectId := range projectIds {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			var err error
+			select {
+			case semaphore <- struct{}{}:
+				err = f(projectId)
+				<-semaphore
+			case <-ctx.Done():
+				err = ctx.Err()
+			}
+			if err != nil {
+				mutex.Lock()
+				errs[projectId] = err
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	waitGroup.Wait()
+	return errs
+}
+
+// One diagnostic by project, sorted by project ID, with the given severity
+func appDeploymentDiagnostics(errs map[int32]error, severity diag.Severity) diag.Diagnostics {
+	projectIds := make([]int32, 0, len(errs))
+	for projectId := range errs {
+		projectIds = append(projectIds, projectId)
+	}
+	sort.Slice(projectIds, func(i, j int) bool { return projectIds[i] < projectIds[j] })
+
+	var diagnostics diag.Diagnostics
+	for _, projectId := range projectIds {
+		for _, diagnostic := range appInstanceDiagnostics(errs[projectId]) {
+			diagnostic.Severity = severity
+			diagnostic.Summary = fmt.Sprintf("project %d: %s", projectId, diagnostic.Summary)
+			diagnostics = append(diagnostics, diagnostic)
+		}
+	}
+	return diagnostics
+}
+
+// Diagnostics of the projects where the operation failed.
+// They are warnings as long as the application is deployed in one project, so that the deployment is not tainted and
+// replaced in every project because of a single one. The failed projects are left out of project_ids, the next apply
+// installs the application there again.
+func appDeploymentFailureDiagnostics(errs map[int32]error, instances map[int32]*appDeploymentInstance) diag.Diagnostics {
+	if len(errs) == 0 {
+		return nil
+	}
+	for _, instance := range instances {
+		if instance.status != string(tkcore.EINSTANCESTATUS_FAILURE) {
+			return appDeploymentDiagnostics(errs, diag.Warning)
+		}
+	}
+	return appDeploymentDiagnostics(errs, diag.Error)
+}
+
+// Application instances of the state by project
+func appDeploymentInstancesFromState(projects interface{}) (map[int32]*appDeploymentInstance, error) {
+	instances := make(map[int32]*appDeploymentInstance)
+	for _, project := range projects.([]interface{}) {
+		projectMap := project.(map[string]interface{})
+		projectId, err := utils.Atoi32(projectMap["project_id"].(string))
+		if err != nil {
+			return nil, err
+		}
+		appId, err := utils.Atoi32(projectMap["app_instance_id"].(string))
+		if err != nil {
+			return nil, err
+		}
+		instances[projectId] = &appDeploymentInstance{projectId: projectId, appId: appId, status: projectMap["status"].(string)}
+	}
+	return instances, nil
+}
+
+func setAppDeploymentInstances(d *schema.ResourceData, instances map[int32]*appDeploymentInstance) error {
+	projectIds := make([]int32, 0, len(instances))
+	for projectId := range instances {
+		projectIds = append(projectIds, projectId)
+	}
+	sort.Slice(projectIds, func(i, j int) bool { return projectIds[i] < projectIds[j] })
+
+	projects := make([]map[string]interface{}, 0, len(projectIds))
+	projectIdStrings := make([]string, 0, len(projectIds))
+	for _, projectId := range projectIds {
+		instance := instances[projectId]
+		projects = append(projects, map[string]interface{}{
+			"project_id":      utils.I32toa(projectId),
+			"app_instance_id": utils.I32toa(instance.appId),
+			"status":          instance.status,
+		})
+		// A failed application instance is installed again on the next apply
+		if instance.status != string(tkcore.EINSTANCESTATUS_FAILURE) {
+			projectIdStrings = append(projectIdStrings, utils.I32toa(projectId))
+		}
+	}
+
+	if err := d.Set("project", projects); err != nil {
+		return err
+	}
+	return d.Set("project_ids", projectIdStrings)
+}
+
+func getAppDeploymentProjectIds(d *schema.ResourceData) ([]int32, error) {
+	return utils.SliceOfSTringsToSliceOfInt32(d.Get("project_ids").(*schema.Set).List())
+}
+
+// Install the application in the project, in place of the application instance which failed there if any
+func installTaikunAppDeploymentInstance(ctx context.Context, apiClient *tk.Client, meta interface{}, projectId int32, failedAppId int32, body tkcore.CreateProjectAppCommand, wait appInstanceWait) (int32, error) {
+	unlock, err := utils.LockProject(ctx, meta, projectId)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	if failedAppId != 0 {
+		if err := uninstallTaikunAppDeploymentInstance(ctx, apiClient, failedAppId, wait); err != nil {
+			return 0, err
+		}
+	}
+
+	body.SetProjectId(projectId)
+	data, response, err := apiClient.Client.ProjectAppsAPI.ProjectappInstall(ctx).CreateProjectAppCommand(body).Execute()
+	if err != nil {
+		return 0, utils.NewApiError(response, err)
+	}
+	appId, err := utils.Atoi32(data.GetId())
+	if err != nil {
+		return 0, err
+	}
+
+	return appId, waitForAppInstanceReady(ctx, apiClient, appId, wait)
+}
+
+func uninstallTaikunAppDeploymentInstance(ctx context.Context, apiClient *tk.Client, appId int32, wait appInstanceWait) error {
+	_, response, err := apiClient.Client.ProjectAppsAPI.ProjectappDelete(ctx, appId).Execute()
+	if err != nil {
+		if utils.IsNotFound(response) {
+			return nil
+		}
+		return utils.NewApiError(response, err)
+	}
+	return waitForAppInstanceDeleted(ctx, apiClient, appId, wait)
+}
+
+// Install the application in the projects which do not have it, at most parallelism at the same time
+func installTaikunAppDeployment(ctx context.Context, d *schema.ResourceData, meta interface{}, projectIds []int32, instances map[int32]*appDeploymentInstance) (map[int32]error, error) {
+	apiClient := meta.(*tk.Client)
+
+	catalogAppId, err := utils.Atoi32(d.Get("catalog_app_id").(string))
+	if err != nil {
+		return nil, err
+	}
+	extraValues, err := mergedValuesBase64(d)
+	if err != nil {
+		return nil, err
+	}
+
+	body := tkcore.CreateProjectAppCommand{}
+	body.SetName(d.Get("name").(string))
+	body.SetCatalogAppId(catalogAppId)
+	body.SetNamespace(d.Get("namespace").(string))
+	body.SetExtraValues(extraValues)
+	body.SetAutoSync(d.Get("autosync").(bool))
+	body.SetTaikunLinkEnabled(false)
+	body.SetTimeout(int32(d.Get("timeout").(int)))
+	wait := appInstanceWaitFromResourceData(d)
+
+	var mutex sync.Mutex
+	errs := forEachAppDeploymentProject(ctx, projectIds, d.Get("parallelism").(int), func(projectId int32) error {
+		var failedAppId int32
+		mutex.Lock()
+		if instance, found := instances[projectId]; found {
+			failedAppId = instance.appId
+		}
+		mutex.Unlock()
+
+		appId, err := installTaikunAppDeploymentInstance(ctx, apiClient, meta, projectId, failedAppId, body, wait)
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		switch {
+		case appId != 0 && err != nil:
+			instances[projectId] = &appDeploymentInstance{projectId: projectId, appId: appId, status: string(tkcore.EINSTANCESTATUS_FAILURE)}
+		case appId != 0:
+			instances[projectId] = &appDeploymentInstance{projectId: projectId, appId: appId, status: string(tkcore.EINSTANCESTATUS_READY)}
+		}
+		// Otherwise the failed application instance is kept, the next read drops it if it was uninstalled
+		return err
+	})
+	return errs, nil
+}
+
+func resourceTaikunAppDeploymentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	projectIds, err := getAppDeploymentProjectIds(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	instances := make(map[int32]*appDeploymentInstance)
+	errs, err := installTaikunAppDeployment(ctx, d, meta, projectIds, instances)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	diagnostics := appDeploymentFailureDiagnostics(errs, instances)
+	if len(instances) == 0 {
+		// Nothing to keep track of, not even a failed application instance
+		return diagnostics
+	}
+
+	d.SetId(strings.Join([]string{d.Get("catalog_app_id").(string), d.Get("namespace").(string), d.Get("name").(string)}, "/"))
+	if err := setAppDeploymentInstances(d, instances); err != nil {
+		return diag.FromErr(err)
+	}
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+
+	return append(diagnostics, utils.ReadAfterCreateWithRetries(generateResourceTaikunAppDeploymentReadWithRetries(), ctx, d, meta)...)
+}
+
+func generateResourceTaikunAppDeploymentReadWithRetries() schema.ReadContextFunc {
+	return generateResourceTaikunAppDeploymentRead(true)
+}
+func generateResourceTaikunAppDeploymentReadWithoutRetries() schema.ReadContextFunc {
+	return generateResourceTaikunAppDeploymentRead(false)
+}
+
+func generateResourceTaikunAppDeploymentRead(withRetries bool) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		apiClient := meta.(*tk.Client)
+		instances, err := appDeploymentInstancesFromState(d.Get("project"))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		// The merged values of the first project whose values differ from the state, so that drift in any project shows
+		mergedValues := d.Get("merged_values").(string)
+		valuesDrifted := false
+		for projectId, instance := range instances {
+			data, response, err := apiClient.Client.ProjectAppsAPI.ProjectappDetails(ctx, instance.appId).Execute()
+			if err != nil {
+				if utils.IsNotFound(response) {
+					// Uninstalled outside of Terraform, it is installed again on the next apply
+					delete(instances, projectId)
+					continue
+				}
+				return utils.DiagnosticsFromApiError(response, err)
+			}
+			// A failed application instance stays failed until it is installed again, even if Taikun reports it as ready,
+			// as its values or autosync may not have been updated
+			if instance.status != string(tkcore.EINSTANCESTATUS_FAILURE) {
+				instance.status = string(data.GetStatus())
+			}
+
+			if remoteValues := flattenMergedValues(data.GetValues()); !valuesDrifted && strings.TrimSpace(remoteValues) != strings.TrimSpace(d.Get("merged_values").(string)) {
+				mergedValues = remoteValues
+				valuesDrifted = true
+			}
+		}
+
+		if len(instances) == 0 {
+			return utils.ReadNotFound(d, d.Id(), withRetries)
+		}
+
+		if err := setAppDeploymentInstances(d, instances); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("merged_values", mergedValues); err != nil {
+			return diag.FromErr(err)
+		}
+		return nil
+	}
+}
+
+func resourceTaikunAppDeploymentUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*tk.Client)
+	oldProjects, _ := d.GetChange("project")
+	instances, err := appDeploymentInstancesFromState(oldProjects)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	wait := appInstanceWaitFromResourceData(d)
+	parallelism := d.Get("parallelism").(int)
+
+	// Removed from the projects of the state which are no longer wanted, failed application instances included
+	oldProjectIds, newProjectIds := d.GetChange("project_ids")
+	toRemove := make([]int32, 0)
+	for projectId := range instances {
+		if !newProjectIds.(*schema.Set).Contains(utils.I32toa(projectId)) {
+			toRemove = append(toRemove, projectId)
+		}
+	}
+	toKeep, err := utils.SliceOfSTringsToSliceOfInt32(oldProjectIds.(*schema.Set).Intersection(newProjectIds.(*schema.Set)).List())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	toAdd, err := utils.SliceOfSTringsToSliceOfInt32(newProjectIds.(*schema.Set).Difference(oldProjectIds.(*schema.Set)).List())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Uninstall from the projects removed
+	var mutex sync.Mutex
+	errs := forEachAppDeploymentProject(ctx, toRemove, parallelism, func(projectId int32) error {
+		mutex.Lock()
+		instance, found := instances[projectId]
+		mutex.Unlock()
+		if !found {
+			return nil
+		}
+
+		unlock, err := utils.LockProject(ctx, meta, projectId)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+		if err := uninstallTaikunAppDeploymentInstance(ctx, apiClient, instance.appId, wait); err != nil {
+			return err
+		}
+
+		mutex.Lock()
+		delete(instances, projectId)
+		mutex.Unlock()
+		return nil
+	})
+
+	// Autosync and values of the projects kept
+	autosyncChanged := d.HasChange("autosync")
+	valuesChanged := d.HasChanges("values", "values_files", "merged_values")
+	if autosyncChanged || valuesChanged {
+		extraValues, err := mergedValuesBase64(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		autosync := d.Get("autosync").(bool)
+		timeout := d.Get("timeout").(int)
+
+		updateErrs := forEachAppDeploymentProject(ctx, toKeep, parallelism, func(projectId int32) error {
+			mutex.Lock()
+			instance, found := instances[projectId]
+			mutex.Unlock()
+			if !found {
+				return nil
+			}
+
+			unlock, err := utils.LockProject(ctx, meta, projectId)
+			if err != nil {
+				return err
+			}
+			defer unlock()
+
+			if autosyncChanged {
+				body := tkcore.AutoSyncManagementCommand{}
+				body.SetId(instance.appId)
+				if autosync {
+					body.SetMode("enable")
+				} else {
+					body.SetMode("disable")
+				}
+				response, err := apiClient.Client.ProjectAppsAPI.ProjectappAutosync(ctx).AutoSyncManagementCommand(body).Execute()
+				if err != nil {
+					return utils.NewApiError(response, err)
+				}
+			}
+			if valuesChanged {
+				// If autosync is enabled, it will trigger sync automatically. If not we need to sync manually.
+				if err := sendTaikunAppInstanceParams(ctx, apiClient, instance.appId, extraValues, timeout, !autosync); err != nil {
+					return err
+				}
+				return waitForAppInstanceReady(ctx, apiClient, instance.appId, wait)
+			}
+			return nil
+		})
+		for projectId, err := range updateErrs {
+			errs[projectId] = err
+			// Left out of project_ids, the application is installed again in the project on the next apply
+			if instance, found := instances[projectId]; found {
+				instance.status = string(tkcore.EINSTANCESTATUS_FAILURE)
+			}
+		}
+	}
+
+	// Install in the projects added
+	installErrs, err := installTaikunAppDeployment(ctx, d, meta, toAdd, instances)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for projectId, err := range installErrs {
+		errs[projectId] = err
+	}
+
+	if err := setAppDeploymentInstances(d, instances); err != nil {
+		return diag.FromErr(err)
+	}
+	diagnostics := appDeploymentFailureDiagnostics(errs, instances)
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+
+	return append(diagnostics, utils.ReadAfterUpdateWithRetries(generateResourceTaikunAppDeploymentReadWithRetries(), ctx, d, meta)...)
+}
+
+func resourceTaikunAppDeploymentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*tk.Client)
+	instances, err := appDeploymentInstancesFromState(d.Get("project"))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	wait := appInstanceWaitFromResourceData(d)
+
+	projectIds := make([]int32, 0, len(instances))
+	for projectId := range instances {
+		projectIds = append(projectIds, projectId)
+	}
+
+	var mutex sync.Mutex
+	errs := forEachAppDeploymentProject(ctx, projectIds, d.Get("parallelism").(int), func(projectId int32) error {
+		mutex.Lock()
+		instance := instances[projectId]
+		mutex.Unlock()
+
+		unlock, err := utils.LockProject(ctx, meta, projectId)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+		if err := uninstallTaikunAppDeploymentInstance(ctx, apiClient, instance.appId, wait); err != nil {
+			return err
+		}
+
+		mutex.Lock()
+		delete(instances, projectId)
+		mutex.Unlock()
+		return nil
+	})
+
+	// Keep the application instances which could not be uninstalled in the state
+	if len(errs) > 0 {
+		if err := setAppDeploymentInstances(d, instances); err != nil {
+			return diag.FromErr(err)
+		}
+		return appDeploymentDiagnostics(errs, diag.Error)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,136 @@
+package app_instance
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	tkcore "github.com/itera-io/taikungoclient/client"
+)
+
+func TestForEachAppDeploymentProject(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	errs := forEachAppDeploymentProject(context.Background(), []int32{1, 2, 3, 4, 5, 6}, 2, func(projectId int32) error {
+		current := running.Add(1)
+		defer running.Add(-1)
+		for {
+			seen := maxRunning.Load()
+			if current <= seen || maxRunning.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		if projectId%2 == 0 {
+			return errors.New("failed")
+		}
+		return nil
+	})
+
+	if maxRunning.Load() > 2 {
+		t.Fatalf("expected at most 2 projects at the same time, got %d", maxRunning.Load())
+	}
+	if len(errs) != 3 {
+		t.Fatalf("expected the errors of projects 2, 4 and 6, got %v", errs)
+	}
+	for _, projectId := range []int32{2, 4, 6} {
+		if errs[projectId] == nil {
+			t.Fatalf("expected an error for project %d, got %v", projectId, errs)
+		}
+	}
+}
+
+func TestForEachAppDeploymentProjectCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The first project cancels the context while the other one waits for its turn
+	var ran atomic.Int32
+	errs := forEachAppDeploymentProject(ctx, []int32{1, 2}, 1, func(projectId int32) error {
+		ran.Add(1)
+		cancel()
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	})
+
+	if ran.Load() != 1 {
+		t.Fatalf("expected a single project to run, got %d", ran.Load())
+	}
+	if len(errs) != 1 {
+		t.Fatalf("expected the project left to be canceled, got %v", errs)
+	}
+	for _, err := range errs {
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	}
+}
+
+func TestAppDeploymentDiagnostics(t *testing.T) {
+	diagnostics := appDeploymentDiagnostics(map[int32]error{
+		12: errors.New("second"),
+		3:  errors.New("first"),
+	}, diag.Warning)
+
+	if len(diagnostics) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %v", diagnostics)
+	}
+	for i, expected := range []string{"project 3: first", "project 12: second"} {
+		if diagnostics[i].Summary != expected {
+			t.Errorf("expected diagnostic %d to be %q, got %q", i, expected, diagnostics[i].Summary)
+		}
+		if diagnostics[i].Severity != diag.Warning {
+			t.Errorf("expected diagnostic %d to be a warning", i)
+		}
+	}
+}
+
+func TestAppDeploymentDiagnosticsOfApplication(t *testing.T) {
+	diagnostics := appDeploymentDiagnostics(map[int32]error{
+		7: &appInstanceError{appId: 42, cause: errors.New("the application failed"), status: "Failure"},
+	}, diag.Error)
+
+	if len(diagnostics) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %v", diagnostics)
+	}
+	if expected := "project 7: error waiting for application (42) to be ready: the application failed"; diagnostics[0].Summary != expected {
+		t.Errorf("expected summary %q, got %q", expected, diagnostics[0].Summary)
+	}
+	if diagnostics[0].Detail != "Status: Failure" {
+		t.Errorf("expected the status in the detail, got %q", diagnostics[0].Detail)
+	}
+}
+
+func TestAppDeploymentFailureDiagnostics(t *testing.T) {
+	failed := &appDeploymentInstance{projectId: 1, appId: 10, status: string(tkcore.EINSTANCESTATUS_FAILURE)}
+	ready := &appDeploymentInstance{projectId: 2, appId: 20, status: string(tkcore.EINSTANCESTATUS_READY)}
+	errs := map[int32]error{1: errors.New("failed")}
+
+	testCases := []struct {
+		name      string
+		errs      map[int32]error
+		instances map[int32]*appDeploymentInstance
+		severity  diag.Severity
+		count     int
+	}{
+		{"no failure", nil, map[int32]*appDeploymentInstance{2: ready}, diag.Error, 0},
+		{"deployed in another project", errs, map[int32]*appDeploymentInstance{1: failed, 2: ready}, diag.Warning, 1},
+		{"deployed nowhere", errs, map[int32]*appDeploymentInstance{1: failed}, diag.Error, 1},
+		{"not even installed", errs, map[int32]*appDeploymentInstance{}, diag.Error, 1},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			diagnostics := appDeploymentFailureDiagnostics(testCase.errs, testCase.instances)
+			if len(diagnostics) != testCase.count {
+				t.Fatalf("expected %d diagnostics, got %v", testCase.count, diagnostics)
+			}
+			for _, diagnostic := range diagnostics {
+				if diagnostic.Severity != testCase.severity {
+					t.Errorf("expected severity %v, got %v", testCase.severity, diagnostic.Severity)
+				}
+			}
+		})
+	}
+}
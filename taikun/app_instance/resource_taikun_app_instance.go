@@ -1,13 +1,18 @@
 package app_instance
 
 import (
+	"bytes"
 	"context"
 	b64 "encoding/base64"
 	"fmt"
+	"log"
+	"os"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -17,7 +22,7 @@ import (
 )
 
 func resourceTaikunAppInstanceSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
+	resourceSchema := map[string]*schema.Schema{
 		"id": {
 			Description: "The ID of the application instance.",
 			Type:        schema.TypeString,
@@ -63,6 +68,21 @@ func resourceTaikunAppInstanceSchema() map[string]*schema.Schema {
 			ForceNew:         true,
 			ValidateDiagFunc: utils.StringIsInt,
 		},
+		"version": {
+			Description:  "The version of the chart to install. Changing it upgrades the application instance in place. Defaults to the version of the catalog app.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringIsNotWhiteSpace,
+		},
+		"available_versions": {
+			Description: "The versions of the chart available in its repository.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
 		"parameters_yaml": {
 			Description:      "A path to a valid yaml file that includes the parameters for the application.",
 			Type:             schema.TypeString,
@@ -72,17 +92,61 @@ func resourceTaikunAppInstanceSchema() map[string]*schema.Schema {
 				// Read file contents, encode in base64 and save to state
 				paramsEncoded, err := utils.FilePathToBase64String(filePath.(string))
 				if err != nil {
-					panic(fmt.Errorf("error reading file %s\nError: %s", filePath, err.Error()))
+					// The file is read again when the parameters are sent, which reports the error
+					return filePath.(string)
 				}
 				return paramsEncoded
 			},
-			ConflictsWith: []string{"parameters_base64"},
+			ConflictsWith:    []string{"parameters_base64", "values", "values_files"},
+			DiffSuppressFunc: suppressImportedParametersDiff,
 		},
 		"parameters_base64": {
-			Description:   "A base64 encoded file containing parameters for the application.",
-			Type:          schema.TypeString,
-			Optional:      true,
-			ConflictsWith: []string{"parameters_yaml"},
+			Description:      "A base64 encoded file containing parameters for the application.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			ConflictsWith:    []string{"parameters_yaml", "values", "values_files"},
+			DiffSuppressFunc: suppressImportedParametersDiff,
+		},
+		"values": {
+			Description:      "The values of the application, as a YAML or JSON object. Use `yamlencode` or `jsonencode` to give them as an HCL object. They are merged on top of `values_files`.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validateValues,
+			StateFunc:        valuesStateFunc,
+			ConflictsWith:    []string{"parameters_yaml", "parameters_base64"},
+			DiffSuppressFunc: suppressImportedParametersDiff,
+		},
+		"values_files": {
+			Description:      "Paths of YAML or JSON files holding values of the application, merged in order like Helm values files.",
+			Type:             schema.TypeList,
+			Optional:         true,
+			ConflictsWith:    []string{"parameters_yaml", "parameters_base64"},
+			DiffSuppressFunc: suppressImportedParametersDiff,
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: utils.StringIsFilePath,
+			},
+		},
+		"merged_values": {
+			Description: "The values of the application as canonical YAML, read from Taikun. The plan shows the values which will be sent, read from `values`, `values_files`, the file of `parameters_yaml` or `parameters_base64`. The keys of `sensitive_values` are left out, unless the other values also set them, in which case they keep the value given there.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"sensitive_values": {
+			Description:      "Secret values of the application, as a YAML or JSON object, merged on top of the other values when they are sent to Taikun. Only their hash is stored in the state and shown in the plan.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			Sensitive:        true,
+			ValidateDiagFunc: validateValues,
+			StateFunc:        sensitiveValuesStateFunc,
+		},
+		"sensitive_value_keys": {
+			Description: "The paths of the keys of `sensitive_values`, such as `auth.password`, left out of the values read from Taikun unless the other values also set them.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
 		},
 		"autosync": {
 			Description: "Indicates whether enable or disable autosyc.",
@@ -109,6 +173,31 @@ func resourceTaikunAppInstanceSchema() map[string]*schema.Schema {
 			ForceNew:    true,
 			Default:     "",
 		},
+		"wait_for": {
+			Description: "What to wait for after the application is installed, synced or upgraded: `ready`, the default, waits for the Helm release to be deployed, `healthy` also waits for its resources to be healthy.",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice([]string{appInstanceWaitForReady, appInstanceWaitForHealthy}, false),
+			},
+		},
+		"sync_trigger": {
+			Description: "Any change of this value syncs the application instance and waits until it is ready, for example a timestamp or the ID of a pipeline run.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"rollback_to_revision": {
+			Description:  "Changing it to a revision of the Helm release rolls the application instance back to it and waits until it is ready. Ignored when the application instance is created. While it is set, the values of the revision are kept until the values of the configuration change, or until the values change in Taikun, which is shown as drift.",
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"rolled_back_values": {
+			Description: "The values of the application as canonical YAML, read from Taikun right after the rollback to `rollback_to_revision`. They are not compared with the configuration while Taikun holds them.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
 		"timeout": {
 			Description:  "The timeout in minutes for the application installation.",
 			Type:         schema.TypeInt,
@@ -117,6 +206,10 @@ func resourceTaikunAppInstanceSchema() map[string]*schema.Schema {
 			ValidateFunc: validation.IntBetween(10, 200),
 		},
 	}
+	utils.AddWriteOnlySecret(resourceSchema, "sensitive_values")
+	resourceSchema["sensitive_values_wo"].ValidateFunc = nil
+	resourceSchema["sensitive_values_wo"].ValidateDiagFunc = validation.AllDiag(validation.ToDiagFunc(validation.StringIsNotEmpty), validateValues)
+	return resourceSchema
 }
 
 func ResourceTaikunAppInstance() *schema.Resource {
@@ -127,7 +220,113 @@ func ResourceTaikunAppInstance() *schema.Resource {
 		UpdateContext: resourceTaikunAppInstanceUpdate,
 		DeleteContext: resourceTaikunAppInstanceDelete,
 		Schema:        resourceTaikunAppInstanceSchema(),
+		CustomizeDiff: customdiff.All(
+			customizeDiffMergedValues,
+			customizeDiffSensitiveValues,
+		),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTaikunAppInstanceImport,
+		},
+	}
+}
+
+// Application instances are imported with their ID or with the ID project_id/name.
+// Their values are read into parameters_base64, whichever attribute the configuration uses.
+func resourceTaikunAppInstanceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	apiClient := meta.(*tk.Client)
+
+	if strings.Contains(d.Id(), "/") {
+		parts, err := utils.ParseImportID(d.Id(), "project_id", "name")
+		if err != nil {
+			return nil, err
+		}
+		projectId, err := utils.Atoi32(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("project_id isn't valid: %s", parts[0])
+		}
+
+		appInstanceId, err := findAppInstanceByName(ctx, apiClient, projectId, parts[1])
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(utils.I32toa(appInstanceId))
+	} else if _, err := utils.Atoi32(d.Id()); err != nil {
+		return nil, fmt.Errorf("unexpected format of ID (%q), expected id or project_id/name", d.Id())
+	}
+
+	// Not returned by Taikun, the default is set so that the configuration does not need to set it
+	if err := d.Set("timeout", resourceTaikunAppInstanceSchema()["timeout"].Default); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func findAppInstanceByName(ctx context.Context, apiClient *tk.Client, projectId int32, name string) (int32, error) {
+	var offset int32 = 0
+	for {
+		response, res, err := apiClient.Client.ProjectAppsAPI.ProjectappList(ctx).Offset(offset).Execute()
+		if err != nil {
+			return 0, utils.NewApiError(res, err)
+		}
+		for _, appInstance := range response.GetData() {
+			if appInstance.GetProjectId() == projectId && appInstance.GetName() == name {
+				return appInstance.GetId(), nil
+			}
+		}
+		offset += int32(len(response.GetData()))
+		if len(response.GetData()) == 0 || offset >= response.GetTotalCount() {
+			return 0, fmt.Errorf("could not find the application instance %s in project %d", name, projectId)
+		}
+	}
+}
+
+// An imported application instance has its values in parameters_base64, the configuration may give them with parameters_yaml, values or values_files instead.
+// The diff of these attributes is ignored as long as the configuration holds the values already in the state.
+func suppressImportedParametersDiff(k string, old string, new string, d *schema.ResourceData) bool {
+	if d.Id() == "" {
+		return false
+	}
+	oldYaml, newYaml := d.GetChange("parameters_yaml")
+	oldBase64, newBase64 := d.GetChange("parameters_base64")
+	oldValues, _ := d.GetChange("values")
+	oldValuesFiles, _ := d.GetChange("values_files")
+	if oldYaml.(string) != "" || oldValues.(string) != "" || len(oldValuesFiles.([]interface{})) > 0 || oldBase64.(string) == "" || newBase64.(string) != "" {
+		return false
+	}
+
+	stateValues, err := b64.StdEncoding.DecodeString(oldBase64.(string))
+	if err != nil {
+		// Older versions of the provider stored the values with the URL alphabet
+		if stateValues, err = b64.URLEncoding.DecodeString(oldBase64.(string)); err != nil {
+			return false
+		}
 	}
+
+	if valuesSpecified(d) {
+		configValues, err := mergedValues(d.Get("values").(string), utils.ResourceGetStringList(d.Get("values_files")))
+		if err != nil {
+			return false
+		}
+		normalizedStateValues, err := normalizeValues(string(stateValues))
+		return err == nil && normalizedStateValues == configValues
+	}
+	if newYaml.(string) == "" {
+		return false
+	}
+
+	// The configuration holds the path of the file, its encoded content once the StateFunc was applied
+	newYamlEncoded := newYaml.(string)
+	if _, err := os.Stat(newYamlEncoded); err == nil {
+		if newYamlEncoded, err = utils.FilePathToBase64String(newYamlEncoded); err != nil {
+			return false
+		}
+	}
+	fileValues, err := b64.StdEncoding.DecodeString(newYamlEncoded)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(bytes.TrimSuffix(stateValues, []byte("\n")), fileValues)
 }
 
 func resourceTaikunAppInstanceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -136,22 +335,37 @@ func resourceTaikunAppInstanceCreate(ctx context.Context, d *schema.ResourceData
 	// Prepare arguments
 	projectId, err := utils.Atoi32(d.Get("project_id").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 	catalogAppId, err := utils.Atoi32(d.Get("catalog_app_id").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 	extraValues := ""
 	paramsInFile := paramsSpecifiedAsFile(d)
-	if paramsInFile {
+	if valuesSpecified(d) {
+		extraValues, err = mergedValuesBase64(d)
+		if err != nil {
+			return utils.DiagnosticsFromError(err)
+		}
+	} else if paramsInFile {
 		extraValues, err = utils.FilePathToBase64String(d.Get("parameters_yaml").(string))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	} else {
 		extraValues = d.Get("parameters_base64").(string)
 	}
+	extraValues, err = withSensitiveValues(d, extraValues)
+	if err != nil {
+		return utils.DiagnosticsFromError(err)
+	}
+
+	unlock, err := utils.LockProject(ctx, meta, projectId)
+	if err != nil {
+		return utils.DiagnosticsFromError(err)
+	}
+	defer unlock()
 
 	// Send install
 	body := &tkcore.CreateProjectAppCommand{}
@@ -163,16 +377,19 @@ func resourceTaikunAppInstanceCreate(ctx context.Context, d *schema.ResourceData
 	body.SetAutoSync(d.Get("autosync").(bool))
 	body.SetTaikunLinkEnabled(d.Get("taikun_link").(bool))
 	body.SetTimeout(int32(d.Get("timeout").(int)))
+	if version, versionIsSet := d.GetOk("version"); versionIsSet {
+		body.SetVersion(version.(string))
+	}
 	data, response, err := apiClient.Client.ProjectAppsAPI.ProjectappInstall(ctx).CreateProjectAppCommand(*body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(response, err))
+		return utils.DiagnosticsFromApiError(response, err)
 	}
 
 	// Wait for install to finish
 	d.SetId(data.GetId())
 	err = resourceTaikunAppInstanceWaitForReady(ctx, d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return appInstanceDiagnostics(err)
 	}
 
 	return utils.ReadAfterCreateWithRetries(generateResourceTaikunAppInstanceReadWithRetries(), ctx, d, meta)
@@ -182,17 +399,27 @@ func resourceTaikunAppInstanceDelete(ctx context.Context, d *schema.ResourceData
 	apiClient := meta.(*tk.Client)
 	appInstanceId, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
+	projectId, err := utils.Atoi32(d.Get("project_id").(string))
+	if err != nil {
+		return utils.DiagnosticsFromError(err)
+	}
+	unlock, err := utils.LockProject(ctx, meta, projectId)
+	if err != nil {
+		return utils.DiagnosticsFromError(err)
+	}
+	defer unlock()
+
 	_, response, err := apiClient.Client.ProjectAppsAPI.ProjectappDelete(ctx, appInstanceId).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(response, err))
+		return utils.DiagnosticsFromApiError(response, err)
 	}
 
 	// Wait for uninstall
 	err = resourceTaikunAppInstanceWaitForDelete(ctx, d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 	return nil
 }
@@ -209,13 +436,12 @@ func generateResourceTaikunAppInstanceRead(withRetries bool) schema.ReadContextF
 		apiClient := meta.(*tk.Client)
 		appId, err := utils.Atoi32(d.Id())
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
-		data, _, err := apiClient.Client.ProjectAppsAPI.ProjectappDetails(ctx, appId).Execute()
+		data, res, err := apiClient.Client.ProjectAppsAPI.ProjectappDetails(ctx, appId).Execute()
 		if err != nil {
 			// Already destroyed/create again
-			d.SetId("")
-			return nil
+			return utils.ReadApiError(d, d.Id(), withRetries, res, err)
 		}
 
 		// Application was found in Failed state.
@@ -223,25 +449,32 @@ func generateResourceTaikunAppInstanceRead(withRetries bool) schema.ReadContextF
 		if data.GetStatus() == tkcore.EINSTANCESTATUS_FAILURE || data.GetStatus() == tkcore.EINSTANCESTATUS_NONE {
 			err = d.Set("status", "Failed")
 			if err != nil {
-				return diag.FromErr(err)
+				return utils.DiagnosticsFromError(err)
 			}
 			return nil
 		}
 
-		// Load all the found data to the local object
-		paramsInFile := paramsSpecifiedAsFile(d)
-		if paramsInFile {
-			// File parameters were used
-			err = utils.SetResourceDataFromMap(d, flattenTaikunAppInstance(true, data))
-			if err != nil {
-				return diag.FromErr(err)
-			}
-		} else {
-			// Base64 parameters were used
-			err = utils.SetResourceDataFromMap(d, flattenTaikunAppInstance(false, data))
-			if err != nil {
-				return diag.FromErr(err)
-			}
+		// Load all the found data to the local object, in the attribute used to give the parameters
+		paramsKey := appInstanceParamsKey(d)
+		data.SetValues(withoutSensitiveValues(data.GetValues(), utils.ResourceGetStringList(d.Get("sensitive_value_keys")), configuredValues(d, paramsKey)))
+		appInstanceMap := flattenTaikunAppInstance(paramsKey, data)
+		if paramsKey != "" && (holdsRolledBackValues(d, appInstanceMap["merged_values"].(string)) || sameValues(d.Get(paramsKey).(string), appInstanceMap[paramsKey].(string))) {
+			// Keep the parameters as given, Taikun may return the same values formatted differently,
+			// and they are canonical YAML once the sensitive values are left out.
+			// After a rollback, Taikun holds the values of the revision, which are not sent again.
+			delete(appInstanceMap, paramsKey)
+		}
+		err = utils.SetResourceDataFromMap(d, appInstanceMap)
+		if err != nil {
+			return utils.DiagnosticsFromError(err)
+		}
+
+		// The versions are informative, the resource is read even if the repository cannot be listed
+		availableVersions, err := utils.GetPackageVersions(ctx, data.GetRepoName(), data.GetPackageName(), apiClient)
+		if err != nil {
+			log.Printf("[WARN] Unable to list the versions of the package %s: %v", data.GetPackageName(), err)
+		} else if err = d.Set("available_versions", availableVersions); err != nil {
+			return utils.DiagnosticsFromError(err)
 		}
 
 		// We need to tell provider that object was created
@@ -250,30 +483,52 @@ func generateResourceTaikunAppInstanceRead(withRetries bool) schema.ReadContextF
 	}
 }
 
-func flattenTaikunAppInstance(paramsSpecifiedAsFile bool, rawAppInstance *tkcore.ProjectAppDetailsDto) map[string]interface{} {
-	paramsKey := "parameters_base64"
-	if paramsSpecifiedAsFile {
-		paramsKey = "parameters_yaml"
+// Attribute in which the values read from Taikun are set, empty if they are only compared through merged_values
+func appInstanceParamsKey(d *schema.ResourceData) string {
+	if valuesSpecified(d) {
+		return ""
+	}
+	if paramsSpecifiedAsFile(d) {
+		return "parameters_yaml"
 	}
-	return map[string]interface{}{
+	return "parameters_base64"
+}
+
+// The values are set in paramsKey, parameters_base64 or parameters_yaml, unless it is empty
+func flattenTaikunAppInstance(paramsKey string, rawAppInstance *tkcore.ProjectAppDetailsDto) map[string]interface{} {
+	appInstanceMap := map[string]interface{}{
 		"id":              utils.I32toa(rawAppInstance.GetId()),
 		"name":            rawAppInstance.GetName(),
 		"namespace":       rawAppInstance.GetNamespace(),
 		"project_id":      utils.I32toa(rawAppInstance.GetProjectId()),
 		"catalog_app_id":  utils.I32toa(rawAppInstance.GetCatalogAppId()),
-		paramsKey:         b64.URLEncoding.EncodeToString([]byte(rawAppInstance.GetValues())),
+		"version":         rawAppInstance.GetVersion(),
+		"merged_values":   flattenMergedValues(rawAppInstance.GetValues()),
 		"autosync":        rawAppInstance.GetAutoSync(),
 		"taikun_link":     rawAppInstance.GetTaikunLinkEnabled(),
 		"taikun_link_url": rawAppInstance.GetTaikunLinkUrl(),
 	}
+	if paramsKey != "" {
+		appInstanceMap[paramsKey] = b64.StdEncoding.EncodeToString([]byte(rawAppInstance.GetValues()))
+	}
+	return appInstanceMap
 }
 
 func resourceTaikunAppInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(*tk.Client)
 	appId, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
+	projectId, err := utils.Atoi32(d.Get("project_id").(string))
+	if err != nil {
+		return utils.DiagnosticsFromError(err)
+	}
+	unlock, err := utils.LockProject(ctx, meta, projectId)
+	if err != nil {
+		return utils.DiagnosticsFromError(err)
+	}
+	defer unlock()
 
 	// Autosync
 	autosyncOld, autosyncNew := d.GetChange("autosync")
@@ -294,33 +549,80 @@ func resourceTaikunAppInstanceUpdate(ctx context.Context, d *schema.ResourceData
 		response, errSync := apiClient.Client.ProjectAppsAPI.ProjectappAutosync(ctx).AutoSyncManagementCommand(body).Execute()
 
 		if errSync != nil {
-			return diag.FromErr(tk.CreateError(response, errSync))
+			return utils.DiagnosticsFromApiError(response, errSync)
+		}
+	}
+
+	// Rollback, before any other change is applied on top of the revision
+	if d.HasChange("rollback_to_revision") {
+		if revision := d.Get("rollback_to_revision").(int); revision != 0 {
+			if err = rollbackTaikunAppInstance(ctx, appId, revision, d, meta); err != nil {
+				return appInstanceDiagnostics(err)
+			}
+		} else if err = d.Set("rolled_back_values", ""); err != nil {
+			return utils.DiagnosticsFromError(err)
+		}
+	}
+
+	// Version, upgraded before the parameters are sent as the new chart may expect them
+	if d.HasChange("version") {
+		if err = upgradeTaikunAppInstance(ctx, appId, d, meta); err != nil {
+			return appInstanceDiagnostics(err)
 		}
 	}
 
 	err = updateParams(ctx, appId, d, meta, !autosyncNew.(bool)) // If autosync is enabled, it will trigger sync automatically. If not we need to sync manually.
 	if err != nil {
-		return diag.FromErr(err)
+		return appInstanceDiagnostics(err)
+	}
+
+	// Sync forced by sync_trigger
+	if d.HasChange("sync_trigger") {
+		if err = syncTaikunAppInstance(ctx, apiClient, appId, d.Get("timeout").(int)); err != nil {
+			return appInstanceDiagnostics(err)
+		}
+		if err = resourceTaikunAppInstanceWaitForReady(ctx, d, meta); err != nil {
+			return appInstanceDiagnostics(err)
+		}
 	}
 
 	return utils.ReadAfterUpdateWithRetries(generateResourceTaikunAppInstanceReadWithRetries(), ctx, d, meta)
 }
 
-// Wait until app is Ready
+// How long to wait for an application instance, in minutes, and whether to wait until it is healthy
+type appInstanceWait struct {
+	timeout int
+	healthy bool
+}
+
+func appInstanceWaitFromResourceData(d *schema.ResourceData) appInstanceWait {
+	return appInstanceWait{
+		timeout: d.Get("timeout").(int),
+		healthy: d.Get("wait_for").(*schema.Set).Contains(appInstanceWaitForHealthy),
+	}
+}
+
+func (w appInstanceWait) timeoutMinutes() int {
+	if w.timeout <= 10 {
+		return 10
+	}
+	return w.timeout
+}
+
+// Wait until app is Ready, and Healthy if wait_for asks for it.
+// If the app fails or the wait times out, the error describes the app as Taikun reports it.
 func resourceTaikunAppInstanceWaitForReady(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
-	apiClient := meta.(*tk.Client)
 	appId, err := utils.Atoi32(d.Id())
 	if err != nil {
 		return err
 	}
+	return waitForAppInstanceReady(ctx, meta.(*tk.Client), appId, appInstanceWaitFromResourceData(d))
+}
 
-	pendingStates := []string{string(tkcore.EINSTANCESTATUS_NONE), string(tkcore.EINSTANCESTATUS_NOT_READY), string(tkcore.EINSTANCESTATUS_INSTALLING), string(tkcore.EINSTANCESTATUS_UNINSTALLING)}
+func waitForAppInstanceReady(ctx context.Context, apiClient *tk.Client, appId int32, wait appInstanceWait) error {
+	pendingStates := []string{string(tkcore.EINSTANCESTATUS_NONE), string(tkcore.EINSTANCESTATUS_NOT_READY), string(tkcore.EINSTANCESTATUS_INSTALLING), string(tkcore.EINSTANCESTATUS_UNINSTALLING), appInstanceStateNotHealthy}
 	targetStates := []string{string(tkcore.EINSTANCESTATUS_READY)}
-
-	timeoutMinutes := d.Get("timeout").(int)
-	if timeoutMinutes <= 10 {
-		timeoutMinutes = 10
-	}
+	timeoutMinutes := wait.timeoutMinutes()
 
 	// Try to get the instance until timeout - If apps are listable, repository is ready
 	createStateConf := &retry.StateChangeConf{
@@ -329,9 +631,15 @@ func resourceTaikunAppInstanceWaitForReady(ctx context.Context, d *schema.Resour
 		Refresh: func() (interface{}, string, error) {
 			data, response, err := apiClient.Client.ProjectAppsAPI.ProjectappDetails(ctx, appId).Execute()
 			if err != nil {
-				return nil, "", tk.CreateError(response, err)
+				return nil, "", utils.NewApiError(response, err)
 			}
 
+			if data.GetStatus() == tkcore.EINSTANCESTATUS_FAILURE {
+				return data, "", fmt.Errorf("the application failed")
+			}
+			if data.GetStatus() == tkcore.EINSTANCESTATUS_READY && wait.healthy && data.GetHealthStatus() != appInstanceHealthHealthy {
+				return data, appInstanceStateNotHealthy, nil
+			}
 			return data, string(data.GetStatus()), nil
 		},
 		Timeout:    time.Duration(timeoutMinutes) * time.Minute,
@@ -339,9 +647,9 @@ func resourceTaikunAppInstanceWaitForReady(ctx context.Context, d *schema.Resour
 		MinTimeout: 10 * time.Second,
 	}
 
-	_, err = createStateConf.WaitForStateContext(ctx)
+	_, err := utils.WaitForState(ctx, createStateConf)
 	if err != nil {
-		return fmt.Errorf("error waiting for application (%d) to be ready: %s", appId, err)
+		return newAppInstanceError(ctx, apiClient, appId, err)
 	}
 
 	return nil
@@ -349,18 +657,17 @@ func resourceTaikunAppInstanceWaitForReady(ctx context.Context, d *schema.Resour
 
 // Wait until app is uninstalled, removed, not found.
 func resourceTaikunAppInstanceWaitForDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
-	apiClient := meta.(*tk.Client)
 	appId, err := utils.Atoi32(d.Id())
 	if err != nil {
 		return err
 	}
+	return waitForAppInstanceDeleted(ctx, meta.(*tk.Client), appId, appInstanceWaitFromResourceData(d))
+}
 
+func waitForAppInstanceDeleted(ctx context.Context, apiClient *tk.Client, appId int32, wait appInstanceWait) error {
 	pendingStates := []string{"present"}
 	targetStates := []string{"gone"}
-	timeoutMinutes := d.Get("timeout").(int)
-	if timeoutMinutes <= 10 {
-		timeoutMinutes = 10
-	}
+	timeoutMinutes := wait.timeoutMinutes()
 
 	// Try to get the instance until timeout - If app is not present, it was deleted.
 	// If uninstall fails during deletion, use your second chance to send uninstall again - usually it can get us unstuck.
@@ -371,7 +678,7 @@ func resourceTaikunAppInstanceWaitForDelete(ctx context.Context, d *schema.Resou
 		Refresh: func() (interface{}, string, error) {
 			data, response, err := apiClient.Client.ProjectAppsAPI.ProjectappList(ctx).Id(appId).Execute()
 			if err != nil {
-				return nil, "", tk.CreateError(response, err)
+				return nil, "", utils.NewApiError(response, err)
 			}
 
 			foundMatch := "present"
@@ -385,7 +692,7 @@ func resourceTaikunAppInstanceWaitForDelete(ctx context.Context, d *schema.Resou
 					secondChance = false
 					_, response, err = apiClient.Client.ProjectAppsAPI.ProjectappDelete(ctx, appId).Execute()
 					if err != nil {
-						return nil, "", tk.CreateError(response, err)
+						return nil, "", utils.NewApiError(response, err)
 					}
 				}
 			}
@@ -397,7 +704,7 @@ func resourceTaikunAppInstanceWaitForDelete(ctx context.Context, d *schema.Resou
 		MinTimeout: 10 * time.Second,
 	}
 
-	_, err = createStateConf.WaitForStateContext(ctx)
+	_, err := utils.WaitForState(ctx, createStateConf)
 	if err != nil {
 		return fmt.Errorf("error waiting for application (%d) to be ready: %s", appId, err)
 	}
@@ -405,33 +712,92 @@ func resourceTaikunAppInstanceWaitForDelete(ctx context.Context, d *schema.Resou
 	return nil
 }
 
-// Set new parameters, sync app, wait until ready
-func setParamsAndSyncTaikunAppInstance(ctx context.Context, appId int32, extraValues string, d *schema.ResourceData, meta interface{}, triggerSync bool) error {
+// Upgrade the release to the new version of the chart, wait until ready
+func upgradeTaikunAppInstance(ctx context.Context, appId int32, d *schema.ResourceData, meta interface{}) error {
 	apiClient := meta.(*tk.Client)
 
+	body := tkcore.EditProjectAppVersionCommand{}
+	body.SetProjectAppId(appId)
+	body.SetVersion(d.Get("version").(string))
+	body.SetTimeout(int32(d.Get("timeout").(int)))
+	response, err := apiClient.Client.ProjectAppsAPI.ProjectappUpdateVersion(ctx).EditProjectAppVersionCommand(body).Execute()
+	if err != nil {
+		return utils.NewApiError(response, err)
+	}
+
+	return resourceTaikunAppInstanceWaitForReady(ctx, d, meta)
+}
+
+// Set new parameters, with the sensitive values merged into them, sync app, wait until ready
+func setParamsAndSyncTaikunAppInstance(ctx context.Context, appId int32, extraValues string, d *schema.ResourceData, meta interface{}, triggerSync bool) error {
+	extraValues, err := withSensitiveValues(d, extraValues)
+	if err != nil {
+		return err
+	}
+
+	err = sendTaikunAppInstanceParams(ctx, meta.(*tk.Client), appId, extraValues, d.Get("timeout").(int), triggerSync)
+	if err != nil {
+		return err
+	}
+
+	err = resourceTaikunAppInstanceWaitForReady(ctx, d, meta)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Set new parameters and sync app, the sync being given timeout minutes
+func sendTaikunAppInstanceParams(ctx context.Context, apiClient *tk.Client, appId int32, extraValues string, timeout int, triggerSync bool) error {
 	body := tkcore.EditProjectAppExtraValuesCommand{}
 	body.SetProjectAppId(appId)
 	body.SetExtraValues(extraValues)
 	_, response, errParams := apiClient.Client.ProjectAppsAPI.ProjectappUpdateExtraValues(ctx).EditProjectAppExtraValuesCommand(body).Execute()
 	if errParams != nil {
-		return tk.CreateError(response, errParams)
+		return utils.NewApiError(response, errParams)
 	}
 
 	if triggerSync {
-		bodySync := tkcore.SyncProjectAppCommand{}
-		bodySync.SetProjectAppId(appId)
-		bodySync.SetTimeout(int32(d.Get("timeout").(int)))
-		response, errSync := apiClient.Client.ProjectAppsAPI.ProjectappSync(ctx).SyncProjectAppCommand(bodySync).Execute()
-		if errSync != nil {
-			return tk.CreateError(response, errSync)
-		}
+		return syncTaikunAppInstance(ctx, apiClient, appId, timeout)
+	}
+	return nil
+}
+
+// Sync app, the sync being given timeout minutes
+func syncTaikunAppInstance(ctx context.Context, apiClient *tk.Client, appId int32, timeout int) error {
+	bodySync := tkcore.SyncProjectAppCommand{}
+	bodySync.SetProjectAppId(appId)
+	bodySync.SetTimeout(int32(timeout))
+	response, errSync := apiClient.Client.ProjectAppsAPI.ProjectappSync(ctx).SyncProjectAppCommand(bodySync).Execute()
+	if errSync != nil {
+		return utils.NewApiError(response, errSync)
 	}
+	return nil
+}
 
-	err := resourceTaikunAppInstanceWaitForReady(ctx, d, meta)
+// Roll the release back to the revision, wait until ready
+func rollbackTaikunAppInstance(ctx context.Context, appId int32, revision int, d *schema.ResourceData, meta interface{}) error {
+	apiClient := meta.(*tk.Client)
+
+	body := tkcore.RollbackProjectAppCommand{}
+	body.SetProjectAppId(appId)
+	body.SetRevision(int32(revision))
+	body.SetTimeout(int32(d.Get("timeout").(int)))
+	response, err := apiClient.Client.ProjectAppsAPI.ProjectappRollback(ctx).RollbackProjectAppCommand(body).Execute()
 	if err != nil {
+		return utils.NewApiError(response, err)
+	}
+	if err = resourceTaikunAppInstanceWaitForReady(ctx, d, meta); err != nil {
 		return err
 	}
-	return nil
+
+	// Record the values of the revision, they are not compared with the configuration as long as Taikun holds them
+	data, response, err := apiClient.Client.ProjectAppsAPI.ProjectappDetails(ctx, appId).Execute()
+	if err != nil {
+		return utils.NewApiError(response, err)
+	}
+	values := withoutSensitiveValues(data.GetValues(), utils.ResourceGetStringList(d.Get("sensitive_value_keys")), configuredValues(d, appInstanceParamsKey(d)))
+	return d.Set("rolled_back_values", flattenMergedValues(values))
 }
 
 // Update parameters of this app in correct order
@@ -439,10 +805,25 @@ func setParamsAndSyncTaikunAppInstance(ctx context.Context, appId int32, extraVa
 func updateParams(ctx context.Context, appId int32, d *schema.ResourceData, meta interface{}, triggerSync bool) (err error) {
 	var extraValues string
 
+	// Values replace the parameters at once, the merged values changed if either of them did
+	if valuesSpecified(d) {
+		if !d.HasChanges("values", "values_files", "merged_values") && !sensitiveValuesChanged(d) {
+			return nil
+		}
+		extraValues, err = mergedValuesBase64(d)
+		if err != nil {
+			return err
+		}
+		return setParamsAndSyncTaikunAppInstance(ctx, appId, extraValues, d, meta, triggerSync)
+	}
+
 	paramsInFile := paramsSpecifiedAsFile(d)
 
 	oldBase64Parameters, newBase64Parameters := d.GetChange("parameters_base64")
-	oldYamlParameters, newYamlParameters := d.GetChange("parameters_yaml")
+	yamlParametersChanged, yamlParameters, err := yamlParametersChange(d)
+	if err != nil {
+		return err
+	}
 
 	if paramsInFile {
 		//  Fist delete base64 params, then create params from file
@@ -452,24 +833,16 @@ func updateParams(ctx context.Context, appId int32, d *schema.ResourceData, meta
 				return err
 			}
 		}
-		if oldYamlParameters != newYamlParameters {
-			extraValues, err = utils.FilePathToBase64String(d.Get("parameters_yaml").(string))
-			if err != nil {
-				return err
-			}
-			err = setParamsAndSyncTaikunAppInstance(ctx, appId, extraValues, d, meta, triggerSync)
+		if yamlParametersChanged {
+			err = setParamsAndSyncTaikunAppInstance(ctx, appId, yamlParameters, d, meta, triggerSync)
 			if err != nil {
 				return err
 			}
 		}
 	} else {
 		//  Fist delete params from file, then create params from base64
-		if oldYamlParameters != newYamlParameters {
-			extraValues, err = utils.FilePathToBase64String(d.Get("parameters_yaml").(string))
-			if err != nil {
-				return err
-			}
-			err = setParamsAndSyncTaikunAppInstance(ctx, appId, extraValues, d, meta, triggerSync)
+		if yamlParametersChanged {
+			err = setParamsAndSyncTaikunAppInstance(ctx, appId, yamlParameters, d, meta, triggerSync)
 			if err != nil {
 				return err
 			}
@@ -481,9 +854,34 @@ func updateParams(ctx context.Context, appId int32, d *schema.ResourceData, meta
 			}
 		}
 	}
+
+	// Only the sensitive values changed, the parameters are sent again with them
+	if sensitiveValuesChanged(d) && oldBase64Parameters == newBase64Parameters && !yamlParametersChanged {
+		// Without a diff for parameters_yaml, the state holds the encoded content of the file
+		extraValues = newBase64Parameters.(string)
+		if paramsInFile {
+			extraValues = d.Get("parameters_yaml").(string)
+		}
+		return setParamsAndSyncTaikunAppInstance(ctx, appId, extraValues, d, meta, triggerSync)
+	}
 	return nil
 }
 
+// The state holds the encoded content of parameters_yaml while the configuration holds the path of the file,
+// so compare the content of the file with the state rather than the path, which would always differ
+func yamlParametersChange(d *schema.ResourceData) (changed bool, encoded string, err error) {
+	// Without a diff for parameters_yaml, the new value is the content from the state and not a path
+	if !d.HasChange("parameters_yaml") {
+		return false, "", nil
+	}
+	oldYamlParameters, newYamlParameters := d.GetChange("parameters_yaml")
+	encoded, err = utils.FilePathToBase64String(newYamlParameters.(string))
+	if err != nil {
+		return false, "", err
+	}
+	return oldYamlParameters.(string) != encoded, encoded, nil
+}
+
 // Single function to answer in what format did the user specify the params
 func paramsSpecifiedAsFile(d *schema.ResourceData) bool {
 	parameters_yaml := d.Get("parameters_yaml")
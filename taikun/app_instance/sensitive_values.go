@@ -0,0 +1,235 @@
+package app_instance
+
+import (
+	"context"
+	"crypto/sha256"
+	b64 "encoding/base64"
+	"encoding/hex"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+)
+
+// Sensitive values are merged on top of the other values when they are sent to Taikun, and are never stored.
+// The state holds a hash of sensitive_values, so that changing them shows in the plan without showing them,
+// and the paths of their keys, which are left out of the values read back from Taikun.
+
+// Hash of the canonical YAML of the sensitive values, stored in place of them
+func sensitiveValuesStateFunc(i interface{}) string {
+	content := valuesStateFunc(i)
+	if content == "" {
+		return ""
+	}
+	hash := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(hash[:])
+}
+
+// Sensitive values given by the configuration, with sensitive_values_wo or sensitive_values.
+// The state only holds their hash, they are read from the configuration.
+func rawSensitiveValues(rawConfig cty.Value) (content string, known bool) {
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return "", false
+	}
+	for _, key := range []string{"sensitive_values_wo", "sensitive_values"} {
+		value := rawConfig.GetAttr(key)
+		if !value.IsKnown() {
+			return "", false
+		}
+		if !value.IsNull() && value.AsString() != "" {
+			return value.AsString(), true
+		}
+	}
+	return "", true
+}
+
+// Merge the sensitive values of the configuration into the base64 encoded values sent to Taikun
+func withSensitiveValues(d *schema.ResourceData, extraValues string) (string, error) {
+	content, _ := rawSensitiveValues(d.GetRawConfig())
+	return mergeSensitiveValues(extraValues, content)
+}
+
+// Merge the sensitive values into the base64 encoded values, which are returned as is without sensitive values
+func mergeSensitiveValues(extraValues string, sensitiveContent string) (string, error) {
+	if sensitiveContent == "" {
+		return extraValues, nil
+	}
+	sensitive, err := parseValues(sensitiveContent)
+	if err != nil {
+		return "", err
+	}
+
+	decoded, err := b64.StdEncoding.DecodeString(extraValues)
+	if err != nil {
+		return "", err
+	}
+	values, err := parseValues(string(decoded))
+	if err != nil {
+		return "", err
+	}
+	mergeValues(values, sensitive)
+
+	merged, err := canonicalValues(values)
+	if err != nil {
+		return "", err
+	}
+	return b64.StdEncoding.EncodeToString([]byte(merged)), nil
+}
+
+// Whether the sensitive values must be sent again
+func sensitiveValuesChanged(d *schema.ResourceData) bool {
+	return utils.SecretHasChange(d, "sensitive_values") || d.HasChange("sensitive_value_keys")
+}
+
+// Dots in keys are escaped with a backslash, like in helm --set
+func escapeValuesKey(key string) string {
+	return strings.ReplaceAll(key, ".", `\.`)
+}
+
+func splitValuesKeyPath(path string) []string {
+	keys := make([]string, 0)
+	key := strings.Builder{}
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path) && path[i+1] == '.':
+			key.WriteByte('.')
+			i++
+		case path[i] == '.':
+			keys = append(keys, key.String())
+			key.Reset()
+		default:
+			key.WriteByte(path[i])
+		}
+	}
+	return append(keys, key.String())
+}
+
+// Sorted paths of the keys of the values which are not maps, such as auth.password
+func valuesKeyPaths(values map[string]interface{}, prefix string) []string {
+	paths := make([]string, 0)
+	for key, value := range values {
+		path := prefix + escapeValuesKey(key)
+		if valueMap, isMap := value.(map[string]interface{}); isMap && len(valueMap) > 0 {
+			paths = append(paths, valuesKeyPaths(valueMap, path+".")...)
+			continue
+		}
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Remove the key at the path, and the maps it leaves empty
+func removeValuesKeyPath(values map[string]interface{}, keys []string) {
+	if len(keys) == 1 {
+		delete(values, keys[0])
+		return
+	}
+	child, isMap := values[keys[0]].(map[string]interface{})
+	if !isMap {
+		return
+	}
+	removeValuesKeyPath(child, keys[1:])
+	if len(child) == 0 {
+		delete(values, keys[0])
+	}
+}
+
+// Value of the key at the path, if the values set it
+func getValuesKeyPath(values map[string]interface{}, keys []string) (interface{}, bool) {
+	value, found := values[keys[0]]
+	if !found || len(keys) == 1 {
+		return value, found
+	}
+	child, isMap := value.(map[string]interface{})
+	if !isMap {
+		return nil, false
+	}
+	return getValuesKeyPath(child, keys[1:])
+}
+
+// Set the key at the path, creating the maps leading to it
+func setValuesKeyPath(values map[string]interface{}, keys []string, value interface{}) {
+	if len(keys) == 1 {
+		values[keys[0]] = value
+		return
+	}
+	child, isMap := values[keys[0]].(map[string]interface{})
+	if !isMap {
+		child = map[string]interface{}{}
+		values[keys[0]] = child
+	}
+	setValuesKeyPath(child, keys[1:], value)
+}
+
+// Values returned by Taikun without the sensitive values, as is if they cannot be parsed.
+// A key also set by the other values of the configuration keeps the value given there, the sensitive value replacing
+// it in Taikun; other keys of the sensitive values are removed.
+func withoutSensitiveValues(remoteValues string, keyPaths []string, configuredValues string) string {
+	if len(keyPaths) == 0 {
+		return remoteValues
+	}
+	values, err := parseValues(remoteValues)
+	if err != nil {
+		return remoteValues
+	}
+	configured, err := parseValues(configuredValues)
+	if err != nil {
+		configured = map[string]interface{}{}
+	}
+	for _, path := range keyPaths {
+		keys := splitValuesKeyPath(path)
+		if value, found := getValuesKeyPath(configured, keys); found {
+			setValuesKeyPath(values, keys, value)
+			continue
+		}
+		removeValuesKeyPath(values, keys)
+	}
+	stripped, err := canonicalValues(values)
+	if err != nil {
+		return remoteValues
+	}
+	return stripped
+}
+
+// Values given by the configuration besides the sensitive values, as the state holds them:
+// merged from values and values_files when paramsKey is empty, decoded from paramsKey otherwise
+func configuredValues(d *schema.ResourceData, paramsKey string) string {
+	if paramsKey == "" {
+		merged, err := mergedValues(d.Get("values").(string), utils.ResourceGetStringList(d.Get("values_files")))
+		if err != nil {
+			return ""
+		}
+		return merged
+	}
+	decoded, err := b64.StdEncoding.DecodeString(d.Get(paramsKey).(string))
+	if err != nil {
+		return ""
+	}
+	return string(decoded)
+}
+
+// Plan the paths of the keys of the sensitive values, write-only values included
+func customizeDiffSensitiveValues(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	content, known := rawSensitiveValues(d.GetRawConfig())
+	if !known {
+		if d.GetRawConfig().IsNull() {
+			return nil
+		}
+		return d.SetNewComputed("sensitive_value_keys")
+	}
+	values, err := parseValues(content)
+	if err != nil {
+		// Reported by the validation of sensitive_values
+		return nil
+	}
+
+	keyPaths := valuesKeyPaths(values, "")
+	if !slices.Equal(keyPaths, utils.ResourceGetStringList(d.Get("sensitive_value_keys"))) {
+		return d.SetNew("sensitive_value_keys", keyPaths)
+	}
+	return nil
+}
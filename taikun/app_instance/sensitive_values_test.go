@@ -0,0 +1,181 @@
+package app_instance
+
+import (
+	b64 "encoding/base64"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestSplitValuesKeyPath(t *testing.T) {
+	testCases := []struct {
+		path string
+		keys []string
+	}{
+		{"password", []string{"password"}},
+		{"auth.password", []string{"auth", "password"}},
+		{`annotations.kubernetes\.io/ingress\.class`, []string{"annotations", "kubernetes.io/ingress.class"}},
+		{`a\b.c`, []string{`a\b`, "c"}},
+		{`trailing\`, []string{`trailing\`}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.path, func(t *testing.T) {
+			if keys := splitValuesKeyPath(testCase.path); !reflect.DeepEqual(keys, testCase.keys) {
+				t.Errorf("expected %q, got %q", testCase.keys, keys)
+			}
+		})
+	}
+}
+
+func TestValuesKeyPaths(t *testing.T) {
+	values, err := parseValues("auth:\n  password: x\n  user: u\nannotations:\n  kubernetes.io/x: y\nempty: {}\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{`annotations.kubernetes\.io/x`, "auth.password", "auth.user", "empty"}
+	if paths := valuesKeyPaths(values, ""); !reflect.DeepEqual(paths, expected) {
+		t.Errorf("expected %q, got %q", expected, paths)
+	}
+}
+
+func TestRemoveValuesKeyPath(t *testing.T) {
+	testCases := []struct {
+		name     string
+		values   string
+		path     string
+		expected string
+	}{
+		{"top level", "password: x\nreplicas: 1\n", "password", "replicas: 1\n"},
+		{"nested", "auth:\n  password: x\n  user: u\n", "auth.password", "auth:\n  user: u\n"},
+		{"empty maps removed", "auth:\n  basic:\n    password: x\nreplicas: 1\n", "auth.basic.password", "replicas: 1\n"},
+		{"escaped dot", "annotations:\n  kubernetes.io/x: y\n  other: z\n", `annotations.kubernetes\.io/x`, "annotations:\n  other: z\n"},
+		{"missing key", "replicas: 1\n", "auth.password", "replicas: 1\n"},
+		{"not a map", "auth: basic\n", "auth.password", "auth: basic\n"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			values, err := parseValues(testCase.values)
+			if err != nil {
+				t.Fatal(err)
+			}
+			removeValuesKeyPath(values, splitValuesKeyPath(testCase.path))
+			if result, _ := canonicalValues(values); result != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetAndSetValuesKeyPath(t *testing.T) {
+	values, err := parseValues("auth:\n  user: u\nreplicas: 1\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, found := getValuesKeyPath(values, []string{"auth", "user"}); !found || value != "u" {
+		t.Errorf("expected auth.user to be u, got %v", value)
+	}
+	if _, found := getValuesKeyPath(values, []string{"replicas", "count"}); found {
+		t.Error("expected no key under a value which is not a map")
+	}
+	if _, found := getValuesKeyPath(values, []string{"auth", "password"}); found {
+		t.Error("expected auth.password not to be found")
+	}
+
+	setValuesKeyPath(values, []string{"auth", "password"}, "x")
+	setValuesKeyPath(values, []string{"replicas", "count"}, 2)
+	if result, _ := canonicalValues(values); result != "auth:\n  password: x\n  user: u\nreplicas:\n  count: 2\n" {
+		t.Errorf("unexpected values %q", result)
+	}
+}
+
+func TestMergeSensitiveValues(t *testing.T) {
+	extraValues := b64.StdEncoding.EncodeToString([]byte("auth:\n  user: u\n  password: placeholder\nreplicas: 1\n"))
+
+	merged, err := mergeSensitiveValues(extraValues, "auth:\n  password: secret\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, _ := b64.StdEncoding.DecodeString(merged)
+	if expected := "auth:\n  password: secret\n  user: u\nreplicas: 1\n"; string(decoded) != expected {
+		t.Errorf("expected %q, got %q", expected, decoded)
+	}
+
+	if unchanged, _ := mergeSensitiveValues(extraValues, ""); unchanged != extraValues {
+		t.Errorf("expected the values as is without sensitive values, got %q", unchanged)
+	}
+	if _, err := mergeSensitiveValues(extraValues, "- not a map"); err == nil {
+		t.Error("expected invalid sensitive values to fail")
+	}
+	if _, err := mergeSensitiveValues("not base64!", "password: x"); err == nil {
+		t.Error("expected invalid base64 values to fail")
+	}
+}
+
+func TestWithoutSensitiveValues(t *testing.T) {
+	remote := "auth:\n  password: secret\n  user: u\ntoken: t\nreplicas: 1\n"
+	keyPaths := []string{"auth.password", "token"}
+
+	testCases := []struct {
+		name       string
+		configured string
+		expected   string
+	}{
+		{"keys only in the sensitive values", "auth:\n  user: u\nreplicas: 1\n", "auth:\n  user: u\nreplicas: 1\n"},
+		{"key also set by the other values", "auth:\n  password: placeholder\n  user: u\nreplicas: 1\n", "auth:\n  password: placeholder\n  user: u\nreplicas: 1\n"},
+		{"invalid configured values", "- not a map", "auth:\n  user: u\nreplicas: 1\n"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if result := withoutSensitiveValues(remote, keyPaths, testCase.configured); result != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, result)
+			}
+		})
+	}
+
+	if result := withoutSensitiveValues(remote, nil, ""); result != remote {
+		t.Errorf("expected the values as is without sensitive keys, got %q", result)
+	}
+	if result := withoutSensitiveValues("- not a map", keyPaths, ""); result != "- not a map" {
+		t.Errorf("expected values which cannot be parsed as is, got %q", result)
+	}
+}
+
+func TestRawSensitiveValues(t *testing.T) {
+	config := func(wo cty.Value, plain cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{"sensitive_values_wo": wo, "sensitive_values": plain})
+	}
+	testCases := []struct {
+		name    string
+		config  cty.Value
+		content string
+		known   bool
+	}{
+		{"write-only", config(cty.StringVal("a: 1"), cty.NullVal(cty.String)), "a: 1", true},
+		{"plain", config(cty.NullVal(cty.String), cty.StringVal("b: 2")), "b: 2", true},
+		{"none", config(cty.NullVal(cty.String), cty.NullVal(cty.String)), "", true},
+		{"unknown", config(cty.NullVal(cty.String), cty.UnknownVal(cty.String)), "", false},
+		{"no configuration", cty.NullVal(cty.EmptyObject), "", false},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			content, known := rawSensitiveValues(testCase.config)
+			if content != testCase.content || known != testCase.known {
+				t.Errorf("expected (%q, %t), got (%q, %t)", testCase.content, testCase.known, content, known)
+			}
+		})
+	}
+}
+
+func TestSensitiveValuesStateFunc(t *testing.T) {
+	hash := sensitiveValuesStateFunc("a: 1")
+	if len(hash) != 64 {
+		t.Fatalf("expected a SHA-256 hash, got %q", hash)
+	}
+	if other := sensitiveValuesStateFunc(`{"a": 1}`); other != hash {
+		t.Errorf("expected the same values in JSON to have the same hash, got %q and %q", hash, other)
+	}
+	if empty := sensitiveValuesStateFunc(""); empty != "" {
+		t.Errorf("expected no hash without sensitive values, got %q", empty)
+	}
+}
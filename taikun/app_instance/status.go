@@ -0,0 +1,115 @@
+package app_instance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	tk "github.com/itera-io/taikungoclient"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+)
+
+const (
+	appInstanceWaitForReady   = "ready"
+	appInstanceWaitForHealthy = "healthy"
+
+	appInstanceHealthHealthy = "Healthy"
+
+	// Pending while the release is deployed but its resources are not healthy yet
+	appInstanceStateNotHealthy = "NotHealthy"
+
+	// Number of the most recent sync events reported when the application fails
+	appInstanceEventsReported = 10
+)
+
+// Error of an application instance which failed or did not become ready in time,
+// with its status, the message of its Helm release and its recent sync events as reported by Taikun
+type appInstanceError struct {
+	appId   int32
+	cause   error
+	status  string
+	health  string
+	message string
+	events  []string
+}
+
+func (e *appInstanceError) Error() string {
+	return fmt.Sprintf("%s\n%s", e.summary(), e.detail())
+}
+
+func (e *appInstanceError) Unwrap() error {
+	return e.cause
+}
+
+func (e *appInstanceError) summary() string {
+	return fmt.Sprintf("error waiting for application (%d) to be ready: %s", e.appId, e.cause)
+}
+
+func (e *appInstanceError) detail() string {
+	detail := strings.Builder{}
+	detail.WriteString(fmt.Sprintf("Status: %s\n", e.status))
+	if e.health != "" {
+		detail.WriteString(fmt.Sprintf("Health: %s\n", e.health))
+	}
+	if e.message != "" {
+		detail.WriteString(fmt.Sprintf("Helm release message: %s\n", e.message))
+	}
+	if len(e.events) > 0 {
+		detail.WriteString("Recent sync events:\n")
+		for _, event := range e.events {
+			detail.WriteString(fmt.Sprintf("  %s\n", event))
+		}
+	}
+	return strings.TrimSuffix(detail.String(), "\n")
+}
+
+// Describe the application instance as Taikun reports it after waiting for it failed.
+// If Taikun cannot be asked, the error of the wait is returned as is.
+func newAppInstanceError(ctx context.Context, apiClient *tk.Client, appId int32, cause error) error {
+	data, response, err := apiClient.Client.ProjectAppsAPI.ProjectappDetails(ctx, appId).Execute()
+	if err != nil {
+		log.Printf("[DEBUG] Unable to describe application instance %d: %s", appId, utils.NewApiError(response, err))
+		return fmt.Errorf("error waiting for application (%d) to be ready: %s", appId, cause)
+	}
+
+	appErr := &appInstanceError{
+		appId:   appId,
+		cause:   cause,
+		status:  string(data.GetStatus()),
+		health:  data.GetHealthStatus(),
+		message: data.GetMessage(),
+	}
+
+	events, response, err := apiClient.Client.ProjectAppsAPI.ProjectappEvents(ctx, appId).Execute()
+	if err != nil {
+		log.Printf("[DEBUG] Unable to list the events of application instance %d: %s", appId, utils.NewApiError(response, err))
+		return appErr
+	}
+	if len(events) > appInstanceEventsReported {
+		events = events[len(events)-appInstanceEventsReported:]
+	}
+	for _, event := range events {
+		appErr.events = append(appErr.events, fmt.Sprintf("%s %s: %s", event.GetTimestamp(), event.GetReason(), event.GetMessage()))
+	}
+
+	return appErr
+}
+
+// Diagnostics of an error of the application instance, with what Taikun reports about the application in their detail.
+// Errors of the Taikun API point at the attribute they relate to.
+func appInstanceDiagnostics(err error) diag.Diagnostics {
+	var appErr *appInstanceError
+	if !errors.As(err, &appErr) {
+		return utils.DiagnosticsFromError(err)
+	}
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  appErr.summary(),
+			Detail:   appErr.detail(),
+		},
+	}
+}
@@ -0,0 +1,32 @@
+//go:build sweep
+
+package app_instance
+
+import (
+	"context"
+
+	tk "github.com/itera-io/taikungoclient"
+	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+)
+
+// Delete the application instances left behind by the acceptance tests, before their projects are deleted
+func AddTestSweepers(client utils.SweeperClientFunc) {
+	utils.AddListTestSweeper("taikun_app_instance", client, nil,
+		func(ctx context.Context, apiClient *tk.Client, offset int32) ([]tkcore.InstanceAppListDto, int, error) {
+			response, res, err := apiClient.Client.ProjectAppsAPI.ProjectappList(ctx).Offset(offset).Execute()
+			if err != nil {
+				return nil, 0, utils.NewApiError(res, err)
+			}
+			return response.GetData(), int(response.GetTotalCount()), nil
+		},
+		(*tkcore.InstanceAppListDto).GetName,
+		func(ctx context.Context, apiClient *tk.Client, appInstance *tkcore.InstanceAppListDto) error {
+			// The project is locked while the application is uninstalled
+			attributes := map[string]interface{}{
+				"project_id": utils.I32toa(appInstance.GetProjectId()),
+			}
+			return utils.SweepResource(ctx, ResourceTaikunAppInstance(), utils.I32toa(appInstance.GetId()), attributes, apiClient)
+		},
+	)
+}
@@ -58,6 +58,15 @@ func testAccAppInstanceName() string {
 	return strings.ToLower(utils.ShortRandomTestName())
 }
 
+// Import the application instance with the ID project_id/name
+func testAccResourceTaikunAppInstanceImportStateId(state *terraform.State) (string, error) {
+	rs, found := state.RootModule().Resources["taikun_app_instance.foo"]
+	if !found {
+		return "", errors.New("taikun_app_instance.foo not found in state")
+	}
+	return fmt.Sprintf("%s/%s", rs.Primary.Attributes["project_id"], rs.Primary.Attributes["name"]), nil
+}
+
 func testAccCheckTaikunAppInstanceExists(state *terraform.State) error {
 	client := utils_testing.TestAccProvider.Meta().(*tk.Client)
 
@@ -0,0 +1,145 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	tk "github.com/itera-io/taikungoclient"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils_testing"
+)
+
+const testAccResourceTaikunAppDeploymentConfig = testAccAppInstancePrerequisites + `
+resource "taikun_app_deployment" "foo" {
+  name           = "%s"
+  namespace      = "%s"
+  catalog_app_id = local.catalog_app_id
+  project_ids    = ["%s"]
+  timeout        = 30
+
+  values = yamlencode({
+    controller = {
+      replicaCount = %d
+    }
+  })
+
+  depends_on = [taikun_catalog_project_binding.foo]
+}
+`
+
+func TestAccResourceTaikunAppDeployment(t *testing.T) {
+	projectID := os.Getenv("TAIKUN_PROJECT_ID")
+	catalogName := utils.RandomTestName()
+	appName := testAccAppInstanceName()
+	namespace := appName + "-ns"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckAppInstance(t) },
+		ProviderFactories: utils_testing.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckTaikunAppDeploymentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccResourceTaikunAppDeploymentConfig,
+					catalogName,
+					projectID,
+					appName,
+					namespace,
+					projectID,
+					1,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunAppDeploymentExists,
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "project_ids.#", "1"),
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "project.#", "1"),
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "project.0.project_id", projectID),
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "project.0.status", "Ready"),
+					resource.TestCheckResourceAttrSet("taikun_app_deployment.foo", "project.0.app_instance_id"),
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "merged_values", "controller:\n  replicaCount: 1\n"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccResourceTaikunAppDeploymentConfig,
+					catalogName,
+					projectID,
+					appName,
+					namespace,
+					projectID,
+					2,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunAppDeploymentExists,
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "project.#", "1"),
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "merged_values", "controller:\n  replicaCount: 2\n"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckTaikunAppDeploymentExists(state *terraform.State) error {
+	client := utils_testing.TestAccProvider.Meta().(*tk.Client)
+
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "taikun_app_deployment" {
+			continue
+		}
+
+		for _, id := range testAccAppDeploymentInstanceIds(rs.Primary.Attributes) {
+			_, _, err := client.Client.ProjectAppsAPI.ProjectappDetails(context.TODO(), id).Execute()
+			if err != nil {
+				return fmt.Errorf("app instance %d of the deployment doesn't exist (id = %s)", id, rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckTaikunAppDeploymentDestroy(state *terraform.State) error {
+	client := utils_testing.TestAccProvider.Meta().(*tk.Client)
+
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "taikun_app_deployment" {
+			continue
+		}
+
+		for _, id := range testAccAppDeploymentInstanceIds(rs.Primary.Attributes) {
+			retryErr := retry.RetryContext(context.Background(), utils.GetReadAfterOpTimeout(false), func() *retry.RetryError {
+				response, _, err := client.Client.ProjectAppsAPI.ProjectappList(context.TODO()).Id(id).Execute()
+				if err != nil {
+					return retry.NonRetryableError(err)
+				}
+				if response.GetTotalCount() != 0 {
+					return retry.RetryableError(errors.New("app instance of the deployment still exists"))
+				}
+				return nil
+			})
+			if utils.TimedOut(retryErr) {
+				return errors.New("app instance of the deployment still exists (timed out)")
+			}
+			if retryErr != nil {
+				return retryErr
+			}
+		}
+	}
+
+	return nil
+}
+
+// IDs of the application instances of the deployment, in every project
+func testAccAppDeploymentInstanceIds(attributes map[string]string) []int32 {
+	count, _ := strconv.Atoi(attributes["project.#"])
+	ids := make([]int32, 0, count)
+	for i := 0; i < count; i++ {
+		id, _ := utils.Atoi32(attributes[fmt.Sprintf("project.%d.app_instance_id", i)])
+		ids = append(ids, id)
+	}
+	return ids
+}
@@ -0,0 +1,148 @@
+package testing
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils_testing"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils_testing/faketaikun"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testUnitResourceTaikunAppDeploymentConfig = `
+resource "taikun_app_deployment" "foo" {
+  name           = "ingress"
+  namespace      = "ingress-ns"
+  catalog_app_id = "%d"
+  project_ids    = [%s]
+  timeout        = 30
+
+  values = yamlencode({
+    controller = {
+      replicaCount = %d
+    }
+  })
+}
+`
+
+func testUnitAppDeploymentProjectIds(projectIds ...int32) string {
+	quoted := make([]string, 0, len(projectIds))
+	for _, projectId := range projectIds {
+		quoted = append(quoted, fmt.Sprintf("%q", fmt.Sprint(projectId)))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// TestUnitResourceTaikunAppDeployment runs against the fake Taikun server.
+// The application fails in one of the two projects: the deployment is not tainted, the failed project is left out of project_ids
+// and removing it from the configuration uninstalls its application instance. Values then change in two projects at once.
+func TestUnitResourceTaikunAppDeployment(t *testing.T) {
+	server := faketaikun.Start(t)
+	projectID := server.AddProject("apps")
+	failingProjectID := server.AddProject("failing")
+	otherProjectID := server.AddProject("other")
+	catalogAppID := server.AddCatalogApp("taikun-managed-apps", "ingress-nginx", "4.11.0")
+	server.FailAppInstalls(failingProjectID)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: utils_testing.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckTaikunAppDeploymentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testUnitResourceTaikunAppDeploymentConfig, catalogAppID, testUnitAppDeploymentProjectIds(projectID, failingProjectID), 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "project.#", "2"),
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "project.0.project_id", fmt.Sprint(projectID)),
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "project.0.status", "Ready"),
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "project.1.project_id", fmt.Sprint(failingProjectID)),
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "project.1.status", "Failure"),
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "project_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttr("taikun_app_deployment.foo", "project_ids.*", fmt.Sprint(projectID)),
+				),
+				// The failed project is planned to be installed again
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: fmt.Sprintf(testUnitResourceTaikunAppDeploymentConfig, catalogAppID, testUnitAppDeploymentProjectIds(projectID), 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunAppDeploymentExists,
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "project.#", "1"),
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "project.0.project_id", fmt.Sprint(projectID)),
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "project_ids.#", "1"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testUnitResourceTaikunAppDeploymentConfig, catalogAppID, testUnitAppDeploymentProjectIds(projectID, otherProjectID), 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunAppDeploymentExists,
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "project.#", "2"),
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "project.0.status", "Ready"),
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "project.1.project_id", fmt.Sprint(otherProjectID)),
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "project.1.status", "Ready"),
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "project_ids.#", "2"),
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "merged_values", "controller:\n  replicaCount: 2\n"),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitResourceTaikunAppDeploymentUpdateFailure verifies that a project where the values fail to be synced is left out of project_ids,
+// so that the next apply installs the application there again
+func TestUnitResourceTaikunAppDeploymentUpdateFailure(t *testing.T) {
+	server := faketaikun.Start(t)
+	projectID := server.AddProject("apps")
+	failingProjectID := server.AddProject("failing")
+	catalogAppID := server.AddCatalogApp("taikun-managed-apps", "ingress-nginx", "4.11.0")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: utils_testing.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckTaikunAppDeploymentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testUnitResourceTaikunAppDeploymentConfig, catalogAppID, testUnitAppDeploymentProjectIds(projectID, failingProjectID), 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "project_ids.#", "2"),
+				),
+			},
+			{
+				PreConfig: func() {
+					server.FailAppInstalls(failingProjectID)
+				},
+				Config: fmt.Sprintf(testUnitResourceTaikunAppDeploymentConfig, catalogAppID, testUnitAppDeploymentProjectIds(projectID, failingProjectID), 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "project.#", "2"),
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "project.0.status", "Ready"),
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "project.1.project_id", fmt.Sprint(failingProjectID)),
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "project.1.status", "Failure"),
+					resource.TestCheckResourceAttr("taikun_app_deployment.foo", "project_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttr("taikun_app_deployment.foo", "project_ids.*", fmt.Sprint(projectID)),
+				),
+				// The failed project is planned to be installed again
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// TestUnitResourceTaikunAppDeploymentFailure verifies that the apply fails when the application is deployed in none of the projects
+func TestUnitResourceTaikunAppDeploymentFailure(t *testing.T) {
+	server := faketaikun.Start(t)
+	failingProjectID := server.AddProject("failing")
+	catalogAppID := server.AddCatalogApp("taikun-managed-apps", "ingress-nginx", "4.11.0")
+	server.FailAppInstalls(failingProjectID)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: utils_testing.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckTaikunAppDeploymentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testUnitResourceTaikunAppDeploymentConfig, catalogAppID, testUnitAppDeploymentProjectIds(failingProjectID), 1),
+				ExpectError: regexp.MustCompile(fmt.Sprintf(`project %d: error waiting for application \(\d+\) to be ready`, failingProjectID)),
+			},
+		},
+	})
+}
@@ -3,6 +3,7 @@ package testing
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -53,8 +54,27 @@ func TestAccResourceTaikunAppInstance(t *testing.T) {
 					resource.TestCheckResourceAttr("taikun_app_instance.foo", "project_id", projectID),
 					resource.TestCheckResourceAttrSet("taikun_app_instance.foo", "catalog_app_id"),
 					resource.TestCheckResourceAttr("taikun_app_instance.foo", "autosync", "false"),
+					resource.TestCheckResourceAttrSet("taikun_app_instance.foo", "version"),
+					resource.TestCheckResourceAttrSet("taikun_app_instance.foo", "available_versions.#"),
 				),
 			},
+			{
+				ResourceName:      "taikun_app_instance.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"timeout",
+				},
+			},
+			{
+				ResourceName:      "taikun_app_instance.foo",
+				ImportState:       true,
+				ImportStateIdFunc: testAccResourceTaikunAppInstanceImportStateId,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"timeout",
+				},
+			},
 		},
 	})
 }
@@ -116,3 +136,374 @@ func TestAccResourceTaikunAppInstanceUpdateAutosync(t *testing.T) {
 		},
 	})
 }
+
+const testAccResourceTaikunAppInstanceValuesConfig = testAccAppInstancePrerequisites + `
+resource "taikun_app_instance" "foo" {
+  name           = "%s"
+  namespace      = "%s"
+  project_id     = "%s"
+  catalog_app_id = local.catalog_app_id
+  timeout        = 30
+  wait_for       = ["ready", "healthy"]
+
+  values = yamlencode({
+    controller = {
+      replicaCount = %d
+    }
+  })
+
+  depends_on = [taikun_catalog_project_binding.foo]
+}
+`
+
+// TestAccResourceTaikunAppInstanceValues verifies that values given in HCL are stored as canonical YAML
+// and that changing one of them updates the application instance in place, waiting for it to be healthy.
+func TestAccResourceTaikunAppInstanceValues(t *testing.T) {
+	projectID := os.Getenv("TAIKUN_PROJECT_ID")
+	catalogName := utils.RandomTestName()
+	appName := testAccAppInstanceName()
+	namespace := appName + "-ns"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckAppInstance(t) },
+		ProviderFactories: utils_testing.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckTaikunAppInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccResourceTaikunAppInstanceValuesConfig,
+					catalogName,
+					projectID,
+					appName,
+					namespace,
+					projectID,
+					1,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunAppInstanceExists,
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "values", "controller:\n  replicaCount: 1\n"),
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "merged_values", "controller:\n  replicaCount: 1\n"),
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "wait_for.#", "2"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccResourceTaikunAppInstanceValuesConfig,
+					catalogName,
+					projectID,
+					appName,
+					namespace,
+					projectID,
+					2,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunAppInstanceExists,
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "values", "controller:\n  replicaCount: 2\n"),
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "merged_values", "controller:\n  replicaCount: 2\n"),
+				),
+			},
+		},
+	})
+}
+
+const testAccResourceTaikunAppInstanceSensitiveValuesConfig = testAccAppInstancePrerequisites + `
+resource "taikun_app_instance" "foo" {
+  name           = "%s"
+  namespace      = "%s"
+  project_id     = "%s"
+  catalog_app_id = local.catalog_app_id
+  timeout        = 30
+
+  values = yamlencode({
+    controller = {
+      replicaCount = 1
+    }
+  })
+
+  sensitive_values = yamlencode({
+    controller = {
+      podAnnotations = {
+        "example.com/secret" = "%s"
+      }
+    }
+  })
+
+  depends_on = [taikun_catalog_project_binding.foo]
+}
+`
+
+const testAccResourceTaikunAppInstanceSensitiveValuesBase64Config = testAccAppInstancePrerequisites + `
+resource "taikun_app_instance" "foo" {
+  name           = "%s"
+  namespace      = "%s"
+  project_id     = "%s"
+  catalog_app_id = local.catalog_app_id
+  timeout        = 30
+
+  parameters_base64 = base64encode(yamlencode({
+    controller = {
+      replicaCount = 1
+      podAnnotations = {
+        "example.com/secret" = "placeholder"
+      }
+    }
+  }))
+
+  sensitive_values = yamlencode({
+    controller = {
+      podAnnotations = {
+        "example.com/secret" = "%s"
+      }
+    }
+  })
+
+  depends_on = [taikun_catalog_project_binding.foo]
+}
+`
+
+// TestAccResourceTaikunAppInstanceSensitiveValues verifies that only a hash of the sensitive values is stored,
+// that they are left out of merged_values and that changing them updates the application instance in place.
+// With parameters_base64, the sensitive key also set there as a placeholder keeps the placeholder and no change is planned.
+func TestAccResourceTaikunAppInstanceSensitiveValues(t *testing.T) {
+	projectID := os.Getenv("TAIKUN_PROJECT_ID")
+	catalogName := utils.RandomTestName()
+	appName := testAccAppInstanceName()
+	namespace := appName + "-ns"
+	hashRegexp := regexp.MustCompile("^[0-9a-f]{64}$")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckAppInstance(t) },
+		ProviderFactories: utils_testing.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckTaikunAppInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccResourceTaikunAppInstanceSensitiveValuesConfig,
+					catalogName,
+					projectID,
+					appName,
+					namespace,
+					projectID,
+					"first",
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunAppInstanceExists,
+					resource.TestMatchResourceAttr("taikun_app_instance.foo", "sensitive_values", hashRegexp),
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "sensitive_value_keys.#", "1"),
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "sensitive_value_keys.0", `controller.podAnnotations.example\.com/secret`),
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "merged_values", "controller:\n  replicaCount: 1\n"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccResourceTaikunAppInstanceSensitiveValuesConfig,
+					catalogName,
+					projectID,
+					appName,
+					namespace,
+					projectID,
+					"second",
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunAppInstanceExists,
+					resource.TestMatchResourceAttr("taikun_app_instance.foo", "sensitive_values", hashRegexp),
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "merged_values", "controller:\n  replicaCount: 1\n"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccResourceTaikunAppInstanceSensitiveValuesBase64Config,
+					catalogName,
+					projectID,
+					appName,
+					namespace,
+					projectID,
+					"third",
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunAppInstanceExists,
+					resource.TestMatchResourceAttr("taikun_app_instance.foo", "sensitive_values", hashRegexp),
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "sensitive_value_keys.0", `controller.podAnnotations.example\.com/secret`),
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "merged_values", "controller:\n  podAnnotations:\n    example.com/secret: placeholder\n  replicaCount: 1\n"),
+				),
+			},
+		},
+	})
+}
+
+const testAccResourceTaikunAppInstanceSyncRollbackConfig = testAccAppInstancePrerequisites + `
+resource "taikun_app_instance" "foo" {
+  name           = "%s"
+  namespace      = "%s"
+  project_id     = "%s"
+  catalog_app_id = local.catalog_app_id
+  timeout        = 30
+  sync_trigger   = "%s"
+  %s
+
+  values = yamlencode({
+    controller = {
+      replicaCount = %d
+    }
+  })
+
+  depends_on = [taikun_catalog_project_binding.foo]
+}
+`
+
+// TestAccResourceTaikunAppInstanceSyncRollback verifies that changing sync_trigger syncs the application instance
+// and that rollback_to_revision rolls its release back, the values of the revision being kept without a plan
+// until the values of the configuration change.
+func TestAccResourceTaikunAppInstanceSyncRollback(t *testing.T) {
+	projectID := os.Getenv("TAIKUN_PROJECT_ID")
+	catalogName := utils.RandomTestName()
+	appName := testAccAppInstanceName()
+	namespace := appName + "-ns"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckAppInstance(t) },
+		ProviderFactories: utils_testing.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckTaikunAppInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccResourceTaikunAppInstanceSyncRollbackConfig,
+					catalogName,
+					projectID,
+					appName,
+					namespace,
+					projectID,
+					"first",
+					"",
+					1,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunAppInstanceExists,
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "sync_trigger", "first"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccResourceTaikunAppInstanceSyncRollbackConfig,
+					catalogName,
+					projectID,
+					appName,
+					namespace,
+					projectID,
+					"first",
+					"",
+					2,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunAppInstanceExists,
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "merged_values", "controller:\n  replicaCount: 2\n"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccResourceTaikunAppInstanceSyncRollbackConfig,
+					catalogName,
+					projectID,
+					appName,
+					namespace,
+					projectID,
+					"second",
+					"",
+					2,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunAppInstanceExists,
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "sync_trigger", "second"),
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "merged_values", "controller:\n  replicaCount: 2\n"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccResourceTaikunAppInstanceSyncRollbackConfig,
+					catalogName,
+					projectID,
+					appName,
+					namespace,
+					projectID,
+					"second",
+					"rollback_to_revision = 1",
+					2,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunAppInstanceExists,
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "rollback_to_revision", "1"),
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "merged_values", "controller:\n  replicaCount: 1\n"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccResourceTaikunAppInstanceSyncRollbackConfig,
+					catalogName,
+					projectID,
+					appName,
+					namespace,
+					projectID,
+					"second",
+					"rollback_to_revision = 1",
+					3,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunAppInstanceExists,
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "merged_values", "controller:\n  replicaCount: 3\n"),
+				),
+			},
+		},
+	})
+}
+
+const testAccResourceTaikunAppInstanceParametersYamlConfig = testAccAppInstancePrerequisites + `
+resource "taikun_app_instance" "foo" {
+  name            = "%s"
+  namespace       = "%s"
+  project_id      = "%s"
+  catalog_app_id  = local.catalog_app_id
+  timeout         = 30
+  parameters_yaml = "%s"
+
+  depends_on = [taikun_catalog_project_binding.foo]
+}
+`
+
+// TestAccResourceTaikunAppInstanceParametersYamlContent verifies that changing the content of the
+// parameters_yaml file, while keeping its path, updates the application instance in place.
+func TestAccResourceTaikunAppInstanceParametersYamlContent(t *testing.T) {
+	projectID := os.Getenv("TAIKUN_PROJECT_ID")
+	catalogName := utils.RandomTestName()
+	appName := testAccAppInstanceName()
+	namespace := appName + "-ns"
+	parametersFile := t.TempDir() + "/values.yaml"
+
+	writeParameters := func(replicaCount int) {
+		content := fmt.Sprintf("controller:\n  replicaCount: %d\n", replicaCount)
+		if err := os.WriteFile(parametersFile, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	config := fmt.Sprintf(testAccResourceTaikunAppInstanceParametersYamlConfig,
+		catalogName,
+		projectID,
+		appName,
+		namespace,
+		projectID,
+		parametersFile,
+	)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckAppInstance(t) },
+		ProviderFactories: utils_testing.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckTaikunAppInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { writeParameters(1) },
+				Config:    config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunAppInstanceExists,
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "merged_values", "controller:\n  replicaCount: 1\n"),
+				),
+			},
+			{
+				PreConfig: func() { writeParameters(2) },
+				Config:    config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunAppInstanceExists,
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "merged_values", "controller:\n  replicaCount: 2\n"),
+				),
+			},
+		},
+	})
+}
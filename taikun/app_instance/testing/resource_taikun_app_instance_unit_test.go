@@ -0,0 +1,202 @@
+package testing
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils_testing"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils_testing/faketaikun"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+const testUnitResourceTaikunAppInstanceConfig = `
+resource "taikun_app_instance" "foo" {
+  name           = "ingress"
+  namespace      = "ingress-ns"
+  project_id     = "%d"
+  catalog_app_id = "%d"
+  version        = "%s"
+  autosync       = %t
+  timeout        = 30
+
+  values = yamlencode({
+    controller = {
+      replicaCount = %d
+    }
+  })
+}
+`
+
+// TestUnitResourceTaikunAppInstance runs against the fake Taikun server, the application going through Installing before being Ready.
+// It covers the install, a change of values synced in place, an upgrade, autosync and the import.
+func TestUnitResourceTaikunAppInstance(t *testing.T) {
+	server := faketaikun.Start(t)
+	projectID := server.AddProject("apps")
+	catalogAppID := server.AddCatalogApp("taikun-managed-apps", "ingress-nginx", "4.11.0", "4.11.1")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: utils_testing.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckTaikunAppInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testUnitResourceTaikunAppInstanceConfig, projectID, catalogAppID, "4.11.0", false, 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunAppInstanceExists,
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "project_id", fmt.Sprint(projectID)),
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "catalog_app_id", fmt.Sprint(catalogAppID)),
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "version", "4.11.0"),
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "available_versions.#", "2"),
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "merged_values", "controller:\n  replicaCount: 1\n"),
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "autosync", "false"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testUnitResourceTaikunAppInstanceConfig, projectID, catalogAppID, "4.11.0", false, 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "merged_values", "controller:\n  replicaCount: 2\n"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testUnitResourceTaikunAppInstanceConfig, projectID, catalogAppID, "4.11.1", true, 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "version", "4.11.1"),
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "autosync", "true"),
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "merged_values", "controller:\n  replicaCount: 2\n"),
+				),
+			},
+			{
+				ResourceName:      "taikun_app_instance.foo",
+				ImportState:       true,
+				ImportStateIdFunc: testAccResourceTaikunAppInstanceImportStateId,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"timeout",
+					"values",
+				},
+			},
+		},
+	})
+}
+
+// TestUnitResourceTaikunAppInstanceWithoutVersions verifies that the application instance is read even if the versions
+// of its package cannot be listed
+func TestUnitResourceTaikunAppInstanceWithoutVersions(t *testing.T) {
+	server := faketaikun.Start(t)
+	projectID := server.AddProject("apps")
+	catalogAppID := server.AddCatalogApp("taikun-managed-apps", "ingress-nginx", "4.11.0")
+	server.FailPackageVersions(catalogAppID)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: utils_testing.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckTaikunAppInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testUnitResourceTaikunAppInstanceConfig, projectID, catalogAppID, "4.11.0", false, 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunAppInstanceExists,
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "version", "4.11.0"),
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "available_versions.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitResourceTaikunAppInstanceFailure verifies that an application which fails to install is reported with its status
+func TestUnitResourceTaikunAppInstanceFailure(t *testing.T) {
+	server := faketaikun.Start(t)
+	projectID := server.AddProject("apps")
+	catalogAppID := server.AddCatalogApp("taikun-managed-apps", "ingress-nginx", "4.11.0")
+	server.FailAppInstalls(projectID)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: utils_testing.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckTaikunAppInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testUnitResourceTaikunAppInstanceConfig, projectID, catalogAppID, "4.11.0", false, 1),
+				ExpectError: regexp.MustCompile(`(?s)error waiting for application \(\d+\) to be ready.*Status: Failure`),
+			},
+		},
+	})
+}
+
+const testUnitResourceTaikunAppInstanceRollbackConfig = `
+resource "taikun_app_instance" "foo" {
+  name           = "ingress"
+  namespace      = "ingress-ns"
+  project_id     = "%d"
+  catalog_app_id = "%d"
+  timeout        = 30
+  %s
+
+  values = yamlencode({
+    controller = {
+      replicaCount = %d
+    }
+  })
+}
+`
+
+// TestUnitResourceTaikunAppInstanceRollback verifies that the values of the revision rolled back to are kept without a plan,
+// until they change in Taikun or the values of the configuration change
+func TestUnitResourceTaikunAppInstanceRollback(t *testing.T) {
+	server := faketaikun.Start(t)
+	projectID := server.AddProject("apps")
+	catalogAppID := server.AddCatalogApp("taikun-managed-apps", "ingress-nginx", "4.11.0")
+	var appInstanceID int32
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: utils_testing.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckTaikunAppInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testUnitResourceTaikunAppInstanceRollbackConfig, projectID, catalogAppID, "", 1),
+			},
+			{
+				Config: fmt.Sprintf(testUnitResourceTaikunAppInstanceRollbackConfig, projectID, catalogAppID, "", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "merged_values", "controller:\n  replicaCount: 2\n"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testUnitResourceTaikunAppInstanceRollbackConfig, projectID, catalogAppID, "rollback_to_revision = 1", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "rollback_to_revision", "1"),
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "merged_values", "controller:\n  replicaCount: 1\n"),
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "rolled_back_values", "controller:\n  replicaCount: 1\n"),
+					func(state *terraform.State) error {
+						id, err := strconv.ParseInt(state.RootModule().Resources["taikun_app_instance.foo"].Primary.ID, 10, 32)
+						appInstanceID = int32(id)
+						return err
+					},
+				),
+			},
+			{
+				// Values changed in Taikun after the rollback are drift
+				PreConfig: func() {
+					server.EditAppValues(appInstanceID, "controller:\n  replicaCount: 5\n")
+				},
+				Config:             fmt.Sprintf(testUnitResourceTaikunAppInstanceRollbackConfig, projectID, catalogAppID, "rollback_to_revision = 1", 2),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: fmt.Sprintf(testUnitResourceTaikunAppInstanceRollbackConfig, projectID, catalogAppID, "rollback_to_revision = 1", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "merged_values", "controller:\n  replicaCount: 2\n"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testUnitResourceTaikunAppInstanceRollbackConfig, projectID, catalogAppID, "rollback_to_revision = 1", 3),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("taikun_app_instance.foo", "merged_values", "controller:\n  replicaCount: 3\n"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,273 @@
+package app_instance
+
+import (
+	"bytes"
+	"context"
+	b64 "encoding/base64"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Values given with values and values_files are merged the way Helm merges values files:
+// the files in order, then values, maps being merged key by key and any other value replacing the previous one.
+// The result is stored in merged_values as canonical YAML, with its keys sorted, so that plans show which keys changed.
+
+// Implemented by both schema.ResourceData and schema.ResourceDiff
+type valuesGetter interface {
+	Get(key string) interface{}
+}
+
+func valuesSpecified(d valuesGetter) bool {
+	return d.Get("values").(string) != "" || len(d.Get("values_files").([]interface{})) > 0
+}
+
+// Parse YAML or JSON values, which must be an object
+func parseValues(content string) (map[string]interface{}, error) {
+	var parsed interface{}
+	if err := yaml.Unmarshal([]byte(content), &parsed); err != nil {
+		return nil, err
+	}
+	if parsed == nil {
+		return map[string]interface{}{}, nil
+	}
+	values, isObject := parsed.(map[string]interface{})
+	if !isObject {
+		return nil, fmt.Errorf("expected a YAML or JSON object, got %T", parsed)
+	}
+	return values, nil
+}
+
+func mergeValues(dst map[string]interface{}, src map[string]interface{}) {
+	for key, srcValue := range src {
+		srcMap, srcIsMap := srcValue.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			mergeValues(dstMap, srcMap)
+			continue
+		}
+		dst[key] = srcValue
+	}
+}
+
+// Canonical YAML of the values, empty if there are none
+func canonicalValues(values map[string]interface{}) (string, error) {
+	if len(values) == 0 {
+		return "", nil
+	}
+	var content bytes.Buffer
+	encoder := yaml.NewEncoder(&content)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(values); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return content.String(), nil
+}
+
+func normalizeValues(content string) (string, error) {
+	values, err := parseValues(content)
+	if err != nil {
+		return "", err
+	}
+	return canonicalValues(values)
+}
+
+// Merge the values files, then the inline values, into canonical YAML
+func mergedValues(inlineValues string, valuesFiles []string) (string, error) {
+	merged := map[string]interface{}{}
+	for _, valuesFile := range valuesFiles {
+		content, err := os.ReadFile(valuesFile)
+		if err != nil {
+			return "", err
+		}
+		values, err := parseValues(string(content))
+		if err != nil {
+			return "", fmt.Errorf("invalid values in %s: %s", valuesFile, err)
+		}
+		mergeValues(merged, values)
+	}
+
+	values, err := parseValues(inlineValues)
+	if err != nil {
+		return "", fmt.Errorf("invalid values: %s", err)
+	}
+	mergeValues(merged, values)
+
+	return canonicalValues(merged)
+}
+
+// Base64 encoded values sent to Taikun as the extra values of the application instance
+func mergedValuesBase64(d *schema.ResourceData) (string, error) {
+	merged, err := mergedValues(d.Get("values").(string), utils.ResourceGetStringList(d.Get("values_files")))
+	if err != nil {
+		return "", err
+	}
+	return b64.StdEncoding.EncodeToString([]byte(merged)), nil
+}
+
+// Canonical YAML of the values returned by Taikun, as is if they cannot be parsed
+func flattenMergedValues(remoteValues string) string {
+	normalized, err := normalizeValues(remoteValues)
+	if err != nil {
+		return remoteValues
+	}
+	return normalized
+}
+
+// Whether both base64 encoded values are the same once normalized, any drift in Taikun is shown in the plan otherwise
+func sameValues(encodedA string, encodedB string) bool {
+	if encodedA == encodedB {
+		return true
+	}
+	a, errA := b64.StdEncoding.DecodeString(encodedA)
+	b, errB := b64.StdEncoding.DecodeString(encodedB)
+	if errA != nil || errB != nil {
+		return false
+	}
+	normalizedA, errA := normalizeValues(string(a))
+	normalizedB, errB := normalizeValues(string(b))
+	return errA == nil && errB == nil && normalizedA == normalizedB
+}
+
+func validateValues(i interface{}, path cty.Path) diag.Diagnostics {
+	content, ok := i.(string)
+	if !ok {
+		return diag.FromErr(path.NewErrorf("expected type to be string"))
+	}
+	if _, err := parseValues(content); err != nil {
+		return diag.FromErr(path.NewErrorf("invalid values: %s", err))
+	}
+	return nil
+}
+
+// Store the values as canonical YAML, so that formatting, key order or JSON instead of YAML do not cause a diff
+func valuesStateFunc(i interface{}) string {
+	normalized, err := normalizeValues(i.(string))
+	if err != nil {
+		// Already reported by validateValues
+		return i.(string)
+	}
+	return normalized
+}
+
+// Attributes which give the values, taikun_app_deployment has values and values_files only
+func valuesAttributes(rawConfig cty.Value) []string {
+	keys := []string{"values", "values_files"}
+	for _, key := range []string{"parameters_yaml", "parameters_base64"} {
+		if rawConfig.Type().IsObjectType() && rawConfig.Type().HasAttribute(key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Canonical YAML of the values given by the configuration, read from whichever attributes it uses.
+// known is false when they cannot be planned: part of the configuration is unknown or the parameters are not a YAML object.
+func plannedMergedValues(d *schema.ResourceDiff) (merged string, known bool, err error) {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return "", false, nil
+	}
+	keys := valuesAttributes(rawConfig)
+	for _, key := range keys {
+		if !rawConfig.GetAttr(key).IsWhollyKnown() {
+			return "", false, nil
+		}
+	}
+
+	if valuesSpecified(d) {
+		merged, err = mergedValues(d.Get("values").(string), utils.ResourceGetStringList(d.Get("values_files")))
+		return merged, err == nil, err
+	}
+
+	if !slices.Contains(keys, "parameters_yaml") {
+		// No values are given
+		return "", true, nil
+	}
+
+	// The state holds the content of parameters_yaml, the configuration the path of the file
+	var content []byte
+	if yamlFile := rawConfig.GetAttr("parameters_yaml"); !yamlFile.IsNull() && yamlFile.AsString() != "" {
+		content, err = os.ReadFile(yamlFile.AsString())
+		if err != nil {
+			return "", false, err
+		}
+	} else if encoded := rawConfig.GetAttr("parameters_base64"); !encoded.IsNull() {
+		content, err = b64.StdEncoding.DecodeString(encoded.AsString())
+		if err != nil {
+			return "", false, nil
+		}
+	}
+
+	normalized, err := normalizeValues(string(content))
+	if err != nil {
+		return "", false, nil
+	}
+	return normalized, true, nil
+}
+
+// After a rollback, Taikun holds the values of the revision, recorded in rolled_back_values. They are not compared with the configuration,
+// nor sent again, as long as rollback_to_revision is set, Taikun still holds them and the values of the configuration, sensitive values included, do not change.
+func rollbackKeepsValues(d *schema.ResourceDiff) bool {
+	return d.Id() != "" && holdsRolledBackValues(d, d.Get("merged_values").(string)) && !d.HasChanges(valuesAttributes(d.GetRawConfig())...) &&
+		!d.HasChanges("sensitive_values", "sensitive_values_wo_version")
+}
+
+// Whether the values read from Taikun, as canonical YAML, are still those the release was rolled back to.
+// Any other change of the values in Taikun is drift, shown in the plan.
+func holdsRolledBackValues(d valuesGetter, remoteMergedValues string) bool {
+	// The data source, which shares the read of the resource, has neither attribute
+	revision, _ := d.Get("rollback_to_revision").(int)
+	rolledBackValues, _ := d.Get("rolled_back_values").(string)
+	return revision != 0 && rolledBackValues != "" &&
+		strings.TrimSpace(rolledBackValues) == strings.TrimSpace(remoteMergedValues)
+}
+
+// Plan merged_values from the configuration, files included, so that changing the content of a values file
+// or of the parameters_yaml file shows in the plan, as does drift of the values in Taikun
+func customizeDiffMergedValues(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	merged, known, err := plannedMergedValues(d)
+	if err != nil {
+		return err
+	}
+	if d.Id() != "" && d.HasChange("rollback_to_revision") {
+		if d.Get("rollback_to_revision").(int) == 0 {
+			if err = d.SetNew("rolled_back_values", ""); err != nil {
+				return err
+			}
+		} else {
+			// The values of the revision are only known once rolled back, they are not replaced by those of the configuration
+			// unless the configuration changes as well
+			if err = d.SetNewComputed("rolled_back_values"); err != nil {
+				return err
+			}
+			if !d.HasChanges(valuesAttributes(d.GetRawConfig())...) && !d.HasChanges("sensitive_values", "sensitive_values_wo_version") {
+				return nil
+			}
+		}
+	}
+	if rollbackKeepsValues(d) {
+		return nil
+	}
+	if !known {
+		// Read back from Taikun after the new parameters are sent
+		if d.Id() != "" && d.HasChanges(valuesAttributes(d.GetRawConfig())...) {
+			return d.SetNewComputed("merged_values")
+		}
+		return nil
+	}
+	if strings.TrimSpace(merged) != strings.TrimSpace(d.Get("merged_values").(string)) {
+		return d.SetNew("merged_values", merged)
+	}
+	return nil
+}
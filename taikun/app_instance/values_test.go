@@ -0,0 +1,304 @@
+package app_instance
+
+import (
+	"context"
+	b64 "encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestParseValues(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  string
+		expected string
+		isError  bool
+	}{
+		{"yaml object", "replicas: 1\nauth:\n  user: u\n", "auth:\n  user: u\nreplicas: 1\n", false},
+		{"json object", `{"replicas": 1, "auth": {"user": "u"}}`, "auth:\n  user: u\nreplicas: 1\n", false},
+		{"empty", "", "", false},
+		{"comment only", "# no values\n", "", false},
+		{"null", "null", "", false},
+		{"list", "- a\n- b\n", "", true},
+		{"scalar", "replicas", "", true},
+		{"invalid", "replicas: [1", "", true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			values, err := parseValues(testCase.content)
+			if testCase.isError {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", values)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if values == nil {
+				t.Fatal("expected an empty map rather than nil")
+			}
+			if result, _ := canonicalValues(values); result != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, result)
+			}
+		})
+	}
+}
+
+func TestMergeValues(t *testing.T) {
+	testCases := []struct {
+		name     string
+		dst      string
+		src      string
+		expected string
+	}{
+		{"new keys added", "a: 1\n", "b: 2\n", "a: 1\nb: 2\n"},
+		{"scalar replaced", "a: 1\n", "a: 2\n", "a: 2\n"},
+		{"maps merged key by key", "auth:\n  user: u\n  password: p\n", "auth:\n  password: q\n", "auth:\n  password: q\n  user: u\n"},
+		{"nested maps merged", "a:\n  b:\n    c: 1\n    d: 2\n", "a:\n  b:\n    d: 3\n", "a:\n  b:\n    c: 1\n    d: 3\n"},
+		{"lists replaced", "hosts:\n  - a\n  - b\n", "hosts:\n  - c\n", "hosts:\n  - c\n"},
+		{"map replaced by a scalar", "auth:\n  user: u\n", "auth: none\n", "auth: none\n"},
+		{"scalar replaced by a map", "auth: none\n", "auth:\n  user: u\n", "auth:\n  user: u\n"},
+		{"empty source", "a: 1\n", "", "a: 1\n"},
+		{"empty destination", "", "a: 1\n", "a: 1\n"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dst, err := parseValues(testCase.dst)
+			if err != nil {
+				t.Fatal(err)
+			}
+			src, err := parseValues(testCase.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			mergeValues(dst, src)
+			if result, _ := canonicalValues(dst); result != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, result)
+			}
+		})
+	}
+}
+
+func TestNormalizeValues(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  string
+		expected string
+		isError  bool
+	}{
+		{"keys sorted", "b: 1\na: 2\n", "a: 2\nb: 1\n", false},
+		{"json", `{"b": {"d": [1, 2], "c": true}, "a": "x"}`, "a: x\nb:\n  c: true\n  d:\n    - 1\n    - 2\n", false},
+		{"indentation", "a:\n    b: 1\n", "a:\n  b: 1\n", false},
+		{"empty", "", "", false},
+		{"empty object", "{}", "", false},
+		{"not an object", "- a\n", "", true},
+		{"invalid", "a: [", "", true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := normalizeValues(testCase.content)
+			if (err != nil) != testCase.isError {
+				t.Fatalf("expected error %t, got %v", testCase.isError, err)
+			}
+			if result != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, result)
+			}
+		})
+	}
+}
+
+// Resource with the attributes giving the values, taikun_app_instance also has the parameters
+func testValuesResource(withParameters bool, customizeDiff schema.CustomizeDiffFunc) *schema.Resource {
+	attributes := map[string]*schema.Schema{
+		"values":       {Type: schema.TypeString, Optional: true},
+		"values_files": {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+	}
+	if withParameters {
+		attributes["parameters_yaml"] = &schema.Schema{Type: schema.TypeString, Optional: true}
+		attributes["parameters_base64"] = &schema.Schema{Type: schema.TypeString, Optional: true}
+	}
+	return &schema.Resource{Schema: attributes, CustomizeDiff: customizeDiff}
+}
+
+func TestPlannedMergedValues(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name string, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	baseFile := writeFile("base.yaml", "replicas: 1\nauth:\n  user: u\n  password: p\n")
+	overrideFile := writeFile("override.json", `{"replicas": 2}`)
+	parametersFile := writeFile("parameters.yaml", "b: 1\na: 2\n")
+	listFile := writeFile("list.yaml", "- a\n")
+	missingFile := filepath.Join(dir, "missing.yaml")
+
+	null := cty.NullVal(cty.String)
+	nullList := cty.NullVal(cty.List(cty.String))
+	files := func(paths ...string) cty.Value {
+		values := make([]cty.Value, len(paths))
+		for i, path := range paths {
+			values[i] = cty.StringVal(path)
+		}
+		return cty.ListVal(values)
+	}
+
+	testCases := []struct {
+		name           string
+		withParameters bool
+		values         cty.Value
+		valuesFiles    cty.Value
+		parametersYaml cty.Value
+		parametersB64  cty.Value
+		expected       string
+		known          bool
+		isError        bool
+	}{
+		{
+			name:        "inline values",
+			values:      cty.StringVal(`{"replicas": 1}`),
+			valuesFiles: nullList,
+			expected:    "replicas: 1\n",
+			known:       true,
+		},
+		{
+			name:        "files merged in order, then inline values",
+			values:      cty.StringVal("auth:\n  password: q\n"),
+			valuesFiles: files(baseFile, overrideFile),
+			expected:    "auth:\n  password: q\n  user: u\nreplicas: 2\n",
+			known:       true,
+		},
+		{
+			name:        "no values",
+			values:      null,
+			valuesFiles: nullList,
+			expected:    "",
+			known:       true,
+		},
+		{
+			name:        "unknown values",
+			values:      cty.UnknownVal(cty.String),
+			valuesFiles: nullList,
+			known:       false,
+		},
+		{
+			name:        "unknown values file",
+			values:      null,
+			valuesFiles: cty.ListVal([]cty.Value{cty.UnknownVal(cty.String)}),
+			known:       false,
+		},
+		{
+			name:        "missing values file",
+			values:      null,
+			valuesFiles: files(missingFile),
+			known:       false,
+			isError:     true,
+		},
+		{
+			name:        "values file which is not an object",
+			values:      null,
+			valuesFiles: files(listFile),
+			known:       false,
+			isError:     true,
+		},
+		{
+			name:           "parameters_yaml",
+			withParameters: true,
+			values:         null,
+			valuesFiles:    nullList,
+			parametersYaml: cty.StringVal(parametersFile),
+			parametersB64:  null,
+			expected:       "a: 2\nb: 1\n",
+			known:          true,
+		},
+		{
+			name:           "missing parameters_yaml",
+			withParameters: true,
+			values:         null,
+			valuesFiles:    nullList,
+			parametersYaml: cty.StringVal(missingFile),
+			parametersB64:  null,
+			known:          false,
+			isError:        true,
+		},
+		{
+			name:           "parameters_base64",
+			withParameters: true,
+			values:         null,
+			valuesFiles:    nullList,
+			parametersYaml: null,
+			parametersB64:  cty.StringVal(b64.StdEncoding.EncodeToString([]byte(`{"replicas": 3}`))),
+			expected:       "replicas: 3\n",
+			known:          true,
+		},
+		{
+			name:           "parameters_base64 which is not base64",
+			withParameters: true,
+			values:         null,
+			valuesFiles:    nullList,
+			parametersYaml: null,
+			parametersB64:  cty.StringVal("not base64!"),
+			known:          false,
+		},
+		{
+			name:           "parameters which are not an object",
+			withParameters: true,
+			values:         null,
+			valuesFiles:    nullList,
+			parametersYaml: cty.StringVal(listFile),
+			parametersB64:  null,
+			known:          false,
+		},
+		{
+			name:           "no parameters",
+			withParameters: true,
+			values:         null,
+			valuesFiles:    nullList,
+			parametersYaml: null,
+			parametersB64:  null,
+			expected:       "",
+			known:          true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var merged string
+			var known bool
+			var plannedErr error
+			resource := testValuesResource(testCase.withParameters, func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+				merged, known, plannedErr = plannedMergedValues(d)
+				return nil
+			})
+
+			config := map[string]cty.Value{"values": testCase.values, "values_files": testCase.valuesFiles}
+			if testCase.withParameters {
+				config["parameters_yaml"] = testCase.parametersYaml
+				config["parameters_base64"] = testCase.parametersB64
+			}
+			rawConfig := cty.ObjectVal(config)
+			state := &terraform.InstanceState{RawConfig: rawConfig}
+			if _, err := resource.Diff(context.Background(), state, terraform.NewResourceConfigShimmed(rawConfig, resource.CoreConfigSchema()), nil); err != nil {
+				t.Fatal(err)
+			}
+
+			if (plannedErr != nil) != testCase.isError {
+				t.Fatalf("expected error %t, got %v", testCase.isError, plannedErr)
+			}
+			if known != testCase.known {
+				t.Fatalf("expected known %t, got %t", testCase.known, known)
+			}
+			if strings.TrimSpace(merged) != strings.TrimSpace(testCase.expected) {
+				t.Errorf("expected %q, got %q", testCase.expected, merged)
+			}
+		})
+	}
+}
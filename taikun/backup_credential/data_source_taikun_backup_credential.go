@@ -12,7 +12,7 @@ func dataSourceTaikunBackupCredentialSchema() map[string]*schema.Schema {
 	dsSchema := utils.DataSourceSchemaFromResourceSchema(resourceTaikunBackupCredentialSchema())
 	utils.AddRequiredFieldsToSchema(dsSchema, "id")
 	utils.SetValidateDiagFuncToSchema(dsSchema, "id", utils.StringIsInt)
-	utils.DeleteFieldsFromSchema(dsSchema, "s3_secret_access_key")
+	utils.DeleteFieldsFromSchema(dsSchema, "s3_secret_access_key", "s3_secret_access_key_wo", "s3_secret_access_key_wo_version")
 	return dsSchema
 }
 
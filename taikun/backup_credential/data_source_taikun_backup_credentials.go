@@ -54,7 +54,7 @@ func dataSourceTaikunBackupCredentialsRead(ctx context.Context, d *schema.Resour
 	for {
 		response, res, err := params.Offset(offset).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		backupCredentialsList = append(backupCredentialsList, response.Data...)
 		if len(backupCredentialsList) == int(response.GetTotalCount()) {
@@ -12,7 +12,7 @@ import (
 )
 
 func resourceTaikunBackupCredentialSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
+	resourceSchema := map[string]*schema.Schema{
 		"created_by": {
 			Description: "The creator of the backup credential.",
 			Type:        schema.TypeString,
@@ -92,6 +92,8 @@ func resourceTaikunBackupCredentialSchema() map[string]*schema.Schema {
 			ValidateFunc: validation.StringIsNotEmpty,
 		},
 	}
+	utils.AddWriteOnlySecret(resourceSchema, "s3_secret_access_key")
+	return resourceSchema
 }
 
 func ResourceTaikunBackupCredential() *schema.Resource {
@@ -102,16 +104,22 @@ func ResourceTaikunBackupCredential() *schema.Resource {
 		UpdateContext: resourceTaikunBackupCredentialUpdate,
 		DeleteContext: resourceTaikunBackupCredentialDelete,
 		Schema:        resourceTaikunBackupCredentialSchema(),
+		CustomizeDiff: utils.SetDefaultOrganizationID,
 	}
 }
 
 func resourceTaikunBackupCredentialCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(*tk.Client)
 
+	s3SecretAccessKey, diags := utils.GetRequiredSecret(d, "s3_secret_access_key")
+	if diags.HasError() {
+		return diags
+	}
+
 	body := tkcore.BackupCredentialsCreateCommand{}
 	body.SetS3Name(d.Get("name").(string))
 	body.SetS3AccessKeyId(d.Get("s3_access_key_id").(string))
-	body.SetS3SecretKey(d.Get("s3_secret_access_key").(string))
+	body.SetS3SecretKey(s3SecretAccessKey)
 	body.SetS3Region(d.Get("s3_region").(string))
 	body.SetS3Endpoint(d.Get("s3_endpoint").(string))
 
@@ -126,18 +134,18 @@ func resourceTaikunBackupCredentialCreate(ctx context.Context, d *schema.Resourc
 
 	createResult, res, err := apiClient.Client.S3CredentialsAPI.S3credentialsCreate(ctx).BackupCredentialsCreateCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 	id, err := utils.Atoi32(createResult.GetId())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	d.SetId(createResult.GetId())
 
 	if d.Get("lock").(bool) {
 		if err := resourceTaikunBackupCredentialLock(ctx, id, true, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -155,26 +163,22 @@ func generateResourceTaikunBackupCredentialRead(withRetries bool) schema.ReadCon
 		id, err := utils.Atoi32(d.Id())
 		d.SetId("")
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		response, res, err := apiClient.Client.S3CredentialsAPI.S3credentialsList(ctx).Id(id).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		if len(response.Data) != 1 {
-			if withRetries {
-				d.SetId(utils.I32toa(id))
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-			}
-			return nil
+			return utils.ReadNotFound(d, utils.I32toa(id), withRetries)
 		}
 
 		rawBackupCredential := response.Data[0]
 
 		err = utils.SetResourceDataFromMap(d, flattenTaikunBackupCredential(&rawBackupCredential))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		d.SetId(utils.I32toa(id))
@@ -187,31 +191,36 @@ func resourceTaikunBackupCredentialUpdate(ctx context.Context, d *schema.Resourc
 	apiClient := meta.(*tk.Client)
 	id, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	if locked, _ := d.GetChange("lock"); locked.(bool) {
 		if err := resourceTaikunBackupCredentialLock(ctx, id, false, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
-	if d.HasChanges("name", "s3_access_key_id", "s3_secret_access_key") {
+	if d.HasChanges("name", "s3_access_key_id") || utils.SecretHasChange(d, "s3_secret_access_key") {
+		s3SecretAccessKey, diags := utils.GetRequiredSecret(d, "s3_secret_access_key")
+		if diags.HasError() {
+			return diags
+		}
+
 		body := tkcore.BackupCredentialsUpdateCommand{}
 		body.SetId(id)
-		body.SetS3SecretKey(d.Get("s3_secret_access_key").(string))
+		body.SetS3SecretKey(s3SecretAccessKey)
 		body.SetS3AccessKeyId(d.Get("s3_access_key_id").(string))
 		body.SetS3Name(d.Get("name").(string))
 
 		res, err := apiClient.Client.S3CredentialsAPI.S3credentialsUpdate(ctx).BackupCredentialsUpdateCommand(body).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 	}
 
 	if d.Get("lock").(bool) {
 		if err := resourceTaikunBackupCredentialLock(ctx, id, true, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -222,12 +231,12 @@ func resourceTaikunBackupCredentialDelete(ctx context.Context, d *schema.Resourc
 	apiClient := meta.(*tk.Client)
 	id, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	res, err := apiClient.Client.S3CredentialsAPI.S3credentialsDelete(ctx, id).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	d.SetId("")
@@ -257,5 +266,5 @@ func resourceTaikunBackupCredentialLock(ctx context.Context, id int32, lock bool
 	body.SetId(id)
 	body.SetMode(utils.GetLockMode(lock))
 	res, err := apiClient.Client.S3CredentialsAPI.S3credentialsLockManagement(ctx).BackupLockManagerCommand(body).Execute()
-	return tk.CreateError(res, err)
+	return utils.NewApiError(res, err)
 }
@@ -0,0 +1,28 @@
+//go:build sweep
+
+package backup_credential
+
+import (
+	"context"
+
+	tk "github.com/itera-io/taikungoclient"
+	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+)
+
+// Delete the backup credentials left behind by the acceptance tests, once the projects using them are deleted
+func AddTestSweepers(client utils.SweeperClientFunc) {
+	utils.AddListTestSweeper("taikun_backup_credential", client, []string{"taikun_project"},
+		func(ctx context.Context, apiClient *tk.Client, offset int32) ([]tkcore.BackupCredentialsListDto, int, error) {
+			response, res, err := apiClient.Client.S3CredentialsAPI.S3credentialsList(ctx).Offset(offset).Execute()
+			if err != nil {
+				return nil, 0, utils.NewApiError(res, err)
+			}
+			return response.GetData(), int(response.GetTotalCount()), nil
+		},
+		(*tkcore.BackupCredentialsListDto).GetS3Name,
+		func(ctx context.Context, apiClient *tk.Client, backupCredential *tkcore.BackupCredentialsListDto) error {
+			return utils.SweepLockableResource(ctx, ResourceTaikunBackupCredential(), backupCredential.GetId(), backupCredential.GetIsLocked(), resourceTaikunBackupCredentialLock, apiClient)
+		},
+	)
+}
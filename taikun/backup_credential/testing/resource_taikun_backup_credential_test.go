@@ -13,6 +13,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 const testAccResourceTaikunBackupCredentialConfig = `
@@ -190,6 +191,83 @@ func TestAccResourceTaikunBackupCredentialRename(t *testing.T) {
 	})
 }
 
+const testAccResourceTaikunBackupCredentialWriteOnlyConfig = `
+resource "taikun_organization" "foo" {
+  name          = "%s"
+  full_name     = "%s"
+  discount_rate = 42
+}
+
+resource "taikun_backup_credential" "foo" {
+  name            = "%s"
+  organization_id = resource.taikun_organization.foo.id
+
+  s3_endpoint                     = "%s"
+  s3_region                       = "%s"
+  s3_secret_access_key_wo         = "%s"
+  s3_secret_access_key_wo_version = %d
+}
+`
+
+// TestAccResourceTaikunBackupCredentialWriteOnly verifies the secret access key can be sent without being stored in the state.
+// The key must not be set from the environment, which would store it in s3_secret_access_key, the test can therefore not run in parallel.
+func TestAccResourceTaikunBackupCredentialWriteOnly(t *testing.T) {
+	organizationName := utils.RandomTestName()
+	organizationFullName := utils.RandomTestName()
+	backupCredentialName := utils.RandomTestName()
+	secretAccessKey := os.Getenv("S3_SECRET_ACCESS_KEY")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			utils_testing.TestAccPreCheck(t)
+			utils_testing.TestAccPreCheckS3(t)
+			t.Setenv("S3_SECRET_ACCESS_KEY", "")
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		ProviderFactories: utils_testing.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckTaikunBackupCredentialDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccResourceTaikunBackupCredentialWriteOnlyConfig,
+					organizationName,
+					organizationFullName,
+					backupCredentialName,
+					os.Getenv("S3_ENDPOINT"),
+					os.Getenv("S3_REGION"),
+					secretAccessKey,
+					1,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunBackupCredentialExists,
+					resource.TestCheckResourceAttr("taikun_backup_credential.foo", "name", backupCredentialName),
+					resource.TestCheckResourceAttr("taikun_backup_credential.foo", "s3_secret_access_key", ""),
+					resource.TestCheckNoResourceAttr("taikun_backup_credential.foo", "s3_secret_access_key_wo"),
+					resource.TestCheckResourceAttr("taikun_backup_credential.foo", "s3_secret_access_key_wo_version", "1"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccResourceTaikunBackupCredentialWriteOnlyConfig,
+					organizationName,
+					organizationFullName,
+					backupCredentialName,
+					os.Getenv("S3_ENDPOINT"),
+					os.Getenv("S3_REGION"),
+					secretAccessKey,
+					2,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunBackupCredentialExists,
+					resource.TestCheckResourceAttr("taikun_backup_credential.foo", "s3_secret_access_key", ""),
+					resource.TestCheckNoResourceAttr("taikun_backup_credential.foo", "s3_secret_access_key_wo"),
+					resource.TestCheckResourceAttr("taikun_backup_credential.foo", "s3_secret_access_key_wo_version", "2"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckTaikunBackupCredentialExists(state *terraform.State) error {
 	client := utils_testing.TestAccProvider.Meta().(*tk.Client)
 
@@ -0,0 +1,63 @@
+package testing
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils_testing"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils_testing/faketaikun"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testUnitResourceTaikunBackupCredentialConfig = `
+resource "taikun_backup_credential" "foo" {
+  name = "%s"
+  lock = %t
+
+  s3_access_key_id     = "access-key"
+  s3_secret_access_key = "secret-key"
+  s3_endpoint          = "https://s3.example.com"
+  s3_region            = "eu-central-1"
+}
+`
+
+// TestUnitResourceTaikunBackupCredential runs against the fake Taikun server, it needs neither Taikun credentials nor S3 credentials.
+func TestUnitResourceTaikunBackupCredential(t *testing.T) {
+	faketaikun.Start(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: utils_testing.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckTaikunBackupCredentialDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testUnitResourceTaikunBackupCredentialConfig, "backup", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunBackupCredentialExists,
+					resource.TestCheckResourceAttr("taikun_backup_credential.foo", "name", "backup"),
+					resource.TestCheckResourceAttr("taikun_backup_credential.foo", "organization_id", fmt.Sprint(faketaikun.DefaultOrganizationID)),
+					resource.TestCheckResourceAttr("taikun_backup_credential.foo", "organization_name", faketaikun.DefaultOrganizationName),
+					resource.TestCheckResourceAttr("taikun_backup_credential.foo", "s3_access_key_id", "access-key"),
+					resource.TestCheckResourceAttr("taikun_backup_credential.foo", "s3_endpoint", "https://s3.example.com"),
+					resource.TestCheckResourceAttr("taikun_backup_credential.foo", "s3_region", "eu-central-1"),
+					resource.TestCheckResourceAttr("taikun_backup_credential.foo", "lock", "false"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testUnitResourceTaikunBackupCredentialConfig, "backup", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunBackupCredentialExists,
+					resource.TestCheckResourceAttr("taikun_backup_credential.foo", "lock", "true"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testUnitResourceTaikunBackupCredentialConfig, "renamed", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunBackupCredentialExists,
+					resource.TestCheckResourceAttr("taikun_backup_credential.foo", "name", "renamed"),
+					resource.TestCheckResourceAttr("taikun_backup_credential.foo", "lock", "false"),
+				),
+			},
+		},
+	})
+}
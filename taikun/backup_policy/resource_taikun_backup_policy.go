@@ -88,6 +88,12 @@ func resourceTaikunBackupPolicyCreate(ctx context.Context, d *schema.ResourceDat
 	apiClient := meta.(*tk.Client)
 
 	projectId, _ := utils.Atoi32(d.Get("project_id").(string))
+	unlock, err := utils.LockProject(ctx, meta, projectId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
 	body := tkcore.CreateBackupPolicyCommand{}
 	body.SetCronPeriod(d.Get("cron_period").(string))
 	body.SetIncludeNamespaces(utils.ResourceGetStringList(d.Get("included_namespaces")))
@@ -97,7 +103,7 @@ func resourceTaikunBackupPolicyCreate(ctx context.Context, d *schema.ResourceDat
 
 	res, err := apiClient.Client.BackupPolicyAPI.BackupCreate(ctx).CreateBackupPolicyCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	d.SetId(fmt.Sprintf("%d/%s", projectId, d.Get("name").(string)))
@@ -125,7 +131,7 @@ func generateResourceTaikunBackupPolicyRead(withRetries bool) schema.ReadContext
 		// maybe add search?
 		response, res, err := apiClient.Client.BackupPolicyAPI.BackupListAllSchedules(ctx, projectId).Limit(4000).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		for _, policy := range response.Data {
 			if policy.GetMetadataName() == backupPolicyName {
@@ -141,11 +147,7 @@ func generateResourceTaikunBackupPolicyRead(withRetries bool) schema.ReadContext
 			}
 		}
 
-		if withRetries {
-			d.SetId(fmt.Sprintf("%d/%s", projectId, backupPolicyName))
-			return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-		}
-		return nil
+		return utils.ReadNotFound(d, fmt.Sprintf("%d/%s", projectId, backupPolicyName), withRetries)
 	}
 }
 
@@ -156,13 +158,19 @@ func resourceTaikunBackupPolicyDelete(ctx context.Context, d *schema.ResourceDat
 		return diag.Errorf("Error while deleting taikun_backup_policy : %s", err)
 	}
 
+	unlock, err := utils.LockProject(ctx, meta, projectId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
 	deleteBody := tkcore.DeleteScheduleCommand{}
 	deleteBody.SetName(backupPolicyName)
 	deleteBody.SetProjectId(projectId)
 
 	res, err := apiClient.Client.BackupPolicyAPI.BackupDeleteSchedule(ctx).DeleteScheduleCommand(deleteBody).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	d.SetId("")
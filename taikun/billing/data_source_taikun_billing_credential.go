@@ -12,6 +12,7 @@ func dataSourceTaikunBillingCredentialSchema() map[string]*schema.Schema {
 	dsSchema := utils.DataSourceSchemaFromResourceSchema(resourceTaikunBillingCredentialSchema())
 	utils.AddRequiredFieldsToSchema(dsSchema, "id")
 	utils.SetValidateDiagFuncToSchema(dsSchema, "id", utils.StringIsInt)
+	utils.DeleteFieldsFromSchema(dsSchema, "prometheus_password_wo", "prometheus_password_wo_version")
 	return dsSchema
 }
 
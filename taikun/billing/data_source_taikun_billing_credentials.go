@@ -54,7 +54,7 @@ func dataSourceTaikunBillingCredentialsRead(ctx context.Context, d *schema.Resou
 	for {
 		response, res, err := params.Offset(offset).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		operationCredentialsList = append(operationCredentialsList, response.Data...)
 		if len(operationCredentialsList) == int(response.GetTotalCount()) {
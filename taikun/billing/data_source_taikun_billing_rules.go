@@ -4,6 +4,7 @@ import (
 	"context"
 	tk "github.com/itera-io/taikungoclient"
 	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -36,7 +37,7 @@ func dataSourceTaikunBillingRulesRead(ctx context.Context, d *schema.ResourceDat
 	for {
 		response, res, err := params.Offset(offset).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		billingRulesList = append(billingRulesList, response.Data...)
 		if len(billingRulesList) == int(response.GetTotalCount()) {
@@ -13,7 +13,7 @@ import (
 )
 
 func resourceTaikunBillingCredentialSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
+	resourceSchema := map[string]*schema.Schema{
 		"created_by": {
 			Description: "The creator of the billing credential.",
 			Type:        schema.TypeString,
@@ -88,6 +88,8 @@ func resourceTaikunBillingCredentialSchema() map[string]*schema.Schema {
 			ValidateFunc: validation.StringIsNotEmpty,
 		},
 	}
+	utils.AddWriteOnlySecret(resourceSchema, "prometheus_password")
+	return resourceSchema
 }
 
 func ResourceTaikunBillingCredential() *schema.Resource {
@@ -98,6 +100,7 @@ func ResourceTaikunBillingCredential() *schema.Resource {
 		UpdateContext: resourceTaikunBillingCredentialUpdate,
 		DeleteContext: resourceTaikunBillingCredentialDelete,
 		Schema:        resourceTaikunBillingCredentialSchema(),
+		CustomizeDiff: utils.SetDefaultOrganizationID,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -107,9 +110,14 @@ func ResourceTaikunBillingCredential() *schema.Resource {
 func resourceTaikunBillingCredentialCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(*tk.Client)
 
+	prometheusPassword, diags := utils.GetRequiredSecret(d, "prometheus_password")
+	if diags.HasError() {
+		return diags
+	}
+
 	body := tkcore.OperationCredentialsCreateCommand{}
 	body.SetName(d.Get("name").(string))
-	body.SetPrometheusPassword(d.Get("prometheus_password").(string))
+	body.SetPrometheusPassword(prometheusPassword)
 	body.SetPrometheusUrl(d.Get("prometheus_url").(string))
 	body.SetPrometheusUsername(d.Get("prometheus_username").(string))
 
@@ -124,18 +132,18 @@ func resourceTaikunBillingCredentialCreate(ctx context.Context, d *schema.Resour
 
 	createResult, res, err := apiClient.Client.OperationCredentialsAPI.OpscredentialsCreate(ctx).OperationCredentialsCreateCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 	id, err := utils.Atoi32(createResult.GetId())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	d.SetId(createResult.GetId())
 
 	if d.Get("lock").(bool) {
 		if err := resourceTaikunBillingCredentialLock(ctx, id, true, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -153,24 +161,20 @@ func generateResourceTaikunBillingCredentialRead(withRetries bool) schema.ReadCo
 		id, err := utils.Atoi32(d.Id())
 		d.SetId("")
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		rawBillingCredential, err := ResourceTaikunBillingCredentialFind(ctx, id, apiClient)
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 		if rawBillingCredential == nil {
-			if withRetries {
-				d.SetId(utils.I32toa(id))
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-			}
-			return nil
+			return utils.ReadNotFound(d, utils.I32toa(id), withRetries)
 		}
 
 		err = utils.SetResourceDataFromMap(d, flattenTaikunBillingCredential(rawBillingCredential))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		d.SetId(utils.I32toa(id))
@@ -183,12 +187,12 @@ func resourceTaikunBillingCredentialUpdate(ctx context.Context, d *schema.Resour
 	apiClient := meta.(*tk.Client)
 	id, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	if d.HasChange("lock") {
 		if err := resourceTaikunBillingCredentialLock(ctx, id, d.Get("lock").(bool), apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -199,12 +203,12 @@ func resourceTaikunBillingCredentialDelete(ctx context.Context, d *schema.Resour
 	apiClient := meta.(*tk.Client)
 	id, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	res, err := apiClient.Client.OperationCredentialsAPI.OpscredentialsDelete(ctx, id).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	d.SetId("")
@@ -235,7 +239,7 @@ func resourceTaikunBillingCredentialLock(ctx context.Context, id int32, lock boo
 	body.SetMode(utils.GetLockMode(lock))
 
 	res, err := apiClient.Client.OperationCredentialsAPI.OpscredentialsLockManager(ctx).OperationCredentialLockManagerCommand(body).Execute()
-	return tk.CreateError(res, err)
+	return utils.NewApiError(res, err)
 }
 
 // Returns the Billing Credential with the given ID or nil if it wasn't found
@@ -246,7 +250,7 @@ func ResourceTaikunBillingCredentialFind(ctx context.Context, id int32, apiClien
 	for {
 		response, res, err := params.Offset(offset).Execute()
 		if err != nil {
-			return nil, tk.CreateError(res, err)
+			return nil, utils.NewApiError(res, err)
 		}
 
 		for _, billingCredential := range response.Data {
@@ -124,7 +124,7 @@ func resourceTaikunBillingRuleCreate(ctx context.Context, d *schema.ResourceData
 
 	createResult, res, err := apiClient.Client.PrometheusRulesAPI.PrometheusrulesCreate(ctx).RuleCreateCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	d.SetId(createResult.GetId())
@@ -148,14 +148,10 @@ func generateResourceTaikunBillingRuleRead(withRetries bool) schema.ReadContextF
 
 		response, res, err := apiClient.Client.PrometheusRulesAPI.PrometheusrulesList(ctx).Id(id).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		if len(response.Data) != 1 {
-			if withRetries {
-				d.SetId(utils.I32toa(id))
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-			}
-			return nil
+			return utils.ReadNotFound(d, utils.I32toa(id), withRetries)
 		}
 
 		rawBillingRule := response.Data[0]
@@ -196,7 +192,7 @@ func resourceTaikunBillingRuleUpdate(ctx context.Context, d *schema.ResourceData
 
 	res, err := apiClient.Client.PrometheusRulesAPI.PrometheusrulesUpdate(ctx, id).RuleForUpdateDto(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	return utils.ReadAfterUpdateWithRetries(generateResourceTaikunBillingRuleReadWithRetries(), ctx, d, meta)
@@ -211,7 +207,7 @@ func resourceTaikunBillingRuleDelete(ctx context.Context, d *schema.ResourceData
 
 	res, err := apiClient.Client.PrometheusRulesAPI.PrometheusrulesDelete(ctx, id).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	d.SetId("")
@@ -0,0 +1,28 @@
+//go:build sweep
+
+package billing
+
+import (
+	"context"
+
+	tk "github.com/itera-io/taikungoclient"
+	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+)
+
+// Delete the billing credentials left behind by the acceptance tests, once the projects using them are deleted
+func AddTestSweepers(client utils.SweeperClientFunc) {
+	utils.AddListTestSweeper("taikun_billing_credential", client, []string{"taikun_project"},
+		func(ctx context.Context, apiClient *tk.Client, offset int32) ([]tkcore.OperationCredentialsListDto, int, error) {
+			response, res, err := apiClient.Client.OperationCredentialsAPI.OpscredentialsList(ctx).Offset(offset).Execute()
+			if err != nil {
+				return nil, 0, utils.NewApiError(res, err)
+			}
+			return response.GetData(), int(response.GetTotalCount()), nil
+		},
+		(*tkcore.OperationCredentialsListDto).GetName,
+		func(ctx context.Context, apiClient *tk.Client, billingCredential *tkcore.OperationCredentialsListDto) error {
+			return utils.SweepLockableResource(ctx, ResourceTaikunBillingCredential(), billingCredential.GetId(), billingCredential.GetIsLocked(), resourceTaikunBillingCredentialLock, apiClient)
+		},
+	)
+}
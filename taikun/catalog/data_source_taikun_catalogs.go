@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	tk "github.com/itera-io/taikungoclient"
 	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
 )
 
 func DataSourceTaikunCatalogs() *schema.Resource {
@@ -36,7 +37,7 @@ func dataSourceTaikunCatalogsRead(ctx context.Context, d *schema.ResourceData, m
 	for {
 		response, res, err := params.Offset(offset).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		catalogsList = append(catalogsList, response.Data...)
 		if len(catalogsList) == int(response.GetTotalCount()) {
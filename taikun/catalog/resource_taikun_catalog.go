@@ -12,6 +12,7 @@ import (
 	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
 	"log"
 	"regexp"
+	"strings"
 	"time"
 )
 
@@ -46,6 +47,13 @@ func taikunApplicationSchema() map[string]*schema.Schema {
 				),
 			),
 		},
+		"version": {
+			Description:  "The version of the chart. Changing it moves the application to this version in place. Defaults to the latest version in the repository.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringIsNotWhiteSpace,
+		},
 	}
 }
 
@@ -85,6 +93,12 @@ func resourceTaikunCatalogSchema() map[string]*schema.Schema {
 			Optional:    true,
 			Default:     false,
 		},
+		"external_applications": {
+			Description: "Indicates whether the applications of the catalog are published with `taikun_catalog_application`. The catalog then leaves its applications alone, `application` cannot be set.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
 		"organization_id": {
 			Description:      "The ID of the organization which owns the catalog.",
 			Type:             schema.TypeString,
@@ -94,9 +108,16 @@ func resourceTaikunCatalogSchema() map[string]*schema.Schema {
 			ValidateDiagFunc: utils.StringIsInt,
 		},
 		"application": {
-			Description: "Bound Applications.",
-			Type:        schema.TypeSet,
-			Optional:    true,
+			Description:   "Bound Applications.",
+			Type:          schema.TypeSet,
+			Optional:      true,
+			ConflictsWith: []string{"external_applications"},
+			DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+				// The applications are published with taikun_catalog_application
+				return d.Get("external_applications").(bool)
+			},
+			// The version is not part of the hash, changing it updates the application instead of replacing it
+			Set: utils.HashAttributes("name", "repository"),
 			Elem: &schema.Resource{
 				Schema: taikunApplicationSchema(),
 			},
@@ -131,9 +152,54 @@ func ResourceTaikunCatalog() *schema.Resource {
 		UpdateContext: resourceTaikunCatalogUpdate,
 		DeleteContext: resourceTaikunCatalogDelete,
 		Schema:        resourceTaikunCatalogSchema(),
+		CustomizeDiff: utils.SetDefaultOrganizationID,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTaikunCatalogImport,
+		},
 	}
 }
 
+// Catalogs are imported with their ID or with the ID organization_id/name
+func resourceTaikunCatalogImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	apiClient := meta.(*tk.Client)
+
+	if !strings.Contains(d.Id(), "/") {
+		catalogId, err := utils.Atoi32(d.Id())
+		if err != nil {
+			return nil, fmt.Errorf("unexpected format of ID (%q), expected id or organization_id/name", d.Id())
+		}
+		data, response, err := apiClient.Client.CatalogAPI.CatalogList(ctx).Id(catalogId).Execute()
+		if err != nil {
+			return nil, utils.NewApiError(response, err)
+		}
+		if len(data.GetData()) != 1 {
+			return nil, fmt.Errorf("could not find the catalog with ID %d", catalogId)
+		}
+		if err := d.Set("name", data.GetData()[0].GetName()); err != nil {
+			return nil, err
+		}
+		if err := d.Set("organization_id", utils.I32toa(data.GetData()[0].GetOrganizationId())); err != nil {
+			return nil, err
+		}
+		return []*schema.ResourceData{d}, nil
+	}
+
+	parts, err := utils.ParseImportID(d.Id(), "organization_id", "name")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := utils.Atoi32(parts[0]); err != nil {
+		return nil, fmt.Errorf("organization_id isn't valid: %s", parts[0])
+	}
+	if err := d.Set("organization_id", parts[0]); err != nil {
+		return nil, err
+	}
+	if err := d.Set("name", parts[1]); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceTaikunCatalogCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(*tk.Client)
 	applicationsWhichShouldBeBound := d.Get("application").(*schema.Set)
@@ -151,14 +217,14 @@ func resourceTaikunCatalogCreate(ctx context.Context, d *schema.ResourceData, me
 	if organizationIDData, organizationIDIsSet := d.GetOk("organization_id"); organizationIDIsSet {
 		orgId, err := utils.Atoi32(organizationIDData.(string))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 		body.SetOrganizationId(orgId)
 	}
 
 	response, err := apiClient.Client.CatalogAPI.CatalogCreate(ctx).CreateCatalogCommand(*body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(response, err))
+		return utils.DiagnosticsFromApiError(response, err)
 	}
 
 	// Get catalogId
@@ -168,7 +234,7 @@ func resourceTaikunCatalogCreate(ctx context.Context, d *schema.ResourceData, me
 	}
 	catalogId, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	// Bind Legacy projects
@@ -191,7 +257,7 @@ func resourceTaikunCatalogCreate(ctx context.Context, d *schema.ResourceData, me
 func resourceTaikunCatalogDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	catalogId, err := utils.Atoi32(d.Get("id").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	// Unbind all projects left in this
@@ -216,7 +282,7 @@ func resourceTaikunCatalogDelete(ctx context.Context, d *schema.ResourceData, me
 	// Delete catalog
 	err = resourceTaikunCatalogWaitForDeletionReady(catalogId, ctx, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	d.SetId("")
@@ -239,14 +305,14 @@ func generateResourceTaikunCatalogRead(withRetries bool) schema.ReadContextFunc
 		if organizationIDData, organizationIDIsSet := d.GetOk("organization_id"); organizationIDIsSet {
 			orgId, err := utils.Atoi32(organizationIDData.(string))
 			if err != nil {
-				return diag.FromErr(err)
+				return utils.DiagnosticsFromError(err)
 			}
 			listQuery = listQuery.OrganizationId(orgId)
 		}
 
 		data, response, err := listQuery.Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(response, err))
+			return utils.DiagnosticsFromApiError(response, err)
 		}
 
 		// Iterate through data to find the correct Catalog
@@ -261,9 +327,7 @@ func generateResourceTaikunCatalogRead(withRetries bool) schema.ReadContextFunc
 		}
 
 		if !foundMatch {
-			//log.Printf("Did not find catalog")
-			d.SetId("")
-			return nil
+			return utils.ReadNotFound(d, d.Id(), withRetries)
 		}
 		//log.Printf("Found catalog")
 
@@ -273,10 +337,15 @@ func generateResourceTaikunCatalogRead(withRetries bool) schema.ReadContextFunc
 			log.Printf("[DEBUG] Skipping setting 'projects' from API because field was omitted in config")
 		}
 
+		// Applications published with taikun_catalog_application are not part of the catalog
+		if d.Get("external_applications").(bool) {
+			rawCatalog.BoundApplications = nil
+		}
+
 		// Load all the found data to the local object
 		err = utils.SetResourceDataFromMap(d, flattenTaikunCatalog(&rawCatalog))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		d.SetId(d.Get("id").(string)) // We need to tell provider that object was created
@@ -295,6 +364,7 @@ func flattenTaikunCatalog(rawCatalog *tkcore.CatalogListDto) map[string]interfac
 			"id":         utils.I32toa(app.GetCatalogAppId()),
 			"name":       app.GetName(),
 			"repository": app.Repository.GetName(),
+			"version":    app.GetVersion(),
 		}
 		applications = append(applications, appMap)
 	}
@@ -324,7 +394,7 @@ func resourceTaikunCatalogUpdate(ctx context.Context, d *schema.ResourceData, me
 	// Name, description
 	catalogId, err := utils.Atoi32(d.Get("id").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 	_, newName := d.GetChange("name")
 	_, newDescription := d.GetChange("description")
@@ -334,7 +404,7 @@ func resourceTaikunCatalogUpdate(ctx context.Context, d *schema.ResourceData, me
 	updatedCatalog.SetDescription(newDescription.(string))
 	response, err := apiClient.Client.CatalogAPI.CatalogEdit(ctx).EditCatalogCommand(updatedCatalog).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(response, err))
+		return utils.DiagnosticsFromApiError(response, err)
 	}
 
 	// lock
@@ -345,7 +415,7 @@ func resourceTaikunCatalogUpdate(ctx context.Context, d *schema.ResourceData, me
 		updateLock.SetMode(utils.GetLockMode(newCatalogLocked.(bool)))
 		response, err = apiClient.Client.CatalogAPI.CatalogLock(ctx).CatalogLockManagementCommand(updateLock).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(response, err))
+			return utils.DiagnosticsFromApiError(response, err)
 		}
 	}
 
@@ -357,7 +427,7 @@ func resourceTaikunCatalogUpdate(ctx context.Context, d *schema.ResourceData, me
 			updateDefault.SetId(catalogId)
 			response, err = apiClient.Client.CatalogAPI.CatalogMakeDefault(ctx).CatalogMakeDefaultCommand(updateDefault).Execute()
 			if err != nil {
-				return diag.FromErr(tk.CreateError(response, err))
+				return utils.DiagnosticsFromApiError(response, err)
 			}
 		} else {
 			// This will get changed by other catalog taking the default status
@@ -386,11 +456,11 @@ func reconcileApplicationsBound(ctx context.Context, oldCatalogApplicationsBound
 	for _, app := range toRemove.List() {
 		catalogAppId, err := utils.Atoi32(app.(map[string]interface{})["id"].(string))
 		if err != nil {
-			diag.FromErr(err)
+			utils.DiagnosticsFromError(err)
 		}
 		response, err := apiClient.Client.CatalogAppAPI.CatalogAppDelete(ctx, catalogAppId).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(response, err))
+			return utils.DiagnosticsFromApiError(response, err)
 		}
 	}
 
@@ -402,9 +472,38 @@ func reconcileApplicationsBound(ctx context.Context, oldCatalogApplicationsBound
 		catalogAppToCreate.SetRepoName(app.(map[string]interface{})["repository"].(string))
 		catalogAppToCreate.SetPackageName(app.(map[string]interface{})["name"].(string))
 		catalogAppToCreate.SetParameters([]tkcore.CatalogAppParamsDto{})
+		if version := app.(map[string]interface{})["version"].(string); version != "" {
+			catalogAppToCreate.SetVersion(version)
+		}
 		_, response, err := apiClient.Client.CatalogAppAPI.CatalogAppCreate(ctx).CreateCatalogAppCommand(catalogAppToCreate).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(response, err))
+			return utils.DiagnosticsFromApiError(response, err)
+		}
+	}
+
+	// Applications which stay bound, with a new version
+	for _, app := range newApplications.Intersection(oldApplications).List() {
+		newVersion := app.(map[string]interface{})["version"].(string)
+		for _, oldApp := range oldApplications.List() {
+			if oldApplications.F(oldApp) != newApplications.F(app) {
+				continue
+			}
+			oldVersion := oldApp.(map[string]interface{})["version"].(string)
+			if newVersion == "" || newVersion == oldVersion {
+				break
+			}
+			catalogAppId, err := utils.Atoi32(oldApp.(map[string]interface{})["id"].(string))
+			if err != nil {
+				return utils.DiagnosticsFromError(err)
+			}
+			catalogAppToEdit := tkcore.EditCatalogAppVersionCommand{}
+			catalogAppToEdit.SetId(catalogAppId)
+			catalogAppToEdit.SetVersion(newVersion)
+			response, err := apiClient.Client.CatalogAppAPI.CatalogAppEditVersion(ctx).EditCatalogAppVersionCommand(catalogAppToEdit).Execute()
+			if err != nil {
+				return utils.DiagnosticsFromApiError(response, err)
+			}
+			break
 		}
 	}
 
@@ -422,7 +521,7 @@ func reconcileProjectsBound(ctx context.Context, oldCatalogProjectsBound interfa
 	if len(toRemove) > 0 {
 		body, err := utils.SliceOfSTringsToSliceOfInt32(toRemove)
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 		_, _ = apiClient.Client.CatalogAPI.CatalogDeleteProject(ctx, catalogId).RequestBody(body).Execute()
 	}
@@ -432,7 +531,7 @@ func reconcileProjectsBound(ctx context.Context, oldCatalogProjectsBound interfa
 	if len(toAdd) > 0 {
 		body, err := utils.SliceOfSTringsToSliceOfInt32(toAdd)
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 		_, _ = apiClient.Client.CatalogAPI.CatalogAddProject(ctx, catalogId).RequestBody(body).Execute()
 	}
@@ -453,7 +552,7 @@ func resourceTaikunCatalogWaitForDeletionReady(catalogId int32, ctx context.Cont
 		Refresh: func() (interface{}, string, error) {
 			data, response, err := apiClient.Client.CatalogAPI.CatalogList(ctx).Id(catalogId).Execute()
 			if err != nil {
-				lastErr = tk.CreateError(response, err)
+				lastErr = utils.NewApiError(response, err)
 				return dummyResult, "", lastErr
 			}
 
@@ -463,7 +562,7 @@ func resourceTaikunCatalogWaitForDeletionReady(catalogId int32, ctx context.Cont
 
 			response, err = apiClient.Client.CatalogAPI.CatalogDelete(ctx, catalogId).Execute()
 			if err != nil {
-				lastErr = tk.CreateError(response, err)
+				lastErr = utils.NewApiError(response, err)
 
 				// Retry as long as we can; return "retry" state
 				return dummyResult, "retry", nil
@@ -477,7 +576,7 @@ func resourceTaikunCatalogWaitForDeletionReady(catalogId int32, ctx context.Cont
 		MinTimeout: 5 * time.Second,
 	}
 
-	_, err := deleteStateConf.WaitForStateContext(ctx)
+	_, err := utils.WaitForState(ctx, deleteStateConf)
 	if err != nil {
 		// Return the last encountered error if available
 		if lastErr != nil {
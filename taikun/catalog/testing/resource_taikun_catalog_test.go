@@ -75,10 +75,138 @@ func TestAccResourceTaikunCatalogAppBinding(t *testing.T) {
 					resource.TestCheckResourceAttr("taikun_catalog.foo", "application.0.name", "nginx"),
 				),
 			},
+			{
+				// Removing the last application unbinds it
+				Config: fmt.Sprintf(testAccResourceTaikunCatalogConfig,
+					catalogName,
+					catalogDescriptionChanged,
+					"",
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunCatalogExists,
+					resource.TestCheckResourceAttr("taikun_catalog.foo", "application.#", "0"),
+					testAccCheckTaikunCatalogApplicationCount(0),
+				),
+			},
+			{
+				ResourceName:      "taikun_catalog.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"projects",
+				},
+			},
+			{
+				ResourceName:      "taikun_catalog.foo",
+				ImportState:       true,
+				ImportStateIdFunc: testAccResourceTaikunCatalogImportStateId,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"projects",
+				},
+			},
+		},
+	})
+}
+
+const testAccResourceTaikunCatalogVersionConfig = `
+resource "taikun_catalog" "foo" {
+  name="%s"
+  description="%s"
+  projects=[]
+
+  application {
+    name="ingress-nginx"
+    repository="taikun-managed-apps"
+    version="%s"
+  }
+}
+`
+
+// Versions of the ingress-nginx chart published in taikun-managed-apps
+const (
+	testAccIngressNginxVersion      = "4.11.2"
+	testAccIngressNginxVersionNewer = "4.11.3"
+)
+
+// TestAccResourceTaikunCatalogAppVersion verifies that changing the version of an application moves it to the version in place
+func TestAccResourceTaikunCatalogAppVersion(t *testing.T) {
+	catalogName := utils.RandomTestName()
+	catalogDescription := utils.RandomTestName()
+	var catalogAppId string
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { utils_testing.TestAccPreCheck(t) },
+		ProviderFactories: utils_testing.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckTaikunCatalogDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccResourceTaikunCatalogVersionConfig,
+					catalogName,
+					catalogDescription,
+					testAccIngressNginxVersion,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunCatalogExists,
+					resource.TestCheckResourceAttr("taikun_catalog.foo", "application.#", "1"),
+					resource.TestCheckResourceAttr("taikun_catalog.foo", "application.0.version", testAccIngressNginxVersion),
+					func(state *terraform.State) error {
+						catalogAppId = state.RootModule().Resources["taikun_catalog.foo"].Primary.Attributes["application.0.id"]
+						return nil
+					},
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccResourceTaikunCatalogVersionConfig,
+					catalogName,
+					catalogDescription,
+					testAccIngressNginxVersionNewer,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunCatalogExists,
+					resource.TestCheckResourceAttr("taikun_catalog.foo", "application.#", "1"),
+					resource.TestCheckResourceAttr("taikun_catalog.foo", "application.0.version", testAccIngressNginxVersionNewer),
+					func(state *terraform.State) error {
+						if id := state.RootModule().Resources["taikun_catalog.foo"].Primary.Attributes["application.0.id"]; id != catalogAppId {
+							return fmt.Errorf("expected the application %s to be updated in place, got the application %s", catalogAppId, id)
+						}
+						return nil
+					},
+				),
+			},
 		},
 	})
 }
 
+// Check the number of applications bound to the catalog in Taikun
+func testAccCheckTaikunCatalogApplicationCount(expected int) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		client := utils_testing.TestAccProvider.Meta().(*tk.Client)
+
+		rs, found := state.RootModule().Resources["taikun_catalog.foo"]
+		if !found {
+			return errors.New("taikun_catalog.foo not found in state")
+		}
+		id, _ := utils.Atoi32(rs.Primary.ID)
+		response, _, err := client.Client.CatalogAPI.CatalogList(context.TODO()).Id(id).Execute()
+		if err != nil || response.GetTotalCount() != 1 {
+			return fmt.Errorf("catalog doesn't exist (id = %s)", rs.Primary.ID)
+		}
+		if count := len(response.GetData()[0].GetBoundApplications()); count != expected {
+			return fmt.Errorf("expected %d applications bound to the catalog, got %d", expected, count)
+		}
+		return nil
+	}
+}
+
+func testAccResourceTaikunCatalogImportStateId(state *terraform.State) (string, error) {
+	rs, found := state.RootModule().Resources["taikun_catalog.foo"]
+	if !found {
+		return "", errors.New("taikun_catalog.foo not found in state")
+	}
+	return fmt.Sprintf("%s/%s", rs.Primary.Attributes["organization_id"], rs.Primary.Attributes["name"]), nil
+}
+
 func testAccCheckTaikunCatalogExists(state *terraform.State) error {
 	client := utils_testing.TestAccProvider.Meta().(*tk.Client)
 
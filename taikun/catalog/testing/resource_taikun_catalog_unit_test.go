@@ -0,0 +1,96 @@
+package testing
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils_testing"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils_testing/faketaikun"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+const testUnitResourceTaikunCatalogConfig = `
+resource "taikun_catalog" "foo" {
+  name        = "catalog"
+  description = "%s"
+  lock        = %t
+
+  application {
+    name       = "ingress-nginx"
+    repository = "taikun-managed-apps"
+    version    = "%s"
+  }
+}
+`
+
+// TestUnitResourceTaikunCatalog runs against the fake Taikun server.
+// The application is moved to another version in place, then the catalog is locked and unlocked.
+func TestUnitResourceTaikunCatalog(t *testing.T) {
+	server := faketaikun.Start(t)
+	server.AddPackage("taikun-managed-apps", "ingress-nginx", testAccIngressNginxVersionNewer, testAccIngressNginxVersion)
+	var catalogAppId string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: utils_testing.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckTaikunCatalogDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testUnitResourceTaikunCatalogConfig, "apps", false, testAccIngressNginxVersion),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunCatalogExists,
+					testAccCheckTaikunCatalogApplicationCount(1),
+					resource.TestCheckResourceAttr("taikun_catalog.foo", "name", "catalog"),
+					resource.TestCheckResourceAttr("taikun_catalog.foo", "description", "apps"),
+					resource.TestCheckResourceAttr("taikun_catalog.foo", "organization_id", fmt.Sprint(faketaikun.DefaultOrganizationID)),
+					resource.TestCheckResourceAttr("taikun_catalog.foo", "lock", "false"),
+					resource.TestCheckResourceAttr("taikun_catalog.foo", "application.#", "1"),
+					resource.TestCheckResourceAttr("taikun_catalog.foo", "application.0.version", testAccIngressNginxVersion),
+					func(state *terraform.State) error {
+						catalogAppId = state.RootModule().Resources["taikun_catalog.foo"].Primary.Attributes["application.0.id"]
+						return nil
+					},
+				),
+			},
+			{
+				Config: fmt.Sprintf(testUnitResourceTaikunCatalogConfig, "apps", false, testAccIngressNginxVersionNewer),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunCatalogApplicationCount(1),
+					resource.TestCheckResourceAttr("taikun_catalog.foo", "application.0.version", testAccIngressNginxVersionNewer),
+					func(state *terraform.State) error {
+						if id := state.RootModule().Resources["taikun_catalog.foo"].Primary.Attributes["application.0.id"]; id != catalogAppId {
+							return fmt.Errorf("expected the application %s to be updated in place, got the application %s", catalogAppId, id)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: fmt.Sprintf(testUnitResourceTaikunCatalogConfig, "locked apps", true, testAccIngressNginxVersionNewer),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunCatalogExists,
+					resource.TestCheckResourceAttr("taikun_catalog.foo", "description", "locked apps"),
+					resource.TestCheckResourceAttr("taikun_catalog.foo", "lock", "true"),
+				),
+			},
+			{
+				ResourceName:      "taikun_catalog.foo",
+				ImportState:       true,
+				ImportStateIdFunc: testAccResourceTaikunCatalogImportStateId,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"projects",
+				},
+			},
+			{
+				// The catalog is unlocked to be deleted
+				Config: fmt.Sprintf(testUnitResourceTaikunCatalogConfig, "apps", false, testAccIngressNginxVersionNewer),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunCatalogExists,
+					resource.TestCheckResourceAttr("taikun_catalog.foo", "lock", "false"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,337 @@
+package catalog_application
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	tk "github.com/itera-io/taikungoclient"
+	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+)
+
+func resourceTaikunCatalogApplicationSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Description: "The ID of the catalog application.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"catalog_id": {
+			Description:      "The ID of the catalog in which the application is published.",
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: utils.StringIsInt,
+		},
+		"name": {
+			Description: "The name of the application, the name of its package in the repository.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			ValidateFunc: validation.All(
+				validation.StringLenBetween(3, 30),
+				validation.StringMatch(
+					regexp.MustCompile("^[a-z0-9-]+$"),
+					"Application name must contain only lowercase alpha numeric characters or non alpha numeric (-)",
+				),
+			),
+		},
+		"repository": {
+			Description: "The name of the repository.",
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			ValidateFunc: validation.All(
+				validation.StringLenBetween(3, 30),
+				validation.StringMatch(
+					regexp.MustCompile("^[a-z0-9-]+$"),
+					"Repository name must contain only lowercase alpha numeric characters or non alpha numeric (-)",
+				),
+			),
+		},
+		"version": {
+			Description:  "The version of the chart. Changing it moves the application to this version in place. Defaults to the latest version in the repository.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringIsNotWhiteSpace,
+		},
+		"available_versions": {
+			Description: "The versions of the chart available in the repository.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"parameter": {
+			Description: "Default parameters of the application, used by the application instances which do not set them.",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Set:         utils.HashAttributes("key", "value"),
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"key": {
+						Description:  "The key of the parameter, in the dotted notation of Helm, for example `controller.replicaCount`.",
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringIsNotWhiteSpace,
+					},
+					"value": {
+						Description: "The value of the parameter.",
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+				},
+			},
+		},
+		"lock": {
+			Description: "Indicates whether to lock the application.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+	}
+}
+
+func ResourceTaikunCatalogApplication() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Application published in a Taikun catalog.",
+		CreateContext: resourceTaikunCatalogApplicationCreate,
+		ReadContext:   generateResourceTaikunCatalogApplicationReadWithoutRetries(),
+		UpdateContext: resourceTaikunCatalogApplicationUpdate,
+		DeleteContext: resourceTaikunCatalogApplicationDelete,
+		Schema:        resourceTaikunCatalogApplicationSchema(),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTaikunCatalogApplicationImport,
+		},
+	}
+}
+
+// Catalog applications are imported with their ID or with the ID catalog_id/repository/name
+func resourceTaikunCatalogApplicationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	apiClient := meta.(*tk.Client)
+
+	if !strings.Contains(d.Id(), "/") {
+		if _, err := utils.Atoi32(d.Id()); err != nil {
+			return nil, fmt.Errorf("unexpected format of ID (%q), expected id or catalog_id/repository/name", d.Id())
+		}
+		return []*schema.ResourceData{d}, nil
+	}
+
+	parts, err := utils.ParseImportID(d.Id(), "catalog_id", "repository", "name")
+	if err != nil {
+		return nil, err
+	}
+	catalogId, err := utils.Atoi32(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("catalog_id isn't valid: %s", parts[0])
+	}
+
+	data, response, err := apiClient.Client.CatalogAPI.CatalogList(ctx).Id(catalogId).Execute()
+	if err != nil {
+		return nil, utils.NewApiError(response, err)
+	}
+	if len(data.GetData()) != 1 {
+		return nil, fmt.Errorf("could not find the catalog with ID %d", catalogId)
+	}
+	for _, app := range data.GetData()[0].GetBoundApplications() {
+		if app.Repository.GetName() == parts[1] && app.GetName() == parts[2] {
+			d.SetId(utils.I32toa(app.GetCatalogAppId()))
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("could not find the application %s of the repository %s in the catalog with ID %d", parts[2], parts[1], catalogId)
+}
+
+func resourceTaikunCatalogApplicationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*tk.Client)
+
+	catalogId, err := utils.Atoi32(d.Get("catalog_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	body := tkcore.CreateCatalogAppCommand{}
+	body.SetCatalogId(catalogId)
+	body.SetRepoName(d.Get("repository").(string))
+	body.SetPackageName(d.Get("name").(string))
+	body.SetParameters(expandCatalogApplicationParameters(d.Get("parameter").(*schema.Set)))
+	if version, versionIsSet := d.GetOk("version"); versionIsSet {
+		body.SetVersion(version.(string))
+	}
+	data, response, err := apiClient.Client.CatalogAppAPI.CatalogAppCreate(ctx).CreateCatalogAppCommand(body).Execute()
+	if err != nil {
+		return utils.DiagnosticsFromApiError(response, err)
+	}
+	d.SetId(data.GetId())
+
+	if d.Get("lock").(bool) {
+		if err := resourceTaikunCatalogApplicationLock(ctx, d, true, meta); err != nil {
+			return utils.DiagnosticsFromError(err)
+		}
+	}
+
+	return utils.ReadAfterCreateWithRetries(generateResourceTaikunCatalogApplicationReadWithRetries(), ctx, d, meta)
+}
+
+func generateResourceTaikunCatalogApplicationReadWithRetries() schema.ReadContextFunc {
+	return generateResourceTaikunCatalogApplicationRead(true)
+}
+func generateResourceTaikunCatalogApplicationReadWithoutRetries() schema.ReadContextFunc {
+	return generateResourceTaikunCatalogApplicationRead(false)
+}
+
+func generateResourceTaikunCatalogApplicationRead(withRetries bool) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		apiClient := meta.(*tk.Client)
+		catalogAppId, err := utils.Atoi32(d.Id())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		data, response, err := apiClient.Client.CatalogAppAPI.CatalogAppDetails(ctx, catalogAppId).Execute()
+		if err != nil {
+			return utils.ReadApiError(d, d.Id(), withRetries, response, err)
+		}
+
+		err = utils.SetResourceDataFromMap(d, flattenTaikunCatalogApplication(data))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		// The versions are informative, the resource is read even if the repository cannot be listed
+		availableVersions, err := utils.GetPackageVersions(ctx, data.Repository.GetName(), data.GetPackageName(), apiClient)
+		if err != nil {
+			log.Printf("[WARN] Unable to list the versions of the package %s: %v", data.GetPackageName(), err)
+		} else if err = d.Set("available_versions", availableVersions); err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.SetId(d.Get("id").(string)) // We need to tell provider that object was created
+		return nil
+	}
+}
+
+func flattenTaikunCatalogApplication(rawCatalogApp *tkcore.CatalogAppDetailsDto) map[string]interface{} {
+	parameters := make([]map[string]interface{}, 0, len(rawCatalogApp.GetParameters()))
+	for _, parameter := range rawCatalogApp.GetParameters() {
+		parameters = append(parameters, map[string]interface{}{
+			"key":   parameter.GetKey(),
+			"value": parameter.GetValue(),
+		})
+	}
+
+	return map[string]interface{}{
+		"id":         utils.I32toa(rawCatalogApp.GetId()),
+		"catalog_id": utils.I32toa(rawCatalogApp.GetCatalogId()),
+		"name":       rawCatalogApp.GetPackageName(),
+		"repository": rawCatalogApp.Repository.GetName(),
+		"version":    rawCatalogApp.GetVersion(),
+		"parameter":  parameters,
+		"lock":       rawCatalogApp.GetIsLocked(),
+	}
+}
+
+func expandCatalogApplicationParameters(parameterSet *schema.Set) []tkcore.CatalogAppParamsDto {
+	parameters := make([]tkcore.CatalogAppParamsDto, 0, parameterSet.Len())
+	for _, element := range parameterSet.List() {
+		parameterMap := element.(map[string]interface{})
+		parameter := tkcore.CatalogAppParamsDto{}
+		parameter.SetKey(parameterMap["key"].(string))
+		parameter.SetValue(parameterMap["value"].(string))
+		parameters = append(parameters, parameter)
+	}
+	return parameters
+}
+
+func resourceTaikunCatalogApplicationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*tk.Client)
+	catalogAppId, err := utils.Atoi32(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// A locked application cannot be edited, unlock it first and lock it again once edited
+	oldLock, newLock := d.GetChange("lock")
+	edited := d.HasChanges("version", "parameter")
+	if oldLock.(bool) && (edited || !newLock.(bool)) {
+		if err := resourceTaikunCatalogApplicationLock(ctx, d, false, meta); err != nil {
+			return utils.DiagnosticsFromError(err)
+		}
+	}
+
+	if d.HasChange("version") {
+		body := tkcore.EditCatalogAppVersionCommand{}
+		body.SetId(catalogAppId)
+		body.SetVersion(d.Get("version").(string))
+		response, err := apiClient.Client.CatalogAppAPI.CatalogAppEditVersion(ctx).EditCatalogAppVersionCommand(body).Execute()
+		if err != nil {
+			return utils.DiagnosticsFromApiError(response, err)
+		}
+	}
+
+	if d.HasChange("parameter") {
+		body := tkcore.EditCatalogAppParamCommand{}
+		body.SetCatalogAppId(catalogAppId)
+		body.SetParameters(expandCatalogApplicationParameters(d.Get("parameter").(*schema.Set)))
+		response, err := apiClient.Client.CatalogAppAPI.CatalogAppEditParams(ctx).EditCatalogAppParamCommand(body).Execute()
+		if err != nil {
+			return utils.DiagnosticsFromApiError(response, err)
+		}
+	}
+
+	if newLock.(bool) && (edited || !oldLock.(bool)) {
+		if err := resourceTaikunCatalogApplicationLock(ctx, d, true, meta); err != nil {
+			return utils.DiagnosticsFromError(err)
+		}
+	}
+
+	return utils.ReadAfterUpdateWithRetries(generateResourceTaikunCatalogApplicationReadWithRetries(), ctx, d, meta)
+}
+
+// Lock or unlock the application
+func resourceTaikunCatalogApplicationLock(ctx context.Context, d *schema.ResourceData, lock bool, meta interface{}) error {
+	apiClient := meta.(*tk.Client)
+	catalogAppId, err := utils.Atoi32(d.Id())
+	if err != nil {
+		return err
+	}
+
+	body := tkcore.CatalogAppLockManagementCommand{}
+	body.SetId(catalogAppId)
+	body.SetMode(utils.GetLockMode(lock))
+	response, err := apiClient.Client.CatalogAppAPI.CatalogAppLockManager(ctx).CatalogAppLockManagementCommand(body).Execute()
+	if err != nil {
+		return utils.NewApiError(response, err)
+	}
+	return nil
+}
+
+func resourceTaikunCatalogApplicationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*tk.Client)
+	catalogAppId, err := utils.Atoi32(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	response, err := apiClient.Client.CatalogAppAPI.CatalogAppDelete(ctx, catalogAppId).Execute()
+	if err != nil {
+		// Deleted together with its catalog
+		if utils.IsNotFound(response) {
+			d.SetId("")
+			return nil
+		}
+		return utils.DiagnosticsFromApiError(response, err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,164 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	tk "github.com/itera-io/taikungoclient"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils_testing"
+)
+
+const testAccResourceTaikunCatalogApplicationConfig = `
+resource "taikun_catalog" "foo" {
+  name                  = "%s"
+  description           = "%s"
+  external_applications = true
+}
+
+resource "taikun_catalog_application" "foo" {
+  catalog_id = taikun_catalog.foo.id
+  name       = "wordpress"
+  repository = "taikun-managed-apps"
+  lock       = %t
+
+  parameter {
+    key   = "service.type"
+    value = "%s"
+  }
+}
+`
+
+func TestAccResourceTaikunCatalogApplication(t *testing.T) {
+	catalogName := utils.RandomTestName()
+	catalogDescription := utils.RandomTestName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { utils_testing.TestAccPreCheck(t) },
+		ProviderFactories: utils_testing.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckTaikunCatalogApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccResourceTaikunCatalogApplicationConfig,
+					catalogName,
+					catalogDescription,
+					false,
+					"ClusterIP",
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunCatalogApplicationExists,
+					resource.TestCheckResourceAttrPair("taikun_catalog_application.foo", "catalog_id", "taikun_catalog.foo", "id"),
+					resource.TestCheckResourceAttr("taikun_catalog_application.foo", "name", "wordpress"),
+					resource.TestCheckResourceAttr("taikun_catalog_application.foo", "repository", "taikun-managed-apps"),
+					resource.TestCheckResourceAttr("taikun_catalog_application.foo", "lock", "false"),
+					resource.TestCheckResourceAttr("taikun_catalog_application.foo", "parameter.#", "1"),
+					resource.TestCheckResourceAttr("taikun_catalog_application.foo", "parameter.0.value", "ClusterIP"),
+					resource.TestCheckResourceAttrSet("taikun_catalog_application.foo", "version"),
+					resource.TestCheckResourceAttrSet("taikun_catalog_application.foo", "available_versions.#"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccResourceTaikunCatalogApplicationConfig,
+					catalogName,
+					catalogDescription,
+					true,
+					"NodePort",
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunCatalogApplicationExists,
+					resource.TestCheckResourceAttr("taikun_catalog_application.foo", "lock", "true"),
+					resource.TestCheckResourceAttr("taikun_catalog_application.foo", "parameter.#", "1"),
+					resource.TestCheckResourceAttr("taikun_catalog_application.foo", "parameter.0.value", "NodePort"),
+				),
+			},
+			{
+				// The application stays locked, it is unlocked to be edited and locked again
+				Config: fmt.Sprintf(testAccResourceTaikunCatalogApplicationConfig,
+					catalogName,
+					catalogDescription,
+					true,
+					"LoadBalancer",
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunCatalogApplicationExists,
+					resource.TestCheckResourceAttr("taikun_catalog_application.foo", "lock", "true"),
+					resource.TestCheckResourceAttr("taikun_catalog_application.foo", "parameter.0.value", "LoadBalancer"),
+				),
+			},
+			{
+				ResourceName:      "taikun_catalog_application.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "taikun_catalog_application.foo",
+				ImportState:       true,
+				ImportStateIdFunc: testAccResourceTaikunCatalogApplicationImportStateId,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceTaikunCatalogApplicationImportStateId(state *terraform.State) (string, error) {
+	rs, found := state.RootModule().Resources["taikun_catalog_application.foo"]
+	if !found {
+		return "", errors.New("taikun_catalog_application.foo not found in state")
+	}
+	return fmt.Sprintf("%s/%s/%s", rs.Primary.Attributes["catalog_id"], rs.Primary.Attributes["repository"], rs.Primary.Attributes["name"]), nil
+}
+
+func testAccCheckTaikunCatalogApplicationExists(state *terraform.State) error {
+	client := utils_testing.TestAccProvider.Meta().(*tk.Client)
+
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "taikun_catalog_application" {
+			continue
+		}
+
+		id, _ := utils.Atoi32(rs.Primary.ID)
+
+		_, _, err := client.Client.CatalogAppAPI.CatalogAppDetails(context.TODO(), id).Execute()
+		if err != nil {
+			return fmt.Errorf("catalog application doesn't exist (id = %s)", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckTaikunCatalogApplicationDestroy(state *terraform.State) error {
+	client := utils_testing.TestAccProvider.Meta().(*tk.Client)
+
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "taikun_catalog_application" {
+			continue
+		}
+
+		retryErr := retry.RetryContext(context.Background(), utils.GetReadAfterOpTimeout(false), func() *retry.RetryError {
+			id, _ := utils.Atoi32(rs.Primary.ID)
+
+			_, response, err := client.Client.CatalogAppAPI.CatalogAppDetails(context.TODO(), id).Execute()
+			if utils.IsNotFound(response) {
+				return nil
+			}
+			if err != nil {
+				return retry.NonRetryableError(err)
+			}
+			return retry.RetryableError(errors.New("catalog application still exists"))
+		})
+		if utils.TimedOut(retryErr) {
+			return errors.New("catalog application still exists (timed out)")
+		}
+		if retryErr != nil {
+			return retryErr
+		}
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package catalog_project_binding
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -38,6 +39,7 @@ func resourceTaikunCatalogProjectBindingSchema() map[string]*schema.Schema {
 			Description: "The ID of the organization which owns both the catalog and the project.",
 			Type:        schema.TypeString,
 			Optional:    true,
+			Computed:    true,
 			//Required:         true,
 			ForceNew:         true,
 			ValidateDiagFunc: utils.StringIsInt,
@@ -53,7 +55,49 @@ func ResourceTaikunCatalogProjectBinding() *schema.Resource {
 		UpdateContext: resourceTaikunCatalogProjectBindingUpdate,
 		DeleteContext: resourceTaikunCatalogProjectBindingDelete,
 		Schema:        resourceTaikunCatalogProjectBindingSchema(),
+		CustomizeDiff: utils.SetDefaultOrganizationID,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTaikunCatalogProjectBindingImport,
+		},
+	}
+}
+
+// Bindings are imported with the ID catalog_id/project_id, the catalog is then looked up to find its name and organization
+func resourceTaikunCatalogProjectBindingImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	apiClient := meta.(*tk.Client)
+
+	parts, err := utils.ParseImportID(d.Id(), "catalog_id", "project_id")
+	if err != nil {
+		return nil, err
+	}
+	catalogId, err := utils.Atoi32(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("catalog_id isn't valid: %s", parts[0])
+	}
+	if _, err := utils.Atoi32(parts[1]); err != nil {
+		return nil, fmt.Errorf("project_id isn't valid: %s", parts[1])
+	}
+
+	data, response, err := apiClient.Client.CatalogAPI.CatalogList(ctx).Id(catalogId).Execute()
+	if err != nil {
+		return nil, utils.NewApiError(response, err)
+	}
+	if len(data.GetData()) != 1 {
+		return nil, fmt.Errorf("could not find the catalog with ID %d", catalogId)
 	}
+	rawCatalog := data.GetData()[0]
+
+	err = utils.SetResourceDataFromMap(d, map[string]interface{}{
+		"catalog_name":    rawCatalog.GetName(),
+		"project_id":      parts[1],
+		"organization_id": utils.I32toa(rawCatalog.GetOrganizationId()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(rawCatalog.GetName() + parts[1])
+
+	return []*schema.ResourceData{d}, nil
 }
 
 func resourceTaikunCatalogProjectBindingCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -63,7 +107,7 @@ func resourceTaikunCatalogProjectBindingCreate(ctx context.Context, d *schema.Re
 	if organizationIDData, organizationIDIsSet := d.GetOk("organization_id"); organizationIDIsSet {
 		organizationIDDataConverted, err := utils.Atoi32(organizationIDData.(string))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 		orgId = organizationIDDataConverted
 	}
@@ -72,13 +116,19 @@ func resourceTaikunCatalogProjectBindingCreate(ctx context.Context, d *schema.Re
 	projectId, err := utils.Atoi32(d.Get("project_id").(string))
 	log.Println("CREATE project_id:", projectId)
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
+	unlock, err := utils.LockProject(ctx, meta, projectId)
+	if err != nil {
+		return utils.DiagnosticsFromError(err)
+	}
+	defer unlock()
+
 	catalogName := d.Get("catalog_name").(string)
 	shouldBeBound := d.Get("is_bound").(bool)
 	err = reconcileBinding(ctx, apiClient, orgId, catalogName, projectId, shouldBeBound)
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	return utils.ReadAfterCreateWithRetries(generateResourceTaikunCatalogProjectBindingReadWithRetries(), ctx, d, meta)
@@ -90,21 +140,27 @@ func resourceTaikunCatalogProjectBindingDelete(ctx context.Context, d *schema.Re
 	if organizationIDData, organizationIDIsSet := d.GetOk("organization_id"); organizationIDIsSet {
 		organizationIDDataConverted, err := utils.Atoi32(organizationIDData.(string))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 		orgId = organizationIDDataConverted
 	}
 
 	projectId, err := utils.Atoi32(d.Get("project_id").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
+	unlock, err := utils.LockProject(ctx, meta, projectId)
+	if err != nil {
+		return utils.DiagnosticsFromError(err)
+	}
+	defer unlock()
+
 	catalogName := d.Get("catalog_name").(string)
 
 	// Does catalog exist and get what projects it has bound?
 	foundCatalog, err := findCatalogByName(ctx, apiClient, orgId, catalogName)
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	catalogHasProjectBound := false
@@ -119,7 +175,7 @@ func resourceTaikunCatalogProjectBindingDelete(ctx context.Context, d *schema.Re
 		body := []int32{projectId}
 		//response, err := apiClient.Client.CatalogAPI.CatalogDeleteProject(ctx, foundCatalog.GetId()).RequestBody(body).Execute()
 		//if err != nil {
-		//	return diag.FromErr(tk.CreateError(response, err))
+		//	return utils.DiagnosticsFromApiError(response, err)
 		//}
 		// If you destroy the binding, Terraform will try to unbind, but ignores if the unbind fails. This is useful in case there are other apps present in the project, but not in terraform state.
 		_, _ = apiClient.Client.CatalogAPI.CatalogDeleteProject(ctx, foundCatalog.GetId()).RequestBody(body).Execute()
@@ -143,21 +199,24 @@ func generateResourceTaikunCatalogProjectBindingRead(withRetries bool) schema.Re
 		if organizationIDData, organizationIDIsSet := d.GetOk("organization_id"); organizationIDIsSet {
 			organizationIDDataConverted, err := utils.Atoi32(organizationIDData.(string))
 			if err != nil {
-				return diag.FromErr(err)
+				return utils.DiagnosticsFromError(err)
 			}
 			orgId = organizationIDDataConverted
 		}
 
 		projectId, err := utils.Atoi32(d.Get("project_id").(string))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 		catalogName := d.Get("catalog_name").(string)
 
 		// Does catalog exist and get what projects it has bound?
 		foundCatalog, err := findCatalogByName(ctx, apiClient, orgId, catalogName)
+		if errors.Is(err, errCatalogNotFound) {
+			return utils.ReadNotFound(d, d.Id(), withRetries)
+		}
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		catalogHasProjectBound := false
@@ -170,7 +229,7 @@ func generateResourceTaikunCatalogProjectBindingRead(withRetries bool) schema.Re
 		// Load all the found data to the local object
 		err = utils.SetResourceDataFromMap(d, flattenTaikunCatalogProjectBinding(catalogHasProjectBound, d.Get("project_id").(string), catalogName))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		d.SetId(d.Get("catalog_name").(string) + d.Get("project_id").(string)) // We need to tell provider that object was created
@@ -195,21 +254,27 @@ func resourceTaikunCatalogProjectBindingUpdate(ctx context.Context, d *schema.Re
 	if organizationIDData, organizationIDIsSet := d.GetOk("organization_id"); organizationIDIsSet {
 		organizationIDDataConverted, err := utils.Atoi32(organizationIDData.(string))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 		orgId = organizationIDDataConverted
 	}
 
 	projectId, err := utils.Atoi32(d.Get("project_id").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
+	unlock, err := utils.LockProject(ctx, meta, projectId)
+	if err != nil {
+		return utils.DiagnosticsFromError(err)
+	}
+	defer unlock()
+
 	catalogName := d.Get("catalog_name").(string)
 	shouldBeBound := d.Get("is_bound").(bool)
 
 	err = reconcileBinding(ctx, apiClient, orgId, catalogName, projectId, shouldBeBound)
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	return utils.ReadAfterUpdateWithRetries(generateResourceTaikunCatalogProjectBindingReadWithRetries(), ctx, d, meta)
@@ -225,7 +290,7 @@ func reconcileBinding(ctx context.Context, apiClient *tk.Client, organizationId
 	// Verify project exists and is ready
 	data, response, err := query.Execute()
 	if err != nil {
-		return tk.CreateError(response, err)
+		return utils.NewApiError(response, err)
 	}
 	if data.GetTotalCount() != 1 {
 		if organizationId != 0 {
@@ -255,7 +320,7 @@ func reconcileBinding(ctx context.Context, apiClient *tk.Client, organizationId
 		body := []int32{projectId}
 		response, err := apiClient.Client.CatalogAPI.CatalogDeleteProject(ctx, foundCatalog.GetId()).RequestBody(body).Execute()
 		if err != nil {
-			return tk.CreateError(response, err)
+			return utils.NewApiError(response, err)
 		}
 	}
 
@@ -264,13 +329,16 @@ func reconcileBinding(ctx context.Context, apiClient *tk.Client, organizationId
 		body := []int32{projectId}
 		response, err := apiClient.Client.CatalogAPI.CatalogAddProject(ctx, foundCatalog.GetId()).RequestBody(body).Execute()
 		if err != nil {
-			return tk.CreateError(response, err)
+			return utils.NewApiError(response, err)
 		}
 	}
 
 	return nil
 }
 
+// Returned by findCatalogByName when the organization has no catalog with this name
+var errCatalogNotFound = errors.New("catalog not found")
+
 func findCatalogByName(ctx context.Context, apiClient *tk.Client, organizationId int32, catalogName string) (rawCatalog tkcore.CatalogListDto, err error) {
 	query := apiClient.Client.CatalogAPI.CatalogList(ctx).Search(catalogName)
 	if organizationId != 0 {
@@ -278,7 +346,7 @@ func findCatalogByName(ctx context.Context, apiClient *tk.Client, organizationId
 	}
 	data, response, err := query.Execute()
 	if err != nil {
-		return rawCatalog, tk.CreateError(response, err)
+		return rawCatalog, utils.NewApiError(response, err)
 	}
 	// Iterate through data to find the correct Catalog
 	foundMatch := false
@@ -292,9 +360,9 @@ func findCatalogByName(ctx context.Context, apiClient *tk.Client, organizationId
 
 	if !foundMatch {
 		if organizationId != 0 {
-			return rawCatalog, fmt.Errorf("catalog '%s' not found in organization %d", catalogName, organizationId)
+			return rawCatalog, fmt.Errorf("%w: '%s' in organization %d", errCatalogNotFound, catalogName, organizationId)
 		}
-		return rawCatalog, fmt.Errorf("catalog '%s' not found in default organization", catalogName)
+		return rawCatalog, fmt.Errorf("%w: '%s' in default organization", errCatalogNotFound, catalogName)
 	}
 
 	return rawCatalog, nil
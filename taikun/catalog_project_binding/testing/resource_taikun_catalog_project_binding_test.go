@@ -10,6 +10,8 @@ import (
 	tk "github.com/itera-io/taikungoclient"
 	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
 	"github.com/itera-io/terraform-provider-taikun/taikun/utils_testing"
+	"os"
+	"regexp"
 	"testing"
 )
 
@@ -79,6 +81,92 @@ func TestAccResourceTaikunCatalogAppBinding(t *testing.T) {
 	})
 }
 
+const testAccResourceTaikunCatalogProjectBindingConfig = `
+resource "taikun_catalog" "foo" {
+  name="%s"
+  description="%s"
+  projects=[]
+}
+
+resource "taikun_catalog_project_binding" "foo" {
+  catalog_name = taikun_catalog.foo.name
+  project_id   = "%s"
+  is_bound     = true
+}
+`
+
+// Binding a catalog requires an existing k8s project (TAIKUN_PROJECT_ID)
+func TestAccResourceTaikunCatalogProjectBinding(t *testing.T) {
+	projectID := os.Getenv("TAIKUN_PROJECT_ID")
+	catalogName := utils.RandomTestName()
+	catalogDescription := utils.RandomTestName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			utils_testing.TestAccPreCheck(t)
+			if projectID == "" {
+				t.Skip("TAIKUN_PROJECT_ID must be set to a running k8s project ID")
+			}
+		},
+		ProviderFactories: utils_testing.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckTaikunCatalogDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccResourceTaikunCatalogProjectBindingConfig,
+					catalogName,
+					catalogDescription,
+					projectID,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunCatalogExists,
+					resource.TestCheckResourceAttr("taikun_catalog_project_binding.foo", "id", catalogName+projectID),
+					resource.TestCheckResourceAttr("taikun_catalog_project_binding.foo", "catalog_name", catalogName),
+					resource.TestCheckResourceAttr("taikun_catalog_project_binding.foo", "project_id", projectID),
+					resource.TestCheckResourceAttr("taikun_catalog_project_binding.foo", "is_bound", "true"),
+					resource.TestCheckResourceAttrSet("taikun_catalog_project_binding.foo", "organization_id"),
+				),
+			},
+			{
+				ResourceName:      "taikun_catalog_project_binding.foo",
+				ImportState:       true,
+				ImportStateIdFunc: testAccResourceTaikunCatalogProjectBindingImportStateId,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "taikun_catalog_project_binding.foo",
+				ImportState:   true,
+				ImportStateId: catalogName,
+				ExpectError:   regexp.MustCompile(`unexpected format of ID .*expected catalog_id/project_id`),
+			},
+			{
+				ResourceName:  "taikun_catalog_project_binding.foo",
+				ImportState:   true,
+				ImportStateId: "/" + projectID,
+				ExpectError:   regexp.MustCompile(`catalog_id is empty`),
+			},
+			{
+				ResourceName:  "taikun_catalog_project_binding.foo",
+				ImportState:   true,
+				ImportStateId: catalogName + "/" + projectID,
+				ExpectError:   regexp.MustCompile(`catalog_id isn't valid`),
+			},
+		},
+	})
+}
+
+// Import the binding with the ID catalog_id/project_id
+func testAccResourceTaikunCatalogProjectBindingImportStateId(state *terraform.State) (string, error) {
+	catalog, found := state.RootModule().Resources["taikun_catalog.foo"]
+	if !found {
+		return "", errors.New("taikun_catalog.foo not found in state")
+	}
+	binding, found := state.RootModule().Resources["taikun_catalog_project_binding.foo"]
+	if !found {
+		return "", errors.New("taikun_catalog_project_binding.foo not found in state")
+	}
+	return fmt.Sprintf("%s/%s", catalog.Primary.ID, binding.Primary.Attributes["project_id"]), nil
+}
+
 func testAccCheckTaikunCatalogExists(state *terraform.State) error {
 	client := utils_testing.TestAccProvider.Meta().(*tk.Client)
 
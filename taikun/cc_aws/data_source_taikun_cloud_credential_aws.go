@@ -12,7 +12,7 @@ func dataSourceTaikunCloudCredentialAWSSchema() map[string]*schema.Schema {
 	dsSchema := utils.DataSourceSchemaFromResourceSchema(resourceTaikunCloudCredentialAWSSchema())
 	utils.AddRequiredFieldsToSchema(dsSchema, "id")
 	utils.SetValidateDiagFuncToSchema(dsSchema, "id", utils.StringIsInt)
-	utils.DeleteFieldsFromSchema(dsSchema, "secret_access_key", "access_key_id")
+	utils.DeleteFieldsFromSchema(dsSchema, "secret_access_key", "secret_access_key_wo", "secret_access_key_wo_version", "access_key_id")
 	return dsSchema
 }
 
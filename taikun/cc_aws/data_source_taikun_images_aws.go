@@ -74,13 +74,13 @@ func DataSourceTaikunImagesAWS() *schema.Resource {
 func dataSourceTaikunImagesAWSRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cloudCredentialID, err := utils.Atoi32(d.Get("cloud_credential_id").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	apiClient := meta.(*tk.Client)
 	owners, err := dataSourceTaikunImagesAWSGetOwnerID(ctx, apiClient, d.Get("owners").(*schema.Set).List())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	body := tkcore.AwsImagesPostListCommand{}
@@ -94,7 +94,7 @@ func dataSourceTaikunImagesAWSRead(ctx context.Context, d *schema.ResourceData,
 		body.SetOffset(offset)
 		response, res, err := apiClient.Client.ImagesAPI.ImagesAwsImagesList(ctx).AwsImagesPostListCommand(body).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		imageList = append(imageList, flattenTaikunImagesAWS(response.GetData()...)...)
 		if len(imageList) == int(response.GetTotalCount()) {
@@ -104,7 +104,7 @@ func dataSourceTaikunImagesAWSRead(ctx context.Context, d *schema.ResourceData,
 	}
 
 	if err := d.Set("images", imageList); err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	d.SetId(utils.I32toa(cloudCredentialID))
@@ -117,7 +117,7 @@ func dataSourceTaikunImagesAWSGetOwnerID(ctx context.Context, apiClient *tk.Clie
 	// Get list of Owners with ID and Name from API
 	response, res, err := apiClient.Client.AWSCloudCredentialAPI.AwsOwners(ctx).Execute()
 	if err != nil {
-		err = tk.CreateError(res, err)
+		err = utils.NewApiError(res, err)
 		return
 	}
 
@@ -4,16 +4,18 @@ import (
 	"context"
 	tk "github.com/itera-io/taikungoclient"
 	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/project"
 	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
 	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceTaikunCloudCredentialAWSSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
+	resourceSchema := map[string]*schema.Schema{
 		"access_key_id": {
 			Description:  "The AWS access key ID. (Can be set with env AWS_ACCESS_KEY_ID)",
 			Type:         schema.TypeString,
@@ -143,6 +145,8 @@ func resourceTaikunCloudCredentialAWSSchema() map[string]*schema.Schema {
 			ValidateFunc: validation.StringIsNotEmpty,
 		},
 	}
+	utils.AddWriteOnlySecret(resourceSchema, "secret_access_key")
+	return resourceSchema
 }
 
 func ResourceTaikunCloudCredentialAWS() *schema.Resource {
@@ -153,22 +157,32 @@ func ResourceTaikunCloudCredentialAWS() *schema.Resource {
 		UpdateContext: resourceTaikunCloudCredentialAWSUpdate,
 		DeleteContext: utils.ResourceTaikunCloudCredentialDelete,
 		Schema:        resourceTaikunCloudCredentialAWSSchema(),
+		CustomizeDiff: customdiff.All(
+			utils.SetDefaultOrganizationID,
+			utils.RequireImportedSecret("secret_access_key"),
+		),
+		Importer: utils.CloudCredentialImporter(tkcore.CLOUDTYPE_AWS, project.ResourceTaikunProjectGetCloudType),
 	}
 }
 
 func resourceTaikunCloudCredentialAWSCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(*tk.Client)
 
+	secretAccessKey, diags := utils.GetRequiredSecret(d, "secret_access_key")
+	if diags.HasError() {
+		return diags
+	}
+
 	body := tkcore.CreateAwsCloudCommand{}
 	body.SetName(d.Get("name").(string))
 	body.SetAwsAccessKeyId(d.Get("access_key_id").(string))
-	body.SetAwsSecretAccessKey(d.Get("secret_access_key").(string))
+	body.SetAwsSecretAccessKey(secretAccessKey)
 	body.SetAwsRegion(d.Get("region").(string))
 
 	/*
 		azCount, err := atoi32(d.Get("az_count").(string))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		} else if azCount < 1 || azCount > 3 {
 			return diag.Errorf("The az_count value must be between 1 and 3 inclusive.")
 		}
@@ -186,18 +200,18 @@ func resourceTaikunCloudCredentialAWSCreate(ctx context.Context, d *schema.Resou
 
 	createResult, res, err := apiClient.Client.AWSCloudCredentialAPI.AwsCreate(ctx).CreateAwsCloudCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 	id, err := utils.Atoi32(createResult.GetId())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	d.SetId(createResult.GetId())
 
 	if d.Get("lock").(bool) {
 		if err := resourceTaikunCloudCredentialAWSLock(ctx, id, true, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -215,26 +229,22 @@ func generateResourceTaikunCloudCredentialAWSRead(withRetries bool) schema.ReadC
 		id, err := utils.Atoi32(d.Id())
 		d.SetId("")
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		response, res, err := apiClient.Client.AWSCloudCredentialAPI.AwsList(ctx).Id(id).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		if len(response.GetData()) != 1 {
-			if withRetries {
-				d.SetId(utils.I32toa(id))
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-			}
-			return nil
+			return utils.ReadNotFound(d, utils.I32toa(id), withRetries)
 		}
 
 		rawCloudCredentialAWS := response.GetData()[0]
 
 		err = utils.SetResourceDataFromMap(d, flattenTaikunCloudCredentialAWS(&rawCloudCredentialAWS))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		d.SetId(utils.I32toa(id))
@@ -247,31 +257,36 @@ func resourceTaikunCloudCredentialAWSUpdate(ctx context.Context, d *schema.Resou
 	apiClient := meta.(*tk.Client)
 	id, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	if locked, _ := d.GetChange("lock"); locked.(bool) {
 		if err := resourceTaikunCloudCredentialAWSLock(ctx, id, false, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
-	if d.HasChanges("access_key_id", "secret_access_key", "name") {
+	if d.HasChanges("access_key_id", "name") || utils.SecretHasChange(d, "secret_access_key") {
+		secretAccessKey, diags := utils.GetRequiredSecret(d, "secret_access_key")
+		if diags.HasError() {
+			return diags
+		}
+
 		updateBody := tkcore.UpdateAwsCommand{}
 		updateBody.SetId(id)
 		updateBody.SetName(d.Get("name").(string))
 		updateBody.SetAwsAccessKeyId(d.Get("access_key_id").(string))
-		updateBody.SetAwsSecretAccessKey(d.Get("secret_access_key").(string))
+		updateBody.SetAwsSecretAccessKey(secretAccessKey)
 
 		res, err := apiClient.Client.AWSCloudCredentialAPI.AwsUpdate(ctx).UpdateAwsCommand(updateBody).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 	}
 
 	if d.Get("lock").(bool) {
 		if err := resourceTaikunCloudCredentialAWSLock(ctx, id, true, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -301,5 +316,5 @@ func resourceTaikunCloudCredentialAWSLock(ctx context.Context, id int32, lock bo
 	body.SetMode(utils.GetLockMode(lock))
 
 	res, err := apiClient.Client.CloudCredentialAPI.CloudcredentialsLockManager(ctx).CloudLockManagerCommand(body).Execute()
-	return tk.CreateError(res, err)
+	return utils.NewApiError(res, err)
 }
@@ -0,0 +1,28 @@
+//go:build sweep
+
+package cc_aws
+
+import (
+	"context"
+
+	tk "github.com/itera-io/taikungoclient"
+	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+)
+
+// Delete the AWS cloud credentials left behind by the acceptance tests, once the projects using them are deleted
+func AddTestSweepers(client utils.SweeperClientFunc) {
+	utils.AddListTestSweeper("taikun_cloud_credential_aws", client, []string{"taikun_project"},
+		func(ctx context.Context, apiClient *tk.Client, offset int32) ([]tkcore.AmazonCredentialsListDto, int, error) {
+			response, res, err := apiClient.Client.AWSCloudCredentialAPI.AwsList(ctx).Offset(offset).Execute()
+			if err != nil {
+				return nil, 0, utils.NewApiError(res, err)
+			}
+			return response.GetData(), int(response.GetTotalCount()), nil
+		},
+		(*tkcore.AmazonCredentialsListDto).GetName,
+		func(ctx context.Context, apiClient *tk.Client, cloudCredential *tkcore.AmazonCredentialsListDto) error {
+			return utils.SweepLockableResource(ctx, ResourceTaikunCloudCredentialAWS(), cloudCredential.GetId(), cloudCredential.GetIsLocked(), resourceTaikunCloudCredentialAWSLock, apiClient)
+		},
+	)
+}
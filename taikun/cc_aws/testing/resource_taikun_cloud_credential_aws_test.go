@@ -52,6 +52,15 @@ func TestAccResourceTaikunCloudCredentialAWS(t *testing.T) {
 					resource.TestCheckResourceAttrSet("taikun_cloud_credential_aws.foo", "is_default"),
 				),
 			},
+			{
+				ResourceName:      "taikun_cloud_credential_aws.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"access_key_id",
+					"secret_access_key",
+				},
+			},
 		},
 	})
 }
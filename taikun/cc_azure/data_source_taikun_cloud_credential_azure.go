@@ -12,7 +12,7 @@ func dataSourceTaikunCloudCredentialAzureSchema() map[string]*schema.Schema {
 	dsSchema := utils.DataSourceSchemaFromResourceSchema(resourceTaikunCloudCredentialAzureSchema())
 	utils.AddRequiredFieldsToSchema(dsSchema, "id")
 	utils.SetValidateDiagFuncToSchema(dsSchema, "id", utils.StringIsInt)
-	utils.DeleteFieldsFromSchema(dsSchema, "subscription_id", "client_id", "client_secret")
+	utils.DeleteFieldsFromSchema(dsSchema, "subscription_id", "client_id", "client_secret", "client_secret_wo", "client_secret_wo_version")
 	return dsSchema
 }
 
@@ -54,7 +54,7 @@ func dataSourceTaikunCloudCredentialsAzureRead(ctx context.Context, d *schema.Re
 	for {
 		response, res, err := params.Offset(offset).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		cloudCredentialsList = append(cloudCredentialsList, response.GetData()...)
 		if len(cloudCredentialsList) == int(response.GetTotalCount()) {
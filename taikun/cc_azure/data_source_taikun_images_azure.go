@@ -82,7 +82,7 @@ func dataSourceTaikunImagesAzureRead(ctx context.Context, d *schema.ResourceData
 	for {
 		response, res, err := params.Offset(offset).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		imageList = append(imageList, utils.FlattenTaikunImages(response.GetData()...)...)
 		if len(imageList) == int(response.GetTotalCount()) {
@@ -4,16 +4,18 @@ import (
 	"context"
 	tk "github.com/itera-io/taikungoclient"
 	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/project"
 	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
 	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceTaikunCloudCredentialAzureSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
+	resourceSchema := map[string]*schema.Schema{
 		"availability_zones": {
 			Description: "The given Azure availability zones for the location.",
 			Type:        schema.TypeList,
@@ -113,7 +115,6 @@ func resourceTaikunCloudCredentialAzureSchema() map[string]*schema.Schema {
 			Description:  "The Azure subscription ID. (Can be set with env AZURE_SUBSCRIPTION)",
 			Type:         schema.TypeString,
 			Required:     true,
-			ForceNew:     true,
 			DefaultFunc:  schema.EnvDefaultFunc("AZURE_SUBSCRIPTION", nil),
 			ValidateFunc: validation.StringIsNotEmpty,
 		},
@@ -126,6 +127,8 @@ func resourceTaikunCloudCredentialAzureSchema() map[string]*schema.Schema {
 			ValidateFunc: validation.StringIsNotEmpty,
 		},
 	}
+	utils.AddWriteOnlySecret(resourceSchema, "client_secret")
+	return resourceSchema
 }
 
 func ResourceTaikunCloudCredentialAzure() *schema.Resource {
@@ -136,23 +139,34 @@ func ResourceTaikunCloudCredentialAzure() *schema.Resource {
 		UpdateContext: resourceTaikunCloudCredentialAzureUpdate,
 		DeleteContext: utils.ResourceTaikunCloudCredentialDelete,
 		Schema:        resourceTaikunCloudCredentialAzureSchema(),
+		CustomizeDiff: customdiff.All(
+			utils.SetDefaultOrganizationID,
+			utils.RequireImportedSecret("client_secret"),
+			utils.ForceNewUnlessImported("subscription_id"),
+		),
+		Importer: utils.CloudCredentialImporter(tkcore.CLOUDTYPE_AZURE, project.ResourceTaikunProjectGetCloudType),
 	}
 }
 
 func resourceTaikunCloudCredentialAzureCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(*tk.Client)
 
+	clientSecret, diags := utils.GetRequiredSecret(d, "client_secret")
+	if diags.HasError() {
+		return diags
+	}
+
 	body := tkcore.CreateAzureCloudCommand{}
 	body.SetName(d.Get("name").(string))
 	body.SetAzureTenantId(d.Get("tenant_id").(string))
 	body.SetAzureClientId(d.Get("client_id").(string))
-	body.SetAzureClientSecret(d.Get("client_secret").(string))
+	body.SetAzureClientSecret(clientSecret)
 	body.SetAzureSubscriptionId(d.Get("subscription_id").(string))
 	body.SetAzureLocation(d.Get("location").(string))
 	azCount := int32(d.Get("az_count").(int))
 	/*
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		} else if azCount < 1 || azCount > 3 {
 			return diag.Errorf("The az_count value must be between 1 and 3 inclusive.")
 		}
@@ -170,18 +184,18 @@ func resourceTaikunCloudCredentialAzureCreate(ctx context.Context, d *schema.Res
 
 	createResult, res, err := apiClient.Client.AzureCloudCredentialAPI.AzureCreate(ctx).CreateAzureCloudCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 	id, err := utils.Atoi32(createResult.GetId())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	d.SetId(createResult.GetId())
 
 	if d.Get("lock").(bool) {
 		if err := resourceTaikunCloudCredentialAzureLock(ctx, id, true, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -199,26 +213,22 @@ func generateResourceTaikunCloudCredentialAzureRead(withRetries bool) schema.Rea
 		id, err := utils.Atoi32(d.Id())
 		d.SetId("")
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		response, res, err := apiClient.Client.AzureCloudCredentialAPI.AzureList(ctx).Id(id).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		if len(response.GetData()) != 1 {
-			if withRetries {
-				d.SetId(utils.I32toa(id))
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-			}
-			return nil
+			return utils.ReadNotFound(d, utils.I32toa(id), withRetries)
 		}
 
 		rawCloudCredentialAzure := response.GetData()[0]
 
 		err = utils.SetResourceDataFromMap(d, flattenTaikunCloudCredentialAzure(&rawCloudCredentialAzure))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		d.SetId(utils.I32toa(id))
@@ -231,31 +241,36 @@ func resourceTaikunCloudCredentialAzureUpdate(ctx context.Context, d *schema.Res
 	apiClient := meta.(*tk.Client)
 	id, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	if locked, _ := d.GetChange("lock"); locked.(bool) {
 		if err := resourceTaikunCloudCredentialAzureLock(ctx, id, false, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
-	if d.HasChanges("client_id", "client_secret", "name") {
+	if d.HasChanges("client_id", "name") || utils.SecretHasChange(d, "client_secret") {
+		clientSecret, diags := utils.GetRequiredSecret(d, "client_secret")
+		if diags.HasError() {
+			return diags
+		}
+
 		updateBody := tkcore.UpdateAzureCommand{}
 		updateBody.SetId(id)
 		updateBody.SetName(d.Get("name").(string))
 		updateBody.SetAzureClientId(d.Get("client_id").(string))
-		updateBody.SetAzureClientSecret(d.Get("client_secret").(string))
+		updateBody.SetAzureClientSecret(clientSecret)
 
 		res, err := apiClient.Client.AzureCloudCredentialAPI.AzureUpdate(ctx).UpdateAzureCommand(updateBody).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 	}
 
 	if d.Get("lock").(bool) {
 		if err := resourceTaikunCloudCredentialAzureLock(ctx, id, true, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -287,5 +302,5 @@ func resourceTaikunCloudCredentialAzureLock(ctx context.Context, id int32, lock
 	body.SetMode(utils.GetLockMode(lock))
 
 	res, err := apiClient.Client.CloudCredentialAPI.CloudcredentialsLockManager(ctx).CloudLockManagerCommand(body).Execute()
-	return tk.CreateError(res, err)
+	return utils.NewApiError(res, err)
 }
@@ -0,0 +1,28 @@
+//go:build sweep
+
+package cc_azure
+
+import (
+	"context"
+
+	tk "github.com/itera-io/taikungoclient"
+	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+)
+
+// Delete the Azure cloud credentials left behind by the acceptance tests, once the projects using them are deleted
+func AddTestSweepers(client utils.SweeperClientFunc) {
+	utils.AddListTestSweeper("taikun_cloud_credential_azure", client, []string{"taikun_project"},
+		func(ctx context.Context, apiClient *tk.Client, offset int32) ([]tkcore.AzureCredentialsListDto, int, error) {
+			response, res, err := apiClient.Client.AzureCloudCredentialAPI.AzureList(ctx).Offset(offset).Execute()
+			if err != nil {
+				return nil, 0, utils.NewApiError(res, err)
+			}
+			return response.GetData(), int(response.GetTotalCount()), nil
+		},
+		(*tkcore.AzureCredentialsListDto).GetName,
+		func(ctx context.Context, apiClient *tk.Client, cloudCredential *tkcore.AzureCredentialsListDto) error {
+			return utils.SweepLockableResource(ctx, ResourceTaikunCloudCredentialAzure(), cloudCredential.GetId(), cloudCredential.GetIsLocked(), resourceTaikunCloudCredentialAzureLock, apiClient)
+		},
+	)
+}
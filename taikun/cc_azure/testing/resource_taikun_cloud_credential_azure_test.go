@@ -56,6 +56,16 @@ func TestAccResourceTaikunCloudCredentialAzure(t *testing.T) {
 					resource.TestCheckResourceAttrSet("taikun_cloud_credential_azure.foo", "is_default"),
 				),
 			},
+			{
+				ResourceName:      "taikun_cloud_credential_azure.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"client_id",
+					"client_secret",
+					"subscription_id",
+				},
+			},
 		},
 	})
 }
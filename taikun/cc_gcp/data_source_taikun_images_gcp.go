@@ -77,7 +77,7 @@ func dataSourceTaikunImagesGCPRead(ctx context.Context, d *schema.ResourceData,
 	for {
 		response, res, err := params.Offset(offset).Latest(latest).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		imageList = append(imageList, utils.FlattenTaikunImages(response.GetData()...)...)
 		if len(imageList) == int(response.GetTotalCount()) {
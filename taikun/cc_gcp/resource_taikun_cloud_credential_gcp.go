@@ -4,11 +4,13 @@ import (
 	"context"
 	tk "github.com/itera-io/taikungoclient"
 	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/project"
 	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
 	"os"
 	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -19,7 +21,6 @@ func resourceTaikunCloudCredentialGCPSchema() map[string]*schema.Schema {
 			Description:  "The number of GCP availability zone expected for the region.",
 			Type:         schema.TypeInt,
 			Optional:     true,
-			ForceNew:     true,
 			ValidateFunc: validation.IntBetween(1, 3),
 			Default:      1,
 		},
@@ -40,7 +41,6 @@ func resourceTaikunCloudCredentialGCPSchema() map[string]*schema.Schema {
 			Description:      "The path of the GCP credential's configuration file.",
 			Type:             schema.TypeString,
 			Required:         true,
-			ForceNew:         true,
 			ValidateDiagFunc: utils.StringIsFilePath,
 		},
 		"folder_id": {
@@ -65,7 +65,6 @@ func resourceTaikunCloudCredentialGCPSchema() map[string]*schema.Schema {
 			Type:          schema.TypeBool,
 			Default:       false,
 			Optional:      true,
-			ForceNew:      true,
 			ConflictsWith: []string{"billing_account_id", "folder_id"},
 		},
 		"lock": {
@@ -125,6 +124,11 @@ func ResourceTaikunCloudCredentialGCP() *schema.Resource {
 		UpdateContext: resourceTaikunCloudCredentialGCPUpdate,
 		DeleteContext: utils.ResourceTaikunCloudCredentialDelete,
 		Schema:        resourceTaikunCloudCredentialGCPSchema(),
+		CustomizeDiff: customdiff.All(
+			utils.SetDefaultOrganizationID,
+			utils.ForceNewUnlessImported("az_count", "config_file", "import_project"),
+		),
+		Importer: utils.CloudCredentialImporter(tkcore.CLOUDTYPE_GOOGLE, project.ResourceTaikunProjectGetCloudType),
 	}
 }
 
@@ -135,7 +139,7 @@ func resourceTaikunCloudCredentialGCPCreate(ctx context.Context, d *schema.Resou
 
 	configFile, err := os.Open(d.Get("config_file").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 	params = params.Config(configFile)
 
@@ -147,7 +151,7 @@ func resourceTaikunCloudCredentialGCPCreate(ctx context.Context, d *schema.Resou
 	azCount := int32(d.Get("az_count").(int))
 	/*
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		} else if azCount < 1 || azCount > 3 {
 			return diag.Errorf("The az_count value must be between 1 and 3 inclusive.")
 		}
@@ -174,19 +178,19 @@ func resourceTaikunCloudCredentialGCPCreate(ctx context.Context, d *schema.Resou
 
 	createResult, res, err := params.Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	id, err := utils.Atoi32(createResult.GetId())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	d.SetId(createResult.GetId())
 
 	if d.Get("lock").(bool) {
 		if err := resourceTaikunCloudCredentialGCPLock(ctx, id, true, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -206,26 +210,22 @@ func generateResourceTaikunCloudCredentialGCPRead(withRetries bool) schema.ReadC
 		id, err := utils.Atoi32(d.Id())
 		d.SetId("")
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		response, res, err := apiClient.Client.GoogleAPI.GooglecloudList(ctx).Id(id).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		if len(response.GetData()) != 1 {
-			if withRetries {
-				d.SetId(utils.I32toa(id))
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-			}
-			return nil
+			return utils.ReadNotFound(d, utils.I32toa(id), withRetries)
 		}
 
 		rawCloudCredentialGCP := response.GetData()[0]
 
 		err = utils.SetResourceDataFromMap(d, flattenTaikunCloudCredentialGCP(&rawCloudCredentialGCP))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		d.SetId(utils.I32toa(id))
@@ -238,12 +238,12 @@ func resourceTaikunCloudCredentialGCPUpdate(ctx context.Context, d *schema.Resou
 	apiClient := meta.(*tk.Client)
 	id, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	if d.HasChange("lock") {
 		if err := resourceTaikunCloudCredentialGCPLock(ctx, id, d.Get("lock").(bool), apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -273,5 +273,5 @@ func resourceTaikunCloudCredentialGCPLock(ctx context.Context, id int32, lock bo
 	body.SetMode(utils.GetLockMode(lock))
 
 	res, err := apiClient.Client.CloudCredentialAPI.CloudcredentialsLockManager(ctx).CloudLockManagerCommand(body).Execute()
-	return tk.CreateError(res, err)
+	return utils.NewApiError(res, err)
 }
@@ -0,0 +1,28 @@
+//go:build sweep
+
+package cc_gcp
+
+import (
+	"context"
+
+	tk "github.com/itera-io/taikungoclient"
+	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+)
+
+// Delete the GCP cloud credentials left behind by the acceptance tests, once the projects using them are deleted
+func AddTestSweepers(client utils.SweeperClientFunc) {
+	utils.AddListTestSweeper("taikun_cloud_credential_gcp", client, []string{"taikun_project"},
+		func(ctx context.Context, apiClient *tk.Client, offset int32) ([]tkcore.GoogleCredentialsListDto, int, error) {
+			response, res, err := apiClient.Client.GoogleAPI.GooglecloudList(ctx).Offset(offset).Execute()
+			if err != nil {
+				return nil, 0, utils.NewApiError(res, err)
+			}
+			return response.GetData(), int(response.GetTotalCount()), nil
+		},
+		(*tkcore.GoogleCredentialsListDto).GetName,
+		func(ctx context.Context, apiClient *tk.Client, cloudCredential *tkcore.GoogleCredentialsListDto) error {
+			return utils.SweepLockableResource(ctx, ResourceTaikunCloudCredentialGCP(), cloudCredential.GetId(), cloudCredential.GetIsLocked(), resourceTaikunCloudCredentialGCPLock, apiClient)
+		},
+	)
+}
@@ -50,6 +50,16 @@ func TestAccResourceTaikunCloudCredentialGCP(t *testing.T) {
 					resource.TestCheckResourceAttr("taikun_cloud_credential_gcp.foo", "az_count", os.Getenv("GCP_AZ_COUNT")),
 				),
 			},
+			{
+				ResourceName:      "taikun_cloud_credential_gcp.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"az_count",
+					"config_file",
+					"import_project",
+				},
+			},
 		},
 	})
 }
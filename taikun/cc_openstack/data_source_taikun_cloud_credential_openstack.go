@@ -12,7 +12,7 @@ func dataSourceTaikunCloudCredentialOpenStackSchema() map[string]*schema.Schema
 	dsSchema := utils.DataSourceSchemaFromResourceSchema(resourceTaikunCloudCredentialOpenStackSchema())
 	utils.AddRequiredFieldsToSchema(dsSchema, "id")
 	utils.SetValidateDiagFuncToSchema(dsSchema, "id", utils.StringIsInt)
-	utils.DeleteFieldsFromSchema(dsSchema, "password", "url")
+	utils.DeleteFieldsFromSchema(dsSchema, "password", "password_wo", "password_wo_version", "url")
 	return dsSchema
 }
 
@@ -3,16 +3,18 @@ package cc_openstack
 import (
 	"context"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	tk "github.com/itera-io/taikungoclient"
 	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/project"
 	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
 	"regexp"
 )
 
 func resourceTaikunCloudCredentialOpenStackSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
+	resourceSchema := map[string]*schema.Schema{
 		"availability_zone": {
 			Description: "The OpenStack availability zone.",
 			Type:        schema.TypeString,
@@ -145,7 +147,6 @@ func resourceTaikunCloudCredentialOpenStackSchema() map[string]*schema.Schema {
 			Description:  "The OpenStack authentication URL. (Can be set with env OS_AUTH_URL)",
 			Type:         schema.TypeString,
 			Required:     true,
-			ForceNew:     true,
 			DefaultFunc:  schema.EnvDefaultFunc("OS_AUTH_URL", nil),
 			ValidateFunc: validation.StringIsNotEmpty,
 		},
@@ -170,6 +171,8 @@ func resourceTaikunCloudCredentialOpenStackSchema() map[string]*schema.Schema {
 			ForceNew:    true,
 		},
 	}
+	utils.AddWriteOnlySecret(resourceSchema, "password")
+	return resourceSchema
 }
 
 func ResourceTaikunCloudCredentialOpenStack() *schema.Resource {
@@ -180,6 +183,12 @@ func ResourceTaikunCloudCredentialOpenStack() *schema.Resource {
 		UpdateContext: resourceTaikunCloudCredentialOpenStackUpdate,
 		DeleteContext: utils.ResourceTaikunCloudCredentialDelete,
 		Schema:        resourceTaikunCloudCredentialOpenStackSchema(),
+		CustomizeDiff: customdiff.All(
+			utils.SetDefaultOrganizationID,
+			utils.RequireImportedSecret("password"),
+			utils.ForceNewUnlessImported("url"),
+		),
+		Importer: utils.CloudCredentialImporter(tkcore.CLOUDTYPE_OPENSTACK, project.ResourceTaikunProjectGetCloudType),
 	}
 }
 
@@ -194,7 +203,10 @@ func resourceTaikunCloudCredentialOpenStackCreate(ctx context.Context, d *schema
 	body.SetOpenStackRegion(d.Get("region").(string))
 
 	username := d.Get("user").(string)
-	password := d.Get("password").(string)
+	password, diags := utils.GetRequiredSecret(d, "password")
+	if diags.HasError() {
+		return diags
+	}
 	domain := d.Get("domain").(string)
 	appCredEnabledBool := d.Get("using_application_credentials").(bool)
 	//appcredenabled := d.Get("application_credential_enabled").(bool)
@@ -246,18 +258,18 @@ func resourceTaikunCloudCredentialOpenStackCreate(ctx context.Context, d *schema
 
 	createResult, res, err := apiClient.Client.OpenstackCloudCredentialAPI.OpenstackCreate(ctx).CreateOpenstackCloudCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 	id, err := utils.Atoi32(createResult.GetId())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	d.SetId(createResult.GetId())
 
 	if d.Get("lock").(bool) {
 		if err := resourceTaikunCloudCredentialOpenStackLock(ctx, id, true, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -275,26 +287,22 @@ func generateResourceTaikunCloudCredentialOpenStackRead(withRetries bool) schema
 		id, err := utils.Atoi32(d.Id())
 		d.SetId("")
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		response, res, err := apiClient.Client.OpenstackCloudCredentialAPI.OpenstackList(ctx).Id(id).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		if len(response.GetData()) != 1 {
-			if withRetries {
-				d.SetId(utils.I32toa(id))
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-			}
-			return nil
+			return utils.ReadNotFound(d, utils.I32toa(id), withRetries)
 		}
 
 		rawCloudCredentialOpenStack := response.GetData()[0]
 
 		err = utils.SetResourceDataFromMap(d, flattenTaikunCloudCredentialOpenStack(&rawCloudCredentialOpenStack))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		d.SetId(utils.I32toa(id))
@@ -307,22 +315,25 @@ func resourceTaikunCloudCredentialOpenStackUpdate(ctx context.Context, d *schema
 	apiClient := meta.(*tk.Client)
 	id, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	if locked, _ := d.GetChange("lock"); locked.(bool) {
 		if err := resourceTaikunCloudCredentialOpenStackLock(ctx, id, false, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
-	if d.HasChanges("user", "password", "name") {
+	if d.HasChanges("user", "name") || utils.SecretHasChange(d, "password") {
 		updateBody := tkcore.UpdateOpenStackCommand{}
 		updateBody.SetId(id)
 		updateBody.SetName(d.Get("name").(string))
 
 		username := d.Get("user").(string)
-		password := d.Get("password").(string)
+		password, diags := utils.GetRequiredSecret(d, "password")
+		if diags.HasError() {
+			return diags
+		}
 		appcredenabled := d.Get("using_application_credentials").(bool)
 
 		if appcredenabled {
@@ -335,13 +346,13 @@ func resourceTaikunCloudCredentialOpenStackUpdate(ctx context.Context, d *schema
 
 		res, err := apiClient.Client.OpenstackCloudCredentialAPI.OpenstackUpdate(ctx).UpdateOpenStackCommand(updateBody).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 	}
 
 	if d.Get("lock").(bool) {
 		if err := resourceTaikunCloudCredentialOpenStackLock(ctx, id, true, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -379,5 +390,5 @@ func resourceTaikunCloudCredentialOpenStackLock(ctx context.Context, id int32, l
 	body.SetMode(utils.GetLockMode(lock))
 
 	res, err := apiClient.Client.CloudCredentialAPI.CloudcredentialsLockManager(ctx).CloudLockManagerCommand(body).Execute()
-	return tk.CreateError(res, err)
+	return utils.NewApiError(res, err)
 }
@@ -54,6 +54,15 @@ func TestAccResourceTaikunCloudCredentialOpenStack(t *testing.T) {
 					resource.TestCheckResourceAttrSet("taikun_cloud_credential_openstack.foo", "is_default"),
 				),
 			},
+			{
+				ResourceName:      "taikun_cloud_credential_openstack.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"password",
+					"url",
+				},
+			},
 		},
 	})
 }
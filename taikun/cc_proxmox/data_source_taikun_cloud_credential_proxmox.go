@@ -12,7 +12,7 @@ func dataSourceTaikunCloudCredentialProxmoxSchema() map[string]*schema.Schema {
 	dsSchema := utils.DataSourceSchemaFromResourceSchema(resourceTaikunCloudCredentialProxmoxSchema())
 	utils.AddRequiredFieldsToSchema(dsSchema, "id")
 	utils.SetValidateDiagFuncToSchema(dsSchema, "id", utils.StringIsInt)
-	utils.DeleteFieldsFromSchema(dsSchema, "client_secret")
+	utils.DeleteFieldsFromSchema(dsSchema, "client_secret", "client_secret_wo", "client_secret_wo_version")
 	return dsSchema
 }
 
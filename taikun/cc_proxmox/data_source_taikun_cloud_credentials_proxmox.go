@@ -55,7 +55,7 @@ func dataSourceTaikunCloudCredentialsProxmoxRead(ctx context.Context, d *schema.
 	for {
 		response, res, err := params.Offset(offset).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		cloudCredentialsList = append(cloudCredentialsList, response.GetData()...)
 		if len(cloudCredentialsList) == int(response.GetTotalCount()) {
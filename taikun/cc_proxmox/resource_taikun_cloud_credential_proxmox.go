@@ -4,16 +4,18 @@ import (
 	"context"
 	tk "github.com/itera-io/taikungoclient"
 	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/project"
 	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
 	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceTaikunCloudCredentialProxmoxSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
+	resourceSchema := map[string]*schema.Schema{
 		"created_by": {
 			Description: "The creator of the Proxmox cloud credential.",
 			Type:        schema.TypeString,
@@ -228,6 +230,8 @@ func resourceTaikunCloudCredentialProxmoxSchema() map[string]*schema.Schema {
 			ForceNew:     true,
 		},
 	}
+	utils.AddWriteOnlySecret(resourceSchema, "client_secret")
+	return resourceSchema
 }
 
 func ResourceTaikunCloudCredentialProxmox() *schema.Resource {
@@ -238,17 +242,27 @@ func ResourceTaikunCloudCredentialProxmox() *schema.Resource {
 		UpdateContext: resourceTaikunCloudCredentialProxmoxUpdate,
 		DeleteContext: utils.ResourceTaikunCloudCredentialDelete,
 		Schema:        resourceTaikunCloudCredentialProxmoxSchema(),
+		CustomizeDiff: customdiff.All(
+			utils.SetDefaultOrganizationID,
+			utils.RequireImportedSecret("client_secret"),
+		),
+		Importer: utils.CloudCredentialImporter(tkcore.CLOUDTYPE_PROXMOX, project.ResourceTaikunProjectGetCloudType),
 	}
 }
 
 func resourceTaikunCloudCredentialProxmoxCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(*tk.Client)
 
+	clientSecret, diags := utils.GetRequiredSecret(d, "client_secret")
+	if diags.HasError() {
+		return diags
+	}
+
 	body := tkcore.CreateProxmoxCommand{}
 	body.SetName(d.Get("name").(string))
 	body.SetUrl(d.Get("api_host").(string))
 	body.SetTokenId(d.Get("client_id").(string))
-	body.SetTokenSecret(d.Get("client_secret").(string))
+	body.SetTokenSecret(clientSecret)
 	body.SetStorage(d.Get("storage").(string))
 	body.SetVmTemplateName(d.Get("vm_template_name").(string))
 	body.SetHypervisors(utils.ResourceGetStringList(d.Get("hypervisors")))
@@ -287,18 +301,18 @@ func resourceTaikunCloudCredentialProxmoxCreate(ctx context.Context, d *schema.R
 
 	createResult, res, err := apiClient.Client.ProxmoxCloudCredentialAPI.ProxmoxCreate(ctx).CreateProxmoxCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 	id, err := utils.Atoi32(createResult.GetId())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	d.SetId(createResult.GetId())
 
 	if d.Get("lock").(bool) {
 		if err := resourceTaikunCloudCredentialProxmoxLock(ctx, id, true, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -316,27 +330,23 @@ func generateResourceTaikunCloudCredentialProxmoxRead(withRetries bool) schema.R
 		id, err := utils.Atoi32(d.Id())
 		d.SetId("")
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		response, res, err := apiClient.Client.ProxmoxCloudCredentialAPI.ProxmoxList(ctx).Id(id).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 
 		if len(response.GetData()) != 1 {
-			if withRetries {
-				d.SetId(utils.I32toa(id))
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-			}
-			return nil
+			return utils.ReadNotFound(d, utils.I32toa(id), withRetries)
 		}
 
 		rawCloudCredentialProxmox := response.GetData()[0]
 
 		err = utils.SetResourceDataFromMap(d, flattenTaikunCloudCredentialProxmox(&rawCloudCredentialProxmox))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		d.SetId(utils.I32toa(id))
@@ -349,25 +359,30 @@ func resourceTaikunCloudCredentialProxmoxUpdate(ctx context.Context, d *schema.R
 	apiClient := meta.(*tk.Client)
 	id, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	if locked, _ := d.GetChange("lock"); locked.(bool) {
 		if err := resourceTaikunCloudCredentialProxmoxLock(ctx, id, false, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
-	if d.HasChanges("client_id", "client_secret", "name") {
+	if d.HasChanges("client_id", "name") || utils.SecretHasChange(d, "client_secret") {
+		clientSecret, diags := utils.GetRequiredSecret(d, "client_secret")
+		if diags.HasError() {
+			return diags
+		}
+
 		updateBody := tkcore.UpdateProxmoxCommand{}
 		updateBody.SetId(id)
 		updateBody.SetName(d.Get("name").(string))
 		updateBody.SetTokenId(d.Get("client_id").(string))
-		updateBody.SetTokenSecret(d.Get("client_secret").(string))
+		updateBody.SetTokenSecret(clientSecret)
 
 		res, err := apiClient.Client.ProxmoxCloudCredentialAPI.ProxmoxUpdate(ctx).UpdateProxmoxCommand(updateBody).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 	}
 
@@ -378,13 +393,13 @@ func resourceTaikunCloudCredentialProxmoxUpdate(ctx context.Context, d *schema.R
 
 		res, err := apiClient.Client.ProxmoxCloudCredentialAPI.ProxmoxUpdateHypervisors(ctx).UpdateHypervisorsCommand(updateBody).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 	}
 
 	if d.Get("lock").(bool) {
 		if err := resourceTaikunCloudCredentialProxmoxLock(ctx, id, true, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -464,5 +479,5 @@ func resourceTaikunCloudCredentialProxmoxLock(ctx context.Context, id int32, loc
 	body.SetMode(utils.GetLockMode(lock))
 
 	res, err := apiClient.Client.CloudCredentialAPI.CloudcredentialsLockManager(ctx).CloudLockManagerCommand(body).Execute()
-	return tk.CreateError(res, err)
+	return utils.NewApiError(res, err)
 }
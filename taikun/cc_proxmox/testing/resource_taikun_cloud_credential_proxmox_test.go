@@ -68,6 +68,14 @@ func TestAccResourceTaikunCloudCredentialProxmox(t *testing.T) {
 					resource.TestCheckResourceAttr("taikun_cloud_credential_proxmox.foo", "public_bridge", os.Getenv("PROXMOX_PUBLIC_BRIDGE")),
 				),
 			},
+			{
+				ResourceName:      "taikun_cloud_credential_proxmox.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"client_secret",
+				},
+			},
 		},
 	})
 }
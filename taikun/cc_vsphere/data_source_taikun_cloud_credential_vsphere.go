@@ -12,7 +12,7 @@ func dataSourceTaikunCloudCredentialVsphereSchema() map[string]*schema.Schema {
 	dsSchema := utils.DataSourceSchemaFromResourceSchema(resourceTaikunCloudCredentialVsphereSchema())
 	utils.AddRequiredFieldsToSchema(dsSchema, "id")
 	utils.SetValidateDiagFuncToSchema(dsSchema, "id", utils.StringIsInt)
-	utils.DeleteFieldsFromSchema(dsSchema, "password")
+	utils.DeleteFieldsFromSchema(dsSchema, "password", "password_wo", "password_wo_version")
 	return dsSchema
 }
 
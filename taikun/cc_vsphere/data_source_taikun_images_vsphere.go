@@ -58,7 +58,7 @@ func dataSourceTaikunImagesVsphereRead(ctx context.Context, d *schema.ResourceDa
 	for {
 		response, res, err := params.Offset(offset).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		data := response.GetData()
 		imageList = append(imageList, utils.FlattenTaikunImages(data...)...)
@@ -5,16 +5,18 @@ import (
 	"fmt"
 	tk "github.com/itera-io/taikungoclient"
 	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/project"
 	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
 	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceTaikunCloudCredentialVsphereSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
+	resourceSchema := map[string]*schema.Schema{
 		"created_by": {
 			Description: "The creator of the vSphere cloud credential.",
 			Type:        schema.TypeString,
@@ -257,6 +259,8 @@ func resourceTaikunCloudCredentialVsphereSchema() map[string]*schema.Schema {
 			ForceNew:     true,
 		},
 	}
+	utils.AddWriteOnlySecret(resourceSchema, "password")
+	return resourceSchema
 }
 
 func ResourceTaikunCloudCredentialVsphere() *schema.Resource {
@@ -267,17 +271,27 @@ func ResourceTaikunCloudCredentialVsphere() *schema.Resource {
 		UpdateContext: resourceTaikunCloudCredentialVsphereUpdate,
 		DeleteContext: utils.ResourceTaikunCloudCredentialDelete,
 		Schema:        resourceTaikunCloudCredentialVsphereSchema(),
+		CustomizeDiff: customdiff.All(
+			utils.SetDefaultOrganizationID,
+			utils.RequireImportedSecret("password"),
+		),
+		Importer: utils.CloudCredentialImporter(tkcore.CLOUDTYPE_VSPHERE, project.ResourceTaikunProjectGetCloudType),
 	}
 }
 
 func resourceTaikunCloudCredentialVsphereCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(*tk.Client)
 
+	password, diags := utils.GetRequiredSecret(d, "password")
+	if diags.HasError() {
+		return diags
+	}
+
 	body := tkcore.CreateVsphereCommand{}
 	body.SetName(d.Get("name").(string))
 
 	body.SetUsername(d.Get("username").(string))
-	body.SetPassword(d.Get("password").(string))
+	body.SetPassword(password)
 	body.SetUrl(d.Get("api_host").(string))
 	body.SetDatacenterName(d.Get("datacenter").(string))
 	body.SetResourcePoolName(d.Get("resource_pool").(string))
@@ -287,9 +301,9 @@ func resourceTaikunCloudCredentialVsphereCreate(ctx context.Context, d *schema.R
 	body.SetVmTemplateName(d.Get("vm_template_name").(string))
 	body.SetContinent(d.Get("continent").(string))
 
-	datacenterId, err := getDatacenterId(ctx, d.Get("datacenter").(string), d.Get("api_host").(string), d.Get("username").(string), d.Get("password").(string), meta)
+	datacenterId, err := getDatacenterId(ctx, d.Get("datacenter").(string), d.Get("api_host").(string), d.Get("username").(string), password, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 	body.SetDatacenterId(datacenterId)
 
@@ -327,18 +341,18 @@ func resourceTaikunCloudCredentialVsphereCreate(ctx context.Context, d *schema.R
 
 	createResult, res, err := apiClient.Client.VsphereCloudCredentialAPI.VsphereCreate(ctx).CreateVsphereCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 	id, err := utils.Atoi32(createResult.GetId())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	d.SetId(createResult.GetId())
 
 	if d.Get("lock").(bool) {
 		if err := resourceTaikunCloudCredentialVsphereLock(ctx, id, true, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -356,27 +370,23 @@ func generateResourceTaikunCloudCredentialVsphereRead(withRetries bool) schema.R
 		id, err := utils.Atoi32(d.Id())
 		d.SetId("")
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		response, res, err := apiClient.Client.VsphereCloudCredentialAPI.VsphereList(ctx).Id(id).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 
 		if len(response.GetData()) != 1 {
-			if withRetries {
-				d.SetId(utils.I32toa(id))
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-			}
-			return nil
+			return utils.ReadNotFound(d, utils.I32toa(id), withRetries)
 		}
 
 		rawCloudCredentialVsphere := response.GetData()[0]
 
 		err = utils.SetResourceDataFromMap(d, flattenTaikunCloudCredentialVsphere(&rawCloudCredentialVsphere))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		d.SetId(utils.I32toa(id))
@@ -389,25 +399,30 @@ func resourceTaikunCloudCredentialVsphereUpdate(ctx context.Context, d *schema.R
 	apiClient := meta.(*tk.Client)
 	id, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	if locked, _ := d.GetChange("lock"); locked.(bool) {
 		if err := resourceTaikunCloudCredentialVsphereLock(ctx, id, false, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
-	if d.HasChanges("client_id", "client_secret", "name") {
+	if d.HasChanges("username", "name") || utils.SecretHasChange(d, "password") {
+		password, diags := utils.GetRequiredSecret(d, "password")
+		if diags.HasError() {
+			return diags
+		}
+
 		updateBody := tkcore.UpdateVsphereCommand{}
 		updateBody.SetId(id)
 		updateBody.SetName(d.Get("name").(string))
 		updateBody.SetUsername(d.Get("username").(string))
-		updateBody.SetPassword(d.Get("password").(string))
+		updateBody.SetPassword(password)
 
 		res, err := apiClient.Client.VsphereCloudCredentialAPI.VsphereUpdate(ctx).UpdateVsphereCommand(updateBody).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 	}
 
@@ -418,13 +433,13 @@ func resourceTaikunCloudCredentialVsphereUpdate(ctx context.Context, d *schema.R
 
 		res, err := apiClient.Client.VsphereCloudCredentialAPI.VsphereUpdateVsphereHypervisors(ctx).UpdateVsphereHypervisorsCommand(updateBody).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 	}
 
 	if d.Get("lock").(bool) {
 		if err := resourceTaikunCloudCredentialVsphereLock(ctx, id, true, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -509,7 +524,7 @@ func resourceTaikunCloudCredentialVsphereLock(ctx context.Context, id int32, loc
 	body.SetMode(utils.GetLockMode(lock))
 
 	res, err := apiClient.Client.CloudCredentialAPI.CloudcredentialsLockManager(ctx).CloudLockManagerCommand(body).Execute()
-	return tk.CreateError(res, err)
+	return utils.NewApiError(res, err)
 }
 
 func getDatacenterId(ctx context.Context, datacenterName string, url string, username string, password string, meta interface{}) (string, error) {
@@ -523,7 +538,7 @@ func getDatacenterId(ctx context.Context, datacenterName string, url string, use
 	}
 	data, response, err := apiClient.Client.VsphereCloudCredentialAPI.VsphereDatacenterList(ctx).DatacenterListCommand(body).Execute()
 	if err != nil {
-		return "", tk.CreateError(response, err)
+		return "", utils.NewApiError(response, err)
 	}
 
 	// Iterate over the list and find the ID for our datacenter name
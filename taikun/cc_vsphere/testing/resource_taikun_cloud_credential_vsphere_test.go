@@ -66,6 +66,14 @@ func TestAccResourceTaikunCloudCredentialVsphere(t *testing.T) {
 					resource.TestCheckResourceAttr("taikun_cloud_credential_vsphere.foo", "private_end_allocation_range", os.Getenv("VSPHERE_PRIVATE_END_RANGE")),
 				),
 			},
+			{
+				ResourceName:      "taikun_cloud_credential_vsphere.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"password",
+				},
+			},
 		},
 	})
 }
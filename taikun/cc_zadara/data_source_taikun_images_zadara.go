@@ -66,7 +66,7 @@ func dataSourceTaikunImagesZadaraRead(ctx context.Context, d *schema.ResourceDat
 		params.Offset(offset)
 		response, res, err := params.Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		imageList = append(imageList, flattenTaikunImagesZadara(response.GetData()...)...)
 		if len(imageList) == int(response.GetTotalCount()) {
@@ -4,16 +4,18 @@ import (
 	"context"
 	tk "github.com/itera-io/taikungoclient"
 	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/project"
 	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
 	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceTaikunCloudCredentialZadaraSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
+	resourceSchema := map[string]*schema.Schema{
 		"access_key_id": {
 			Description:  "The Zadara access key ID. (Can be set with env ZADARA_ACCESS_KEY_ID)",
 			Type:         schema.TypeString,
@@ -146,6 +148,8 @@ func resourceTaikunCloudCredentialZadaraSchema() map[string]*schema.Schema {
 			),
 		},
 	}
+	utils.AddWriteOnlySecret(resourceSchema, "secret_access_key")
+	return resourceSchema
 }
 
 func ResourceTaikunCloudCredentialZadara() *schema.Resource {
@@ -156,16 +160,26 @@ func ResourceTaikunCloudCredentialZadara() *schema.Resource {
 		UpdateContext: resourceTaikunCloudCredentialZadaraUpdate,
 		DeleteContext: utils.ResourceTaikunCloudCredentialDelete,
 		Schema:        resourceTaikunCloudCredentialZadaraSchema(),
+		CustomizeDiff: customdiff.All(
+			utils.SetDefaultOrganizationID,
+			utils.RequireImportedSecret("secret_access_key"),
+		),
+		Importer: utils.CloudCredentialImporter(tkcore.CLOUDTYPE_ZADARA, project.ResourceTaikunProjectGetCloudType),
 	}
 }
 
 func resourceTaikunCloudCredentialZadaraCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(*tk.Client)
 
+	secretAccessKey, diags := utils.GetRequiredSecret(d, "secret_access_key")
+	if diags.HasError() {
+		return diags
+	}
+
 	body := tkcore.CreateZadaraCloudCommand{}
 	body.SetName(d.Get("name").(string))
 	body.SetZadaraAccessKeyId(d.Get("access_key_id").(string))
-	body.SetZadaraSecretAccessKey(d.Get("secret_access_key").(string))
+	body.SetZadaraSecretAccessKey(secretAccessKey)
 	body.SetZadaraRegion(d.Get("region").(string))
 	body.SetZadaraUrl(d.Get("url").(string))
 	body.SetZadaraVolumeType(d.Get("volume_type").(string))
@@ -182,18 +196,18 @@ func resourceTaikunCloudCredentialZadaraCreate(ctx context.Context, d *schema.Re
 
 	createResult, res, err := apiClient.Client.ZadaraCloudCredentialAPI.ZadaraCreate(ctx).CreateZadaraCloudCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 	id, err := utils.Atoi32(createResult.GetId())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	d.SetId(createResult.GetId())
 
 	if d.Get("lock").(bool) {
 		if err := resourceTaikunCloudCredentialZadaraLock(ctx, id, true, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -211,26 +225,22 @@ func generateResourceTaikunCloudCredentialZadaraRead(withRetries bool) schema.Re
 		id, err := utils.Atoi32(d.Id())
 		d.SetId("")
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		response, res, err := apiClient.Client.ZadaraCloudCredentialAPI.ZadaraList(ctx).Id(id).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		if len(response.GetData()) != 1 {
-			if withRetries {
-				d.SetId(utils.I32toa(id))
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-			}
-			return nil
+			return utils.ReadNotFound(d, utils.I32toa(id), withRetries)
 		}
 
 		rawCloudCredentialZadara := response.GetData()[0]
 
 		err = utils.SetResourceDataFromMap(d, flattenTaikunCloudCredentialZadara(&rawCloudCredentialZadara))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		d.SetId(utils.I32toa(id))
@@ -243,31 +253,36 @@ func resourceTaikunCloudCredentialZadaraUpdate(ctx context.Context, d *schema.Re
 	apiClient := meta.(*tk.Client)
 	id, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	if locked, _ := d.GetChange("lock"); locked.(bool) {
 		if err := resourceTaikunCloudCredentialZadaraLock(ctx, id, false, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
-	if d.HasChanges("access_key_id", "secret_access_key", "name") {
+	if d.HasChanges("access_key_id", "name") || utils.SecretHasChange(d, "secret_access_key") {
+		secretAccessKey, diags := utils.GetRequiredSecret(d, "secret_access_key")
+		if diags.HasError() {
+			return diags
+		}
+
 		updateBody := tkcore.UpdateZadaraCommand{}
 		updateBody.SetId(id)
 		updateBody.SetName(d.Get("name").(string))
 		updateBody.SetZadaraAccessKeyId(d.Get("access_key_id").(string))
-		updateBody.SetZadaraSecretAccessKey(d.Get("secret_access_key").(string))
+		updateBody.SetZadaraSecretAccessKey(secretAccessKey)
 
 		res, err := apiClient.Client.ZadaraCloudCredentialAPI.ZadaraUpdate(ctx).UpdateZadaraCommand(updateBody).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 	}
 
 	if d.Get("lock").(bool) {
 		if err := resourceTaikunCloudCredentialZadaraLock(ctx, id, true, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -299,5 +314,5 @@ func resourceTaikunCloudCredentialZadaraLock(ctx context.Context, id int32, lock
 	body.SetMode(utils.GetLockMode(lock))
 
 	res, err := apiClient.Client.CloudCredentialAPI.CloudcredentialsLockManager(ctx).CloudLockManagerCommand(body).Execute()
-	return tk.CreateError(res, err)
+	return utils.NewApiError(res, err)
 }
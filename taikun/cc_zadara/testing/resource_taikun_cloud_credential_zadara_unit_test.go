@@ -0,0 +1,70 @@
+package testing
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils_testing"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils_testing/faketaikun"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testUnitResourceTaikunCloudCredentialZadaraConfig = `
+resource "taikun_cloud_credential_zadara" "foo" {
+  name = "%s"
+  lock = %t
+
+  url               = "https://zadara.example.com"
+  region            = "symphony"
+  volume_type       = "gp2"
+  access_key_id     = "access-key"
+  secret_access_key = "secret-key"
+  az_count          = 2
+}
+`
+
+// TestUnitResourceTaikunCloudCredentialZadara runs against the fake Taikun server, it needs neither Taikun credentials nor Zadara credentials.
+// Importing is not tested: the importer looks the cloud credential up in every cloud, only Zadara is emulated.
+func TestUnitResourceTaikunCloudCredentialZadara(t *testing.T) {
+	faketaikun.Start(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: utils_testing.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckTaikunCloudCredentialZadaraDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testUnitResourceTaikunCloudCredentialZadaraConfig, "zadara", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunCloudCredentialZadaraExists,
+					resource.TestCheckResourceAttr("taikun_cloud_credential_zadara.foo", "name", "zadara"),
+					resource.TestCheckResourceAttr("taikun_cloud_credential_zadara.foo", "organization_id", fmt.Sprint(faketaikun.DefaultOrganizationID)),
+					resource.TestCheckResourceAttr("taikun_cloud_credential_zadara.foo", "organization_name", faketaikun.DefaultOrganizationName),
+					resource.TestCheckResourceAttr("taikun_cloud_credential_zadara.foo", "url", "https://zadara.example.com"),
+					resource.TestCheckResourceAttr("taikun_cloud_credential_zadara.foo", "region", "symphony"),
+					resource.TestCheckResourceAttr("taikun_cloud_credential_zadara.foo", "volume_type", "gp2"),
+					resource.TestCheckResourceAttr("taikun_cloud_credential_zadara.foo", "az_count", "2"),
+					resource.TestCheckResourceAttr("taikun_cloud_credential_zadara.foo", "availability_zones.#", "2"),
+					resource.TestCheckResourceAttr("taikun_cloud_credential_zadara.foo", "is_default", "false"),
+					resource.TestCheckResourceAttr("taikun_cloud_credential_zadara.foo", "lock", "false"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testUnitResourceTaikunCloudCredentialZadaraConfig, "zadara", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunCloudCredentialZadaraExists,
+					resource.TestCheckResourceAttr("taikun_cloud_credential_zadara.foo", "lock", "true"),
+				),
+			},
+			{
+				// The cloud credential is unlocked to be renamed
+				Config: fmt.Sprintf(testUnitResourceTaikunCloudCredentialZadaraConfig, "renamed", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunCloudCredentialZadaraExists,
+					resource.TestCheckResourceAttr("taikun_cloud_credential_zadara.foo", "name", "renamed"),
+					resource.TestCheckResourceAttr("taikun_cloud_credential_zadara.foo", "lock", "false"),
+				),
+			},
+		},
+	})
+}
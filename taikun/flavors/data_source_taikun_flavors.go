@@ -106,7 +106,7 @@ func dataSourceTaikunFlavorsRead(ctx context.Context, d *schema.ResourceData, me
 	for {
 		response, res, err := prepare.Offset(offset).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		flavorDTOs = append(flavorDTOs, response.GetData()...)
 		if len(flavorDTOs) == int(response.GetTotalCount()) {
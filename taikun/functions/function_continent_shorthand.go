@@ -0,0 +1,50 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+)
+
+var _ function.Function = &continentShorthandFunction{}
+
+type continentShorthandFunction struct{}
+
+func NewContinentShorthandFunction() function.Function {
+	return &continentShorthandFunction{}
+}
+
+func (f *continentShorthandFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "continent_shorthand"
+}
+
+func (f *continentShorthandFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Convert a continent to the shorthand used by Taikun",
+		MarkdownDescription: "Convert a continent name (`Europe`, `Asia` or `America`, case insensitive) to the shorthand the Taikun API uses for the continent of cloud credentials: `eu`, `as` or `us`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "continent",
+				MarkdownDescription: "Name of the continent.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *continentShorthandFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var continent string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &continent))
+	if resp.Error != nil {
+		return
+	}
+
+	shorthand := utils.ContinentShorthand(continent)
+	if shorthand == "" {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("unknown continent %q, expected one of Europe, Asia or America", continent))
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, shorthand))
+}
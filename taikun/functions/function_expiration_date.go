@@ -0,0 +1,92 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+)
+
+// Durations in days or weeks, which time.ParseDuration does not support
+var expirationDateDurationRegexp = regexp.MustCompile(`^([0-9]+)([dw])$`)
+
+var _ function.Function = &expirationDateFunction{}
+
+type expirationDateFunction struct{}
+
+func NewExpirationDateFunction() function.Function {
+	return &expirationDateFunction{}
+}
+
+func (f *expirationDateFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "expiration_date"
+}
+
+func (f *expirationDateFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Compute an expiration date in the format expected by Taikun",
+		MarkdownDescription: "Add `duration` to the RFC 3339 `timestamp` and return the resulting date in the format `dd/mm/yyyy`, " +
+			"as expected by attributes such as the project's `expiration_date`. " +
+			"Use `plantimestamp()` as the timestamp, the result must not change between the plan and the apply.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "duration",
+				MarkdownDescription: "Duration to add, either in days (`30d`), in weeks (`2w`) or in a format supported by `timeadd` (`720h`).",
+			},
+			function.StringParameter{
+				Name:                "timestamp",
+				MarkdownDescription: "RFC 3339 timestamp the duration is added to, for example `plantimestamp()`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *expirationDateFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var duration, timestamp string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &duration, &timestamp))
+	if resp.Error != nil {
+		return
+	}
+
+	parsedDuration, err := parseExpirationDuration(duration)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	parsedTimestamp, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("expected an RFC 3339 timestamp, got %q", timestamp))
+		return
+	}
+
+	expirationDate := utils.Rfc3339DateTimeToDate(parsedTimestamp.UTC().Add(parsedDuration).Format(time.RFC3339))
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, expirationDate))
+}
+
+func parseExpirationDuration(duration string) (time.Duration, error) {
+	if match := expirationDateDurationRegexp.FindStringSubmatch(duration); match != nil {
+		count, err := strconv.Atoi(match[1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %s", duration, err)
+		}
+		days := count
+		if match[2] == "w" {
+			days = count * 7
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+
+	parsedDuration, err := time.ParseDuration(duration)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q, expected for example 30d, 2w or 720h", duration)
+	}
+	if parsedDuration < 0 {
+		return 0, fmt.Errorf("invalid duration %q, an expiration date cannot be in the past", duration)
+	}
+	return parsedDuration, nil
+}
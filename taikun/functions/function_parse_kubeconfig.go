@@ -0,0 +1,167 @@
+package functions
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+var parseKubeconfigAttributeTypes = map[string]attr.Type{
+	"client_certificate":     types.StringType,
+	"client_key":             types.StringType,
+	"cluster_ca_certificate": types.StringType,
+	"host":                   types.StringType,
+	"namespace":              types.StringType,
+	"token":                  types.StringType,
+}
+
+var _ function.Function = &parseKubeconfigFunction{}
+
+type parseKubeconfigFunction struct{}
+
+type parseKubeconfigResult struct {
+	ClientCertificate    string `tfsdk:"client_certificate"`
+	ClientKey            string `tfsdk:"client_key"`
+	ClusterCaCertificate string `tfsdk:"cluster_ca_certificate"`
+	Host                 string `tfsdk:"host"`
+	Namespace            string `tfsdk:"namespace"`
+	Token                string `tfsdk:"token"`
+}
+
+// Subset of the kubeconfig format needed to connect to a cluster
+type kubeconfigFile struct {
+	CurrentContext string `yaml:"current-context"`
+	Clusters       []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster   string `yaml:"cluster"`
+			User      string `yaml:"user"`
+			Namespace string `yaml:"namespace"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			ClientCertificateData string `yaml:"client-certificate-data"`
+			ClientKeyData         string `yaml:"client-key-data"`
+			Token                 string `yaml:"token"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+}
+
+func NewParseKubeconfigFunction() function.Function {
+	return &parseKubeconfigFunction{}
+}
+
+func (f *parseKubeconfigFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_kubeconfig"
+}
+
+func (f *parseKubeconfigFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Extract the connection settings of a kubeconfig",
+		MarkdownDescription: "Parse the content of a kubeconfig, such as the `content` of the `taikun_kubeconfig` resource or ephemeral resource, " +
+			"and return the settings of its current context: `host`, `cluster_ca_certificate`, `client_certificate`, `client_key`, `token` and `namespace`. " +
+			"Certificates and keys are decoded from base64 and can be passed as is to the `kubernetes` or `helm` providers. Settings missing from the kubeconfig are empty strings.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "content",
+				MarkdownDescription: "Content of the kubeconfig's YAML file.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: parseKubeconfigAttributeTypes,
+		},
+	}
+}
+
+func (f *parseKubeconfigFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var content string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &content))
+	if resp.Error != nil {
+		return
+	}
+
+	result, err := parseKubeconfig(content)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
+
+func parseKubeconfig(content string) (*parseKubeconfigResult, error) {
+	var kubeconfig kubeconfigFile
+	if err := yaml.Unmarshal([]byte(content), &kubeconfig); err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig: %s", err)
+	}
+	if len(kubeconfig.Clusters) == 0 {
+		return nil, fmt.Errorf("invalid kubeconfig: no cluster defined")
+	}
+
+	// Without contexts, the first cluster and user are used
+	clusterName := kubeconfig.Clusters[0].Name
+	userName := ""
+	if len(kubeconfig.Users) != 0 {
+		userName = kubeconfig.Users[0].Name
+	}
+	result := &parseKubeconfigResult{}
+	for i, kubeContext := range kubeconfig.Contexts {
+		if kubeContext.Name == kubeconfig.CurrentContext || (i == 0 && kubeconfig.CurrentContext == "") {
+			clusterName = kubeContext.Context.Cluster
+			userName = kubeContext.Context.User
+			result.Namespace = kubeContext.Context.Namespace
+			break
+		}
+	}
+
+	clusterFound := false
+	for _, cluster := range kubeconfig.Clusters {
+		if cluster.Name != clusterName {
+			continue
+		}
+		clusterFound = true
+		result.Host = cluster.Cluster.Server
+		certificateAuthority, err := base64.StdEncoding.DecodeString(cluster.Cluster.CertificateAuthorityData)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate-authority-data of cluster %s: %s", clusterName, err)
+		}
+		result.ClusterCaCertificate = string(certificateAuthority)
+		break
+	}
+	if !clusterFound {
+		return nil, fmt.Errorf("invalid kubeconfig: cluster %s not found", clusterName)
+	}
+
+	for _, user := range kubeconfig.Users {
+		if user.Name != userName {
+			continue
+		}
+		clientCertificate, err := base64.StdEncoding.DecodeString(user.User.ClientCertificateData)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client-certificate-data of user %s: %s", userName, err)
+		}
+		clientKey, err := base64.StdEncoding.DecodeString(user.User.ClientKeyData)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client-key-data of user %s: %s", userName, err)
+		}
+		result.ClientCertificate = string(clientCertificate)
+		result.ClientKey = string(clientKey)
+		result.Token = user.User.Token
+		break
+	}
+
+	return result, nil
+}
@@ -0,0 +1,93 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Same format as the Kubernetes versions of projects, the leading v is optional
+var kubernetesVersionRegexp = regexp.MustCompile(`^v?([0-9]+)\.([0-9]+)\.([0-9]+)$`)
+
+var _ function.Function = &versionCompareFunction{}
+
+type versionCompareFunction struct{}
+
+func NewVersionCompareFunction() function.Function {
+	return &versionCompareFunction{}
+}
+
+func (f *versionCompareFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "version_compare"
+}
+
+func (f *versionCompareFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Compare two Kubernetes versions",
+		MarkdownDescription: "Compare two versions in the format `vMAJOR.MINOR.PATCH`, such as the project's `kubernetes_version`. " +
+			"Return `-1` if `version1` is older than `version2`, `0` if they are equal and `1` if `version1` is newer. " +
+			"Fail if either version is not in the expected format.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "version1",
+				MarkdownDescription: "First version, for example `v1.28.3`. The leading `v` is optional.",
+			},
+			function.StringParameter{
+				Name:                "version2",
+				MarkdownDescription: "Second version, for example `v1.29.0`. The leading `v` is optional.",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f *versionCompareFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var version1, version2 string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &version1, &version2))
+	if resp.Error != nil {
+		return
+	}
+
+	parsedVersion1, err := parseKubernetesVersion(version1)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	parsedVersion2, err := parseKubernetesVersion(version2)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	var result int64
+	for i := range parsedVersion1 {
+		if parsedVersion1[i] != parsedVersion2[i] {
+			if parsedVersion1[i] < parsedVersion2[i] {
+				result = -1
+			} else {
+				result = 1
+			}
+			break
+		}
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
+
+func parseKubernetesVersion(version string) ([3]int, error) {
+	var parsedVersion [3]int
+	match := kubernetesVersionRegexp.FindStringSubmatch(version)
+	if match == nil {
+		return parsedVersion, fmt.Errorf("invalid version %q, Kubernetes versions must be in the format vMAJOR.MINOR.PATCH", version)
+	}
+	for i := range parsedVersion {
+		number, err := strconv.Atoi(match[i+1])
+		if err != nil {
+			return parsedVersion, fmt.Errorf("invalid version %q: %s", version, err)
+		}
+		parsedVersion[i] = number
+	}
+	return parsedVersion, nil
+}
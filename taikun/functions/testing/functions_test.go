@@ -0,0 +1,189 @@
+package testing
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils_testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+const testAccFunctionTaikunExpirationDateConfig = `
+output "days" {
+  value = provider::taikun::expiration_date("30d", "2024-01-15T10:00:00Z")
+}
+
+output "hours" {
+  value = provider::taikun::expiration_date("36h", "2024-12-31T13:00:00+01:00")
+}
+`
+
+func TestUnitFunctionTaikunExpirationDate(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV5ProviderFactories: utils_testing.TestAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFunctionTaikunExpirationDateConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("days", "14/02/2024"),
+					resource.TestCheckOutput("hours", "02/01/2025"),
+				),
+			},
+		},
+	})
+}
+
+const testAccFunctionTaikunVersionCompareConfig = `
+output "older" {
+  value = provider::taikun::version_compare("v1.28.3", "v1.29.0")
+}
+
+output "equal" {
+  value = provider::taikun::version_compare("v1.28.3", "1.28.3")
+}
+
+output "newer" {
+  value = provider::taikun::version_compare("v1.28.10", "v1.28.9")
+}
+`
+
+const testAccFunctionTaikunVersionCompareInvalidConfig = `
+output "invalid" {
+  value = provider::taikun::version_compare("1.28", "v1.29.0")
+}
+`
+
+func TestUnitFunctionTaikunVersionCompare(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV5ProviderFactories: utils_testing.TestAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFunctionTaikunVersionCompareConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("older", "-1"),
+					resource.TestCheckOutput("equal", "0"),
+					resource.TestCheckOutput("newer", "1"),
+				),
+			},
+			{
+				Config:      testAccFunctionTaikunVersionCompareInvalidConfig,
+				ExpectError: regexp.MustCompile("vMAJOR.MINOR.PATCH"),
+			},
+		},
+	})
+}
+
+const testAccFunctionTaikunParseKubeconfigConfig = `
+locals {
+  kubeconfig = provider::taikun::parse_kubeconfig(<<-EOT
+    apiVersion: v1
+    kind: Config
+    clusters:
+    - name: foo
+      cluster:
+        server: https://10.0.0.1:6443
+        certificate-authority-data: %s
+    contexts:
+    - name: foo-admin
+      context:
+        cluster: foo
+        user: admin
+        namespace: default
+    current-context: foo-admin
+    users:
+    - name: admin
+      user:
+        token: secret-token
+  EOT
+  )
+}
+
+output "host" {
+  value = local.kubeconfig.host
+}
+
+output "cluster_ca_certificate" {
+  value = local.kubeconfig.cluster_ca_certificate
+}
+
+output "token" {
+  value = local.kubeconfig.token
+}
+
+output "namespace" {
+  value = local.kubeconfig.namespace
+}
+`
+
+func TestUnitFunctionTaikunParseKubeconfig(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV5ProviderFactories: utils_testing.TestAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Base64 encoding of "certificate"
+				Config: fmt.Sprintf(testAccFunctionTaikunParseKubeconfigConfig, "Y2VydGlmaWNhdGU="),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("host", "https://10.0.0.1:6443"),
+					resource.TestCheckOutput("cluster_ca_certificate", "certificate"),
+					resource.TestCheckOutput("token", "secret-token"),
+					resource.TestCheckOutput("namespace", "default"),
+				),
+			},
+		},
+	})
+}
+
+const testAccFunctionTaikunContinentShorthandConfig = `
+output "europe" {
+  value = provider::taikun::continent_shorthand("Europe")
+}
+
+output "asia" {
+  value = provider::taikun::continent_shorthand("asia")
+}
+
+output "america" {
+  value = provider::taikun::continent_shorthand("AMERICA")
+}
+`
+
+const testAccFunctionTaikunContinentShorthandInvalidConfig = `
+output "invalid" {
+  value = provider::taikun::continent_shorthand("Atlantis")
+}
+`
+
+func TestUnitFunctionTaikunContinentShorthand(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV5ProviderFactories: utils_testing.TestAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFunctionTaikunContinentShorthandConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("europe", "eu"),
+					resource.TestCheckOutput("asia", "as"),
+					resource.TestCheckOutput("america", "us"),
+				),
+			},
+			{
+				Config:      testAccFunctionTaikunContinentShorthandInvalidConfig,
+				ExpectError: regexp.MustCompile(`unknown\s+continent\s+"Atlantis"`),
+			},
+		},
+	})
+}
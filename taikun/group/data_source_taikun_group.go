@@ -51,7 +51,7 @@ func dataSourceTaikunGroupRead(ctx context.Context, d *schema.ResourceData, meta
 
 	response, res, err := apiClient.Client.GroupsAPI.GroupsList(ctx).AccountId(accountId).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	for _, item := range response.GetData() {
@@ -56,7 +56,7 @@ func dataSourceTaikunGroupsRead(ctx context.Context, d *schema.ResourceData, met
 
 	response, res, err := apiClient.Client.GroupsAPI.GroupsList(ctx).AccountId(accountId).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	err = d.Set("groups", flattenGroups(response.GetData()))
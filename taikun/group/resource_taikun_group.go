@@ -58,7 +58,7 @@ func resourceTaikunGroupCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	id, res, err := apiClient.Client.GroupsAPI.GroupsCreate(ctx).CreateGroupCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	d.SetId(utils.I32toa(id))
@@ -86,7 +86,7 @@ func generateResourceTaikunGroupRead(withRetries bool) schema.ReadContextFunc {
 
 		response, res, err := apiClient.Client.GroupsAPI.GroupsList(ctx).AccountId(accountId).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 
 		var found *tkcore.GroupListItem
@@ -99,11 +99,7 @@ func generateResourceTaikunGroupRead(withRetries bool) schema.ReadContextFunc {
 		}
 
 		if found == nil {
-			if withRetries {
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-			}
-			d.SetId("")
-			return nil
+			return utils.ReadNotFound(d, d.Id(), withRetries)
 		}
 
 		err = utils.SetResourceDataFromMap(d, flattenTaikunGroup(found, accountId))
@@ -133,7 +129,7 @@ func resourceTaikunGroupUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 	res, err := apiClient.Client.GroupsAPI.GroupsUpdate(ctx, id).UpdateGroupDto(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	return utils.ReadAfterUpdateWithRetries(generateResourceTaikunGroupReadWithRetries(), ctx, d, meta)
@@ -151,7 +147,7 @@ func resourceTaikunGroupDelete(ctx context.Context, d *schema.ResourceData, meta
 		if res != nil && res.StatusCode == 404 {
 			return nil
 		}
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	d.SetId("")
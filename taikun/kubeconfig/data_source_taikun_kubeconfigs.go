@@ -49,7 +49,7 @@ func dataSourceTaikunKubeconfigsRead(ctx context.Context, d *schema.ResourceData
 	for {
 		response, res, err := params.Offset(offset).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		retrievedKubeconfigCount += len(response.Data)
 		for _, kubeconfigDTO := range response.Data {
@@ -0,0 +1,290 @@
+package kubeconfig
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	tk "github.com/itera-io/taikungoclient"
+	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+)
+
+// Private data key holding the kubeconfig created by Open, deleted by Close
+const ephemeralKubeconfigPrivateKey = "created_kubeconfig"
+
+// Validity period in minutes of the kubeconfigs created by the ephemeral resource
+const ephemeralKubeconfigDefaultValidityPeriod = 60
+
+var kubeconfigRoles = []string{"cluster-admin", "admin", "edit", "view"}
+var kubeconfigAccessScopes = []string{"all", "managers", "personal"}
+
+var (
+	_ ephemeral.EphemeralResource                   = &ephemeralTaikunKubeconfig{}
+	_ ephemeral.EphemeralResourceWithConfigure      = &ephemeralTaikunKubeconfig{}
+	_ ephemeral.EphemeralResourceWithClose          = &ephemeralTaikunKubeconfig{}
+	_ ephemeral.EphemeralResourceWithValidateConfig = &ephemeralTaikunKubeconfig{}
+)
+
+type ephemeralTaikunKubeconfig struct {
+	apiClient *tk.Client
+}
+
+type ephemeralTaikunKubeconfigModel struct {
+	AccessScope    types.String `tfsdk:"access_scope"`
+	Content        types.String `tfsdk:"content"`
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Namespace      types.String `tfsdk:"namespace"`
+	ProjectID      types.String `tfsdk:"project_id"`
+	Role           types.String `tfsdk:"role"`
+	ValidityPeriod types.Int64  `tfsdk:"validity_period"`
+}
+
+type ephemeralTaikunKubeconfigPrivate struct {
+	ID        int32 `json:"id"`
+	ProjectID int32 `json:"project_id"`
+}
+
+func NewEphemeralTaikunKubeconfig() ephemeral.EphemeralResource {
+	return &ephemeralTaikunKubeconfig{}
+}
+
+func (e *ephemeralTaikunKubeconfig) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kubeconfig"
+}
+
+func (e *ephemeralTaikunKubeconfig) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Taikun kubeconfig only available for the current run, its content is never stored in the plan or the state. " +
+			"Either fetch an existing kubeconfig with `id`, or omit `id` to create a short-lived kubeconfig which is deleted at the end of the run.",
+		Attributes: map[string]schema.Attribute{
+			"access_scope": schema.StringAttribute{
+				MarkdownDescription: "Who can use the created kubeconfig: `personal` (only you), `managers` (managers only) or `all` (all users with access to this project). Defaults to `personal`.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "Content of the kubeconfig's YAML file.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of an existing kubeconfig to fetch. If omitted, a kubeconfig is created for the run.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The kubeconfig's name. Generated for created kubeconfigs if omitted.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "The created kubeconfig's namespace.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the kubeconfig's project.",
+				Required:            true,
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "The created kubeconfig's role: `cluster-admin`, `admin`, `edit` or `view`. Required if `id` is omitted.",
+				Optional:            true,
+			},
+			"validity_period": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The created kubeconfig's validity period in minutes, so that it expires even if it could not be deleted. Defaults to `%d`.", ephemeralKubeconfigDefaultValidityPeriod),
+				Optional:            true,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (e *ephemeralTaikunKubeconfig) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	apiClient, ok := req.ProviderData.(*tk.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *tk.Client, got %T.", req.ProviderData))
+		return
+	}
+	e.apiClient = apiClient
+}
+
+func (e *ephemeralTaikunKubeconfig) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var data ephemeralTaikunKubeconfigModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.ProjectID.IsUnknown() && !data.ProjectID.IsNull() {
+		if _, err := utils.Atoi32(data.ProjectID.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("project_id"), "Invalid project ID", "expected an int inside a string")
+		}
+	}
+	if data.ID.IsNull() && data.Role.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("role"), "Missing role", "role is required to create a kubeconfig when id is omitted.")
+	}
+	if !data.Role.IsNull() && !data.Role.IsUnknown() && !slices.Contains(kubeconfigRoles, data.Role.ValueString()) {
+		resp.Diagnostics.AddAttributeError(path.Root("role"), "Invalid role", fmt.Sprintf("expected role to be one of %v, got %s", kubeconfigRoles, data.Role.ValueString()))
+	}
+	if !data.AccessScope.IsNull() && !data.AccessScope.IsUnknown() && !slices.Contains(kubeconfigAccessScopes, data.AccessScope.ValueString()) {
+		resp.Diagnostics.AddAttributeError(path.Root("access_scope"), "Invalid access scope", fmt.Sprintf("expected access_scope to be one of %v, got %s", kubeconfigAccessScopes, data.AccessScope.ValueString()))
+	}
+	if !data.ValidityPeriod.IsNull() && !data.ValidityPeriod.IsUnknown() && data.ValidityPeriod.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("validity_period"), "Invalid validity period", "a kubeconfig created for the run must expire, expected validity_period to be at least 1")
+	}
+}
+
+func (e *ephemeralTaikunKubeconfig) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ephemeralTaikunKubeconfigModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := utils.Atoi32(data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("project_id"), "Invalid project ID", err.Error())
+		return
+	}
+
+	var kubeconfigID int32
+	if data.ID.IsNull() {
+		kubeconfigID = e.create(ctx, projectID, &data, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		kubeconfigID, err = utils.Atoi32(data.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("id"), "Invalid kubeconfig ID", err.Error())
+			return
+		}
+		e.readExisting(ctx, projectID, kubeconfigID, &data, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	body := tkcore.DownloadKubeConfigCommand{}
+	body.SetProjectId(projectID)
+	body.SetId(kubeconfigID)
+	content, res, err := e.apiClient.Client.KubeConfigAPI.KubeconfigDownload(ctx).DownloadKubeConfigCommand(body).Execute()
+	if err != nil {
+		resp.Diagnostics.Append(utils.FrameworkDiagnostics(utils.DiagnosticsFromApiError(res, err))...)
+		return
+	}
+	data.Content = types.StringValue(content)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// Create a kubeconfig for the run and remember it in the private data, so that Close deletes it
+func (e *ephemeralTaikunKubeconfig) create(ctx context.Context, projectID int32, data *ephemeralTaikunKubeconfigModel, resp *ephemeral.OpenResponse) int32 {
+	if data.AccessScope.IsNull() {
+		data.AccessScope = types.StringValue("personal")
+	}
+	if data.Name.IsNull() {
+		data.Name = types.StringValue(fmt.Sprintf("terraform-%d", time.Now().Unix()))
+	}
+	if data.ValidityPeriod.IsNull() {
+		data.ValidityPeriod = types.Int64Value(ephemeralKubeconfigDefaultValidityPeriod)
+	}
+
+	body := tkcore.CreateKubeConfigCommand{}
+	body.SetIsAccessibleForAll(data.AccessScope.ValueString() == "all")
+	body.SetIsAccessibleForManager(data.AccessScope.ValueString() == "managers")
+	body.SetKubeConfigRoleId(utils.GetKubeconfigRoleID(data.Role.ValueString()))
+	body.SetName(data.Name.ValueString())
+	body.SetTtl(int32(data.ValidityPeriod.ValueInt64()))
+	body.SetProjectId(projectID)
+	if !data.Namespace.IsNull() {
+		body.SetNamespace(data.Namespace.ValueString())
+	}
+
+	unlock, err := utils.LockProject(ctx, e.apiClient, projectID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to lock project", err.Error())
+		return 0
+	}
+	defer unlock()
+
+	response, res, err := e.apiClient.Client.KubeConfigAPI.KubeconfigCreate(ctx).CreateKubeConfigCommand(body).Execute()
+	if err != nil {
+		resp.Diagnostics.Append(utils.FrameworkDiagnostics(utils.DiagnosticsFromApiError(res, err))...)
+		return 0
+	}
+	kubeconfigID, err := utils.Atoi32(response.GetId())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid kubeconfig ID returned by Taikun", err.Error())
+		return 0
+	}
+	data.ID = types.StringValue(response.GetId())
+	if data.Namespace.IsNull() {
+		data.Namespace = types.StringValue("")
+	}
+
+	private, err := json.Marshal(ephemeralTaikunKubeconfigPrivate{ID: kubeconfigID, ProjectID: projectID})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to store the created kubeconfig", err.Error())
+		return 0
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, ephemeralKubeconfigPrivateKey, private)...)
+
+	return kubeconfigID
+}
+
+func (e *ephemeralTaikunKubeconfig) readExisting(ctx context.Context, projectID int32, kubeconfigID int32, data *ephemeralTaikunKubeconfigModel, resp *ephemeral.OpenResponse) {
+	response, res, err := e.apiClient.Client.KubeConfigAPI.KubeconfigList(ctx).Id(kubeconfigID).ProjectId(projectID).Execute()
+	if err != nil {
+		resp.Diagnostics.Append(utils.FrameworkDiagnostics(utils.DiagnosticsFromApiError(res, err))...)
+		return
+	}
+	if len(response.Data) != 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("id"), "Kubeconfig not found", fmt.Sprintf("Kubeconfig %d was not found in project %d.", kubeconfigID, projectID))
+		return
+	}
+
+	kubeconfigMap := flattenTaikunKubeconfig(&response.Data[0], "")
+	data.AccessScope = types.StringValue(kubeconfigMap["access_scope"].(string))
+	data.Name = types.StringValue(kubeconfigMap["name"].(string))
+	data.Namespace = types.StringValue(kubeconfigMap["namespace"].(string))
+}
+
+func (e *ephemeralTaikunKubeconfig) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	private, diags := req.Private.GetKey(ctx, ephemeralKubeconfigPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || private == nil {
+		return // The kubeconfig was not created by Open
+	}
+
+	var created ephemeralTaikunKubeconfigPrivate
+	if err := json.Unmarshal(private, &created); err != nil {
+		resp.Diagnostics.AddError("Unable to read the created kubeconfig", err.Error())
+		return
+	}
+
+	unlock, err := utils.LockProject(ctx, e.apiClient, created.ProjectID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to lock project", err.Error())
+		return
+	}
+	defer unlock()
+
+	body := tkcore.DeleteKubeConfigCommand{}
+	body.SetId(created.ID)
+	res, err := e.apiClient.Client.KubeConfigAPI.KubeconfigDelete(ctx).DeleteKubeConfigCommand(body).Execute()
+	if err != nil && !utils.IsNotFound(res) {
+		resp.Diagnostics.Append(utils.FrameworkDiagnostics(utils.DiagnosticsFromApiError(res, err))...)
+	}
+}
@@ -128,9 +128,15 @@ func resourceTaikunKubeconfigCreate(ctx context.Context, d *schema.ResourceData,
 	}
 	body.SetProjectId(projectID)
 
+	unlock, err := utils.LockProject(ctx, meta, projectID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
 	response, res, err := apiClient.Client.KubeConfigAPI.KubeconfigCreate(ctx).CreateKubeConfigCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 	d.SetId(response.GetId())
 
@@ -159,15 +165,11 @@ func generateResourceTaikunKubeconfigRead(withRetries bool) schema.ReadContextFu
 
 		response, res, err := apiClient.Client.KubeConfigAPI.KubeconfigList(ctx).Id(id32).ProjectId(projectID).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 
 		if len(response.Data) != 1 {
-			if withRetries {
-				d.SetId(id)
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-			}
-			return nil
+			return utils.ReadNotFound(d, id, withRetries)
 		}
 
 		kubeconfigDTO := response.Data[0]
@@ -194,13 +196,23 @@ func resourceTaikunKubeconfigDelete(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	projectID, err := utils.Atoi32(d.Get("project_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	unlock, err := utils.LockProject(ctx, meta, projectID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
 	body := tkcore.DeleteKubeConfigCommand{}
 	body.SetId(id)
 
 	res, err := apiClient.Client.KubeConfigAPI.KubeconfigDelete(ctx).DeleteKubeConfigCommand(body).Execute()
 
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	d.SetId("")
@@ -0,0 +1,129 @@
+package testing
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils_testing"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils_testing/faketaikun"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// The echo provider copies the ephemeral kubeconfig into its resource, so that it can be checked
+const testUnitEphemeralTaikunKubeconfigConfig = `
+ephemeral "taikun_kubeconfig" "foo" {
+  project_id = "%d"
+  %s
+}
+
+provider "echo" {
+  data = ephemeral.taikun_kubeconfig.foo
+}
+
+resource "echo" "%s" {}
+`
+
+var testUnitEphemeralTaikunKubeconfigEchoProvider = map[string]func() (tfprotov6.ProviderServer, error){
+	"echo": echoprovider.NewProviderServer(),
+}
+
+// Only the echo resource is in the state, the ephemeral kubeconfig is not
+func testUnitCheckEphemeralTaikunKubeconfigNotInState(echoResource string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		for name := range state.RootModule().Resources {
+			if name != echoResource {
+				return fmt.Errorf("expected only %s in the state, found %s", echoResource, name)
+			}
+		}
+		return nil
+	}
+}
+
+// The kubeconfigs created for the plan and the apply are deleted once the run is over, the existing kubeconfig is kept
+func testUnitCheckEphemeralTaikunKubeconfigCount(server *faketaikun.Server, projectID int32, expected int) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if count := server.CountKubeconfigs(projectID); count != expected {
+			return fmt.Errorf("expected %d kubeconfigs left in project %d, got %d", expected, projectID, count)
+		}
+		return nil
+	}
+}
+
+// TestUnitEphemeralTaikunKubeconfig runs against the fake Taikun server.
+// A kubeconfig created for the run is deleted when the ephemeral resource is closed, an existing kubeconfig is only fetched.
+func TestUnitEphemeralTaikunKubeconfig(t *testing.T) {
+	server := faketaikun.Start(t)
+	projectID := server.AddProject("kubeconfigs")
+	existingID := server.AddKubeconfig(projectID, "existing")
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV5ProviderFactories: utils_testing.TestAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testUnitEphemeralTaikunKubeconfigEchoProvider,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testUnitEphemeralTaikunKubeconfigConfig, projectID, `role = "view"`, "created"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.created", tfjsonpath.New("data").AtMapKey("content"), knownvalue.StringRegexp(regexp.MustCompile(`(?s)kind: Config.*token: fake-kubeconfig-token-\d+`))),
+					statecheck.ExpectKnownValue("echo.created", tfjsonpath.New("data").AtMapKey("name"), knownvalue.StringRegexp(regexp.MustCompile(`^terraform-\d+$`))),
+					statecheck.ExpectKnownValue("echo.created", tfjsonpath.New("data").AtMapKey("access_scope"), knownvalue.StringExact("personal")),
+					statecheck.ExpectKnownValue("echo.created", tfjsonpath.New("data").AtMapKey("namespace"), knownvalue.StringExact("")),
+					statecheck.ExpectKnownValue("echo.created", tfjsonpath.New("data").AtMapKey("role"), knownvalue.StringExact("view")),
+					statecheck.ExpectKnownValue("echo.created", tfjsonpath.New("data").AtMapKey("validity_period"), knownvalue.Int64Exact(60)),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testUnitCheckEphemeralTaikunKubeconfigNotInState("echo.created"),
+					testUnitCheckEphemeralTaikunKubeconfigCount(server, projectID, 1),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testUnitEphemeralTaikunKubeconfigConfig, projectID, fmt.Sprintf(`id = "%d"`, existingID), "existing"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.existing", tfjsonpath.New("data").AtMapKey("content"), knownvalue.StringExact(faketaikun.KubeconfigContent(existingID))),
+					statecheck.ExpectKnownValue("echo.existing", tfjsonpath.New("data").AtMapKey("id"), knownvalue.StringExact(fmt.Sprint(existingID))),
+					statecheck.ExpectKnownValue("echo.existing", tfjsonpath.New("data").AtMapKey("name"), knownvalue.StringExact("existing")),
+					statecheck.ExpectKnownValue("echo.existing", tfjsonpath.New("data").AtMapKey("access_scope"), knownvalue.StringExact("personal")),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testUnitCheckEphemeralTaikunKubeconfigNotInState("echo.existing"),
+					testUnitCheckEphemeralTaikunKubeconfigCount(server, projectID, 1),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitEphemeralTaikunKubeconfigErrors verifies that a missing role and a kubeconfig which does not exist are reported
+func TestUnitEphemeralTaikunKubeconfigErrors(t *testing.T) {
+	server := faketaikun.Start(t)
+	projectID := server.AddProject("kubeconfigs")
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV5ProviderFactories: utils_testing.TestAccProtoV5ProviderFactories,
+		ProtoV6ProviderFactories: testUnitEphemeralTaikunKubeconfigEchoProvider,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testUnitEphemeralTaikunKubeconfigConfig, projectID, "", "foo"),
+				ExpectError: regexp.MustCompile(`Missing role`),
+			},
+			{
+				Config:      fmt.Sprintf(testUnitEphemeralTaikunKubeconfigConfig, projectID, `id = "999"`, "foo"),
+				ExpectError: regexp.MustCompile(fmt.Sprintf(`Kubeconfig 999 was not found in project %d`, projectID)),
+			},
+		},
+	})
+}
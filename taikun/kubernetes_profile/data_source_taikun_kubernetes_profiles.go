@@ -54,7 +54,7 @@ func dataSourceTaikunKubernetesProfilesRead(ctx context.Context, d *schema.Resou
 	for {
 		response, res, err := params.Offset(offset).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		kubernetesProfilesListDtos = append(kubernetesProfilesListDtos, response.GetData()...)
 		if len(kubernetesProfilesListDtos) == int(response.GetTotalCount()) {
@@ -125,6 +125,7 @@ func ResourceTaikunKubernetesProfile() *schema.Resource {
 		UpdateContext: resourceTaikunKubernetesProfileUpdate,
 		DeleteContext: resourceTaikunKubernetesProfileDelete,
 		Schema:        ResourceTaikunKubernetesProfileSchema(),
+		CustomizeDiff: utils.SetDefaultOrganizationID,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -149,7 +150,7 @@ func resourceTaikunKubernetesProfileCreate(ctx context.Context, d *schema.Resour
 	if err == nil {
 		body.SetProxmoxStorage(*proxmoxStorage) // User input is not empty and valid.
 	} else if d.Get("proxmox_storage").(string) != "" {
-		return diag.FromErr(err) // User input is not empty and not valid.
+		return utils.DiagnosticsFromError(err) // User input is not empty and not valid.
 	}
 
 	organizationIDData, organizationIDIsSet := d.GetOk("organization_id")
@@ -163,18 +164,18 @@ func resourceTaikunKubernetesProfileCreate(ctx context.Context, d *schema.Resour
 
 	createResult, res, err := apiClient.Client.KubernetesProfilesAPI.KubernetesprofilesCreate(ctx).CreateKubernetesProfileCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 	id, err := utils.Atoi32(createResult.GetId())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	d.SetId(createResult.GetId())
 
 	if d.Get("lock").(bool) {
 		if err := resourceTaikunKubernetesProfileLock(ctx, id, true, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -192,26 +193,22 @@ func generateResourceTaikunKubernetesProfileRead(withRetries bool) schema.ReadCo
 		id, err := utils.Atoi32(d.Id())
 		d.SetId("")
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		response, res, err := apiClient.Client.KubernetesProfilesAPI.KubernetesprofilesList(ctx).Id(id).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		if len(response.GetData()) != 1 {
-			if withRetries {
-				d.SetId(utils.I32toa(id))
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-			}
-			return nil
+			return utils.ReadNotFound(d, utils.I32toa(id), withRetries)
 		}
 
 		rawKubernetesProfile := response.GetData()[0]
 
 		err = utils.SetResourceDataFromMap(d, FlattenTaikunKubernetesProfile(&rawKubernetesProfile))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		d.SetId(utils.I32toa(id))
@@ -225,12 +222,12 @@ func resourceTaikunKubernetesProfileUpdate(ctx context.Context, d *schema.Resour
 
 	id, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	if d.HasChange("lock") {
 		if err := resourceTaikunKubernetesProfileLock(ctx, id, d.Get("lock").(bool), apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -241,12 +238,12 @@ func resourceTaikunKubernetesProfileDelete(ctx context.Context, d *schema.Resour
 	apiClient := meta.(*tk.Client)
 	id, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	res, err := apiClient.Client.KubernetesProfilesAPI.KubernetesprofilesDelete(ctx, id).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	d.SetId("")
@@ -281,5 +278,5 @@ func resourceTaikunKubernetesProfileLock(ctx context.Context, id int32, lock boo
 	body.SetMode(utils.GetLockMode(lock))
 
 	res, err := apiClient.Client.KubernetesProfilesAPI.KubernetesprofilesLockManager(ctx).KubernetesProfilesLockManagerCommand(body).Execute()
-	return tk.CreateError(res, err)
+	return utils.NewApiError(res, err)
 }
@@ -0,0 +1,28 @@
+//go:build sweep
+
+package kubernetes_profile
+
+import (
+	"context"
+
+	tk "github.com/itera-io/taikungoclient"
+	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+)
+
+// Delete the Kubernetes profiles left behind by the acceptance tests, once the projects using them are deleted
+func AddTestSweepers(client utils.SweeperClientFunc) {
+	utils.AddListTestSweeper("taikun_kubernetes_profile", client, []string{"taikun_project"},
+		func(ctx context.Context, apiClient *tk.Client, offset int32) ([]tkcore.KubernetesProfilesListDto, int, error) {
+			response, res, err := apiClient.Client.KubernetesProfilesAPI.KubernetesprofilesList(ctx).Offset(offset).Execute()
+			if err != nil {
+				return nil, 0, utils.NewApiError(res, err)
+			}
+			return response.GetData(), int(response.GetTotalCount()), nil
+		},
+		(*tkcore.KubernetesProfilesListDto).GetName,
+		func(ctx context.Context, apiClient *tk.Client, kubernetesProfile *tkcore.KubernetesProfilesListDto) error {
+			return utils.SweepLockableResource(ctx, ResourceTaikunKubernetesProfile(), kubernetesProfile.GetId(), kubernetesProfile.GetIsLocked(), resourceTaikunKubernetesProfileLock, apiClient)
+		},
+	)
+}
@@ -0,0 +1,56 @@
+package testing
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils_testing"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils_testing/faketaikun"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestUnitResourceTaikunKubernetesProfile runs against the fake Taikun server.
+// Every attribute but the lock replaces the profile, the lock is toggled in place.
+func TestUnitResourceTaikunKubernetesProfile(t *testing.T) {
+	faketaikun.Start(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: utils_testing.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckTaikunKubernetesProfileDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccResourceTaikunKubernetesProfileConfig, "profile", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunKubernetesProfileExists,
+					resource.TestCheckResourceAttr("taikun_kubernetes_profile.foo", "name", "profile"),
+					resource.TestCheckResourceAttr("taikun_kubernetes_profile.foo", "lock", "false"),
+					resource.TestCheckResourceAttr("taikun_kubernetes_profile.foo", "load_balancing_solution", "Octavia"),
+					resource.TestCheckResourceAttr("taikun_kubernetes_profile.foo", "schedule_on_master", "false"),
+					resource.TestCheckResourceAttr("taikun_kubernetes_profile.foo", "organization_id", fmt.Sprint(faketaikun.DefaultOrganizationID)),
+					resource.TestCheckResourceAttr("taikun_kubernetes_profile.foo", "organization_name", faketaikun.DefaultOrganizationName),
+					resource.TestCheckResourceAttr("taikun_kubernetes_profile.foo", "proxmox_storage", "NFS"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccResourceTaikunKubernetesProfileConfig, "profile", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunKubernetesProfileExists,
+					resource.TestCheckResourceAttr("taikun_kubernetes_profile.foo", "lock", "true"),
+				),
+			},
+			{
+				ResourceName:      "taikun_kubernetes_profile.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: fmt.Sprintf(testAccResourceTaikunKubernetesProfileConfig, "profile", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunKubernetesProfileExists,
+					resource.TestCheckResourceAttr("taikun_kubernetes_profile.foo", "lock", "false"),
+				),
+			},
+		},
+	})
+}
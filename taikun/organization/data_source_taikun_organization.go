@@ -44,7 +44,7 @@ func dataSourceTaikunOrganizationRead(ctx context.Context, d *schema.ResourceDat
 
 	response, res, err := params.Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 	if len(response.Data) != 1 {
 		return diag.Errorf("organization not found")
@@ -5,6 +5,7 @@ import (
 
 	tk "github.com/itera-io/taikungoclient"
 	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -38,7 +39,7 @@ func dataSourceTaikunOrganizationsRead(ctx context.Context, d *schema.ResourceDa
 	for {
 		response, res, err := params.Offset(offset).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		rawOrganizationsList = append(rawOrganizationsList, response.Data...)
 		if len(rawOrganizationsList) == int(response.GetTotalCount()) {
@@ -93,7 +93,7 @@ func resourceTaikunOrganizationCreate(ctx context.Context, d *schema.ResourceDat
 
 	createResult, res, err := apiClient.Client.OrganizationsAPI.OrganizationsCreate(ctx).OrganizationCreateCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	d.SetId(createResult.GetId())
@@ -116,14 +116,10 @@ func generateResourceTaikunOrganizationRead(withRetries bool) schema.ReadContext
 		response, res, err := apiClient.Client.OrganizationsAPI.OrganizationsList(ctx).Id(id32).Execute()
 
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		if len(response.Data) != 1 {
-			if withRetries {
-				d.SetId(id)
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-			}
-			return nil
+			return utils.ReadNotFound(d, id, withRetries)
 		}
 
 		rawOrganization := response.Data[0]
@@ -154,7 +150,7 @@ func resourceTaikunOrganizationUpdate(ctx context.Context, d *schema.ResourceDat
 
 	res, err := apiClient.Client.OrganizationsAPI.OrganizationsUpdate(ctx).UpdateOrganizationCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	return utils.ReadAfterUpdateWithRetries(generateResourceTaikunOrganizationReadWithRetries(), ctx, d, meta)
@@ -169,7 +165,7 @@ func resourceTaikunOrganizationDelete(ctx context.Context, d *schema.ResourceDat
 
 	res, err := apiClient.Client.OrganizationsAPI.OrganizationsDelete(ctx, id).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	d.SetId("")
@@ -79,7 +79,7 @@ func resourceTaikunOrganizationBillingRuleAttachmentCreate(ctx context.Context,
 
 	response, err := apiClient.Client.OrganizationsAPI.OrganizationsAddPrometheusrules(ctx, organizationId).AddPrometheusRulesToOrganizationDto(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(response, err))
+		return utils.DiagnosticsFromApiError(response, err)
 	}
 
 	id := fmt.Sprintf("%d/%d", organizationId, billingRuleId)
@@ -107,14 +107,10 @@ func generateResourceTaikunOrganizationBillingRuleAttachmentRead(withRetries boo
 		response, res, err := apiClient.Client.PrometheusRulesAPI.PrometheusrulesList(ctx).Id(billingRuleId).Execute()
 
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		if len(response.Data) != 1 {
-			if withRetries {
-				d.SetId(id)
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-			}
-			return nil
+			return utils.ReadNotFound(d, id, withRetries)
 		}
 
 		rawBillingRule := response.Data[0]
@@ -138,11 +134,7 @@ func generateResourceTaikunOrganizationBillingRuleAttachmentRead(withRetries boo
 			}
 		}
 
-		if withRetries {
-			d.SetId(id)
-			return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-		}
-		return nil
+		return utils.ReadNotFound(d, id, withRetries)
 	}
 }
 
@@ -157,7 +149,7 @@ func resourceTaikunOrganizationBillingRuleAttachmentDelete(ctx context.Context,
 	organizationsListResponse, res, err := apiClient.Client.OrganizationsAPI.OrganizationsList(ctx).Id(organizationId).Execute()
 
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 	if len(organizationsListResponse.Data) != 1 {
 		d.SetId("")
@@ -166,7 +158,7 @@ func resourceTaikunOrganizationBillingRuleAttachmentDelete(ctx context.Context,
 
 	billingRulesListResponse, res, err := apiClient.Client.PrometheusRulesAPI.PrometheusrulesList(ctx).Id(billingRuleId).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 	if len(billingRulesListResponse.Data) != 1 {
 		d.SetId("")
@@ -176,7 +168,7 @@ func resourceTaikunOrganizationBillingRuleAttachmentDelete(ctx context.Context,
 	body := []int32{billingRuleId}
 	response, err := apiClient.Client.OrganizationsAPI.OrganizationsDeletePrometheusrules(ctx, organizationId).RequestBody(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(response, err))
+		return utils.DiagnosticsFromApiError(response, err)
 	}
 
 	d.SetId("")
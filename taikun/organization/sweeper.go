@@ -0,0 +1,47 @@
+//go:build sweep
+
+package organization
+
+import (
+	"context"
+
+	tk "github.com/itera-io/taikungoclient"
+	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+)
+
+// Delete the organizations left behind by the acceptance tests, once everything they contain is deleted
+func AddTestSweepers(client utils.SweeperClientFunc) {
+	dependencies := []string{
+		"taikun_access_profile",
+		"taikun_alerting_profile",
+		"taikun_backup_credential",
+		"taikun_billing_credential",
+		"taikun_cloud_credential_aws",
+		"taikun_cloud_credential_azure",
+		"taikun_cloud_credential_gcp",
+		"taikun_cloud_credential_openstack",
+		"taikun_cloud_credential_proxmox",
+		"taikun_cloud_credential_vsphere",
+		"taikun_cloud_credential_zadara",
+		"taikun_kubernetes_profile",
+		"taikun_policy_profile",
+		"taikun_project",
+		"taikun_showback_credential",
+		"taikun_standalone_profile",
+		"taikun_user",
+	}
+	utils.AddListTestSweeper("taikun_organization", client, dependencies,
+		func(ctx context.Context, apiClient *tk.Client, offset int32) ([]tkcore.OrganizationDetailsDto, int, error) {
+			response, res, err := apiClient.Client.OrganizationsAPI.OrganizationsList(ctx).Offset(offset).Execute()
+			if err != nil {
+				return nil, 0, utils.NewApiError(res, err)
+			}
+			return response.GetData(), int(response.GetTotalCount()), nil
+		},
+		(*tkcore.OrganizationDetailsDto).GetName,
+		func(ctx context.Context, apiClient *tk.Client, organization *tkcore.OrganizationDetailsDto) error {
+			return utils.SweepResource(ctx, ResourceTaikunOrganization(), utils.I32toa(organization.GetId()), nil, apiClient)
+		},
+	)
+}
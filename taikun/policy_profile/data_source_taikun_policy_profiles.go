@@ -54,7 +54,7 @@ func dataSourceTaikunPolicyProfilesRead(ctx context.Context, d *schema.ResourceD
 	for {
 		response, res, err := params.Offset(offset).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		opaProfilesListDtos = append(opaProfilesListDtos, response.GetData()...)
 		if len(opaProfilesListDtos) == int(response.GetTotalCount()) {
@@ -136,6 +136,7 @@ func ResourceTaikunPolicyProfile() *schema.Resource {
 		UpdateContext: resourceTaikunPolicyProfileUpdate,
 		DeleteContext: resourceTaikunPolicyProfileDelete,
 		Schema:        resourceTaikunPolicyProfileSchema(),
+		CustomizeDiff: utils.SetDefaultOrganizationID,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -167,7 +168,7 @@ func resourceTaikunPolicyProfileCreate(ctx context.Context, d *schema.ResourceDa
 
 	createResult, res, err := apiClient.Client.OpaProfilesAPI.OpaprofilesCreate(ctx).CreateOpaProfileCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	d.SetId(createResult.GetId())
@@ -176,11 +177,11 @@ func resourceTaikunPolicyProfileCreate(ctx context.Context, d *schema.ResourceDa
 	if locked {
 		id, err := utils.Atoi32(createResult.GetId())
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 		err = resourceTaikunPolicyProfileLock(ctx, id, true, apiClient)
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -199,24 +200,20 @@ func generateResourceTaikunPolicyProfileRead(isAfterUpdateOrCreate bool) schema.
 		id, err := utils.Atoi32(d.Id())
 		d.SetId("")
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		rawPolicyProfile, err := ResourceTaikunPolicyProfileFind(ctx, id, apiClient)
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 		if rawPolicyProfile == nil {
-			if isAfterUpdateOrCreate {
-				d.SetId(utils.I32toa(id))
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-			}
-			return nil
+			return utils.ReadNotFound(d, utils.I32toa(id), isAfterUpdateOrCreate)
 		}
 
 		err = utils.SetResourceDataFromMap(d, flattenTaikunPolicyProfile(rawPolicyProfile))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		d.SetId(utils.I32toa(id))
@@ -230,13 +227,13 @@ func resourceTaikunPolicyProfileUpdate(ctx context.Context, d *schema.ResourceDa
 
 	id, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	if locked, _ := d.GetChange("lock"); locked.(bool) {
 		err := resourceTaikunPolicyProfileLock(ctx, id, false, apiClient)
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -256,7 +253,7 @@ func resourceTaikunPolicyProfileUpdate(ctx context.Context, d *schema.ResourceDa
 
 		res, err := apiClient.Client.OpaProfilesAPI.OpaprofilesUpdate(ctx).OpaProfileUpdateCommand(body).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 
 	}
@@ -264,7 +261,7 @@ func resourceTaikunPolicyProfileUpdate(ctx context.Context, d *schema.ResourceDa
 	if d.Get("lock").(bool) {
 		err := resourceTaikunPolicyProfileLock(ctx, id, true, apiClient)
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -275,12 +272,12 @@ func resourceTaikunPolicyProfileDelete(ctx context.Context, d *schema.ResourceDa
 	apiClient := meta.(*tk.Client)
 	id, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	res, err := apiClient.Client.OpaProfilesAPI.OpaprofilesDelete(ctx, id).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	d.SetId("")
@@ -294,7 +291,7 @@ func resourceTaikunPolicyProfileLock(ctx context.Context, id int32, lock bool, a
 
 	res, err := apiClient.Client.OpaProfilesAPI.OpaprofilesLockManager(ctx).OpaProfileLockManagerCommand(lockBody).Execute()
 
-	return tk.CreateError(res, err)
+	return utils.NewApiError(res, err)
 }
 
 func flattenTaikunPolicyProfile(rawPolicyProfile *tkcore.OpaProfileListDto) map[string]interface{} {
@@ -324,7 +321,7 @@ func ResourceTaikunPolicyProfileFind(ctx context.Context, id int32, apiClient *t
 	for {
 		response, res, err := params.Offset(offset).Execute()
 		if err != nil {
-			return nil, tk.CreateError(res, err)
+			return nil, utils.NewApiError(res, err)
 		}
 
 		for _, policyProfile := range response.GetData() {
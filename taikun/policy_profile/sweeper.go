@@ -0,0 +1,28 @@
+//go:build sweep
+
+package policy_profile
+
+import (
+	"context"
+
+	tk "github.com/itera-io/taikungoclient"
+	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+)
+
+// Delete the policy profiles left behind by the acceptance tests, once the projects using them are deleted
+func AddTestSweepers(client utils.SweeperClientFunc) {
+	utils.AddListTestSweeper("taikun_policy_profile", client, []string{"taikun_project"},
+		func(ctx context.Context, apiClient *tk.Client, offset int32) ([]tkcore.OpaProfileListDto, int, error) {
+			response, res, err := apiClient.Client.OpaProfilesAPI.OpaprofilesList(ctx).Offset(offset).Execute()
+			if err != nil {
+				return nil, 0, utils.NewApiError(res, err)
+			}
+			return response.GetData(), int(response.GetTotalCount()), nil
+		},
+		(*tkcore.OpaProfileListDto).GetName,
+		func(ctx context.Context, apiClient *tk.Client, policyProfile *tkcore.OpaProfileListDto) error {
+			return utils.SweepLockableResource(ctx, ResourceTaikunPolicyProfile(), policyProfile.GetId(), policyProfile.GetIsLocked(), resourceTaikunPolicyProfileLock, apiClient)
+		},
+	)
+}
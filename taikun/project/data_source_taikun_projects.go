@@ -49,19 +49,19 @@ func dataSourceTaikunProjectsRead(ctx context.Context, d *schema.ResourceData, m
 
 	response, res, err := params.Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	projects := make([]map[string]interface{}, len(response.GetData()))
 	for i, projectEntityDTO := range response.GetData() {
 		response, res, err := apiClient.Client.ServersAPI.ServersDetails(ctx, projectEntityDTO.GetId()).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 
 		responseVM, res, err := apiClient.Client.StandaloneAPI.StandaloneDetails(ctx, projectEntityDTO.GetId()).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 
 		boundFlavorDTOs, err := resourceTaikunProjectGetBoundFlavorDTOs(ctx, projectEntityDTO.GetId(), apiClient)
@@ -77,7 +77,7 @@ func dataSourceTaikunProjectsRead(ctx context.Context, d *schema.ResourceData, m
 		project := response.GetProject()
 		quotaResponse, res, err := apiClient.Client.ProjectQuotasAPI.ProjectquotasList(ctx).Id(project.GetId()).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		if len(quotaResponse.GetData()) != 1 {
 			return nil
@@ -18,6 +18,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// Attributes identifying the servers of each set
+var (
+	serverBastionHashAttributes    = []string{"name", "disk_size", "flavor", "spot_server", "hypervisor"}
+	serverKubemasterHashAttributes = []string{"name", "disk_size", "flavor", "kubernetes_node_label", "spot_server", "wasm", "hypervisor"}
+	serverKubeworkerHashAttributes = []string{"name", "disk_size", "flavor", "kubernetes_node_label", "spot_server", "wasm", "hypervisor", "proxmox_extra_disk_size"}
+)
+
 func resourceTaikunProjectSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"access_ip": {
@@ -214,7 +221,7 @@ func resourceTaikunProjectSchema() map[string]*schema.Schema {
 			MaxItems:     1,
 			Optional:     true,
 			RequiredWith: []string{"server_kubemaster", "server_kubeworker"},
-			Set:          utils.HashAttributes("name", "disk_size", "flavor", "spot_server", "hypervisor"),
+			Set:          utils.HashAttributes(serverBastionHashAttributes...),
 			Elem: &schema.Resource{
 				Schema: taikunServerBasicSchema(),
 			},
@@ -224,7 +231,7 @@ func resourceTaikunProjectSchema() map[string]*schema.Schema {
 			Type:         schema.TypeSet,
 			Optional:     true,
 			RequiredWith: []string{"server_bastion", "server_kubeworker"},
-			Set:          utils.HashAttributes("name", "disk_size", "flavor", "kubernetes_node_label", "spot_server", "wasm", "hypervisor"),
+			Set:          utils.HashAttributes(serverKubemasterHashAttributes...),
 			Elem: &schema.Resource{
 				Schema: taikunServerSchemaWithKubernetesNodeLabels(),
 			},
@@ -234,7 +241,7 @@ func resourceTaikunProjectSchema() map[string]*schema.Schema {
 			Type:         schema.TypeSet,
 			Optional:     true,
 			RequiredWith: []string{"server_bastion", "server_kubemaster"},
-			Set:          utils.HashAttributes("name", "disk_size", "flavor", "kubernetes_node_label", "spot_server", "wasm", "hypervisor", "proxmox_extra_disk_size"),
+			Set:          utils.HashAttributes(serverKubeworkerHashAttributes...),
 			Elem: &schema.Resource{
 				Schema: taikunServerKubeworkerSchema(),
 			},
@@ -330,12 +337,14 @@ func resourceTaikunProjectSchema() map[string]*schema.Schema {
 
 func ResourceTaikunProject() *schema.Resource {
 	return &schema.Resource{
-		Description:   "Taikun Project",
-		CreateContext: resourceTaikunProjectCreate,
-		ReadContext:   generateResourceTaikunProjectReadWithoutRetries(),
-		UpdateContext: resourceTaikunProjectUpdate,
-		DeleteContext: resourceTaikunProjectDelete,
-		Schema:        resourceTaikunProjectSchema(),
+		Description:    "Taikun Project",
+		CreateContext:  resourceTaikunProjectCreate,
+		ReadContext:    generateResourceTaikunProjectReadWithoutRetries(),
+		UpdateContext:  resourceTaikunProjectUpdate,
+		DeleteContext:  resourceTaikunProjectDelete,
+		Schema:         resourceTaikunProjectSchema(),
+		SchemaVersion:  1,
+		StateUpgraders: resourceTaikunProjectStateUpgraders(),
 		CustomizeDiff: customdiff.All(
 			customdiff.ValidateValue(
 				"server_kubemaster",
@@ -433,7 +442,7 @@ func resourceTaikunProjectCreate(ctx context.Context, d *schema.ResourceData, me
 		body.SetRouterIdEndRange(int32(d.Get("router_id_end_range").(int)))
 	}
 	if err := resourceTaikunProjectValidateKubernetesProfileLB(ctx, d, apiClient); err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	if accessProfileID, accessProfileIDIsSet := d.GetOk("access_profile_id"); accessProfileIDIsSet {
@@ -513,22 +522,28 @@ func resourceTaikunProjectCreate(ctx context.Context, d *schema.ResourceData, me
 	// Send project creation request
 	response, responseBody, err := apiClient.Client.ProjectsAPI.ProjectsCreate(ctx).CreateProjectCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(responseBody, err))
+		return utils.DiagnosticsFromApiError(responseBody, err)
 	}
 
 	d.SetId(response.GetId())
 	projectID, _ := utils.Atoi32(response.GetId())
 
+	unlock, err := utils.LockProject(ctx, meta, projectID)
+	if err != nil {
+		return utils.DiagnosticsFromError(err)
+	}
+	defer unlock()
+
 	if resourceTaikunProjectQuotaIsSet(d) {
 		if err = resourceTaikunProjectEditQuotas(ctx, d, apiClient, projectID); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
 	if _, imagesIsSet := d.GetOk("images"); imagesIsSet {
 		err := resourceTaikunProjectEditImages(ctx, d, apiClient, projectID)
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -536,36 +551,36 @@ func resourceTaikunProjectCreate(ctx context.Context, d *schema.ResourceData, me
 	if _, bastionsIsSet := d.GetOk("server_bastion"); bastionsIsSet {
 
 		if err := resourceTaikunProjectSetServers(ctx, d, apiClient, projectID); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		if err := resourceTaikunProjectCommit(ctx, apiClient, projectID); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		if err := resourceTaikunProjectWaitForStatus(ctx, []string{"Ready"}, []string{"Updating", "Pending"}, apiClient, projectID); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
 	if _, vmIsSet := d.GetOk("vm"); vmIsSet {
 
 		if err := resourceTaikunProjectSetVMs(ctx, d, apiClient, projectID); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		if err := resourceTaikunProjectStandaloneCommit(ctx, apiClient, projectID); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		if err := resourceTaikunProjectWaitForStatus(ctx, []string{"Ready"}, []string{"Updating", "Pending"}, apiClient, projectID); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
 	if d.Get("lock").(bool) {
 		if err := resourceTaikunProjectLock(ctx, projectID, true, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -584,25 +599,24 @@ func generateResourceTaikunProjectRead(withRetries bool) schema.ReadContextFunc
 		id32, err := utils.Atoi32(id)
 		d.SetId("")
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
-		response, _, err := apiClient.Client.ServersAPI.ServersDetails(ctx, id32).Execute()
+		response, res, err := apiClient.Client.ServersAPI.ServersDetails(ctx, id32).Execute()
 		if err != nil {
+			// Right after create, the details of a project still being provisioned may fail with any error: retry all of them
 			if withRetries {
-				d.SetId(id)
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
+				return utils.ReadNotFound(d, id, withRetries)
 			}
-			return nil
+			return utils.ReadApiError(d, id, withRetries, res, err)
 		}
 
-		responseVM, _, err := apiClient.Client.StandaloneAPI.StandaloneDetails(ctx, id32).Execute()
+		responseVM, res, err := apiClient.Client.StandaloneAPI.StandaloneDetails(ctx, id32).Execute()
 		if err != nil {
 			if withRetries {
-				d.SetId(id)
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
+				return utils.ReadNotFound(d, id, withRetries)
 			}
-			return nil
+			return utils.ReadApiError(d, id, withRetries, res, err)
 		}
 
 		projectDetailsDTO := response.GetProject()
@@ -611,41 +625,37 @@ func generateResourceTaikunProjectRead(withRetries bool) schema.ReadContextFunc
 
 		boundFlavorDTOs, err := resourceTaikunProjectGetBoundFlavorDTOs(ctx, projectDetailsDTO.GetId(), apiClient)
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		var boundImageDTOs []tkcore.BoundImagesForProjectsListDto
 
 		boundImageDTOs, err = resourceTaikunProjectGetBoundImageDTOs(ctx, projectDetailsDTO.GetId(), apiClient)
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		quotaResponse, bodyResponse, err := apiClient.Client.ProjectQuotasAPI.ProjectquotasList(ctx).Id(id32).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(bodyResponse, err))
+			return utils.DiagnosticsFromApiError(bodyResponse, err)
 		}
 		if len(quotaResponse.Data) != 1 {
-			if withRetries {
-				d.SetId(id)
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-			}
-			return nil
+			return utils.ReadNotFound(d, id, withRetries)
 		}
 
 		deleteOnExpiration, err := resourceTaikunProjectGetDeleteOnExpiration(ctx, projectDetailsDTO.GetId(), apiClient)
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		projectMap := flattenTaikunProject(&projectDetailsDTO, serverList, vmList, boundFlavorDTOs, boundImageDTOs, &quotaResponse.Data[0], deleteOnExpiration)
 		usernames := resourceTaikunProjectGetResourceDataVmUsernames(d)
 		if err := utils.SetResourceDataFromMap(d, projectMap); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		if err := resourceTaikunProjectRestoreResourceDataVmUsernames(d, usernames); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		d.SetId(id)
@@ -740,11 +750,17 @@ func resourceTaikunProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 	apiClient := meta.(*tk.Client)
 	id, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
+	unlock, err := utils.LockProject(ctx, meta, id)
+	if err != nil {
+		return utils.DiagnosticsFromError(err)
+	}
+	defer unlock()
+
 	if err = resourceTaikunProjectUnlockIfLocked(ctx, id, apiClient); err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	if d.HasChange("alerting_profile_id") {
@@ -752,14 +768,14 @@ func resourceTaikunProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 		body.SetProjectId(id)
 		bodyResponse, newErr := apiClient.Client.AlertingProfilesAPI.AlertingprofilesDetach(ctx).AttachDetachAlertingProfileCommand(body).Execute()
 		if newErr != nil {
-			return diag.FromErr(tk.CreateError(bodyResponse, newErr))
+			return utils.DiagnosticsFromApiError(bodyResponse, newErr)
 		}
 		if newAlertingProfileIDData, newAlertingProfileIDProvided := d.GetOk("alerting_profile_id"); newAlertingProfileIDProvided {
 			newAlertingProfileID, _ := utils.Atoi32(newAlertingProfileIDData.(string))
 			body.SetAlertingProfileId(newAlertingProfileID)
 			bodyResponse, newErr := apiClient.Client.AlertingProfilesAPI.AlertingprofilesAttach(ctx).AttachDetachAlertingProfileCommand(body).Execute()
 			if newErr != nil {
-				return diag.FromErr(tk.CreateError(bodyResponse, newErr))
+				return utils.DiagnosticsFromApiError(bodyResponse, newErr)
 			}
 		}
 	}
@@ -785,17 +801,17 @@ func resourceTaikunProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 
 		res, err := apiClient.Client.ProjectsAPI.ProjectsExtendLifetime(ctx).ProjectExtendLifeTimeCommand(body).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 	}
 	if d.HasChange("images") {
 		if err = resourceTaikunProjectEditImages(ctx, d, apiClient, id); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 	if d.HasChanges("quota_cpu_units", "quota_disk_size", "quota_ram_size", "quota_vm_cpu_units", "quota_vm_ram_size", "quota_vm_volume_size") {
 		if err = resourceTaikunProjectEditQuotas(ctx, d, apiClient, id); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -807,18 +823,18 @@ func resourceTaikunProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 		if oldSet.Len() == 0 {
 			// The project was empty before
 			if err = resourceTaikunProjectUpdateToggleServices(ctx, d, apiClient); err != nil {
-				return diag.FromErr(err)
+				return utils.DiagnosticsFromError(err)
 			}
 			if err = resourceTaikunProjectSetServers(ctx, d, apiClient, id); err != nil {
-				return diag.FromErr(err)
+				return utils.DiagnosticsFromError(err)
 			}
 
 			if err = resourceTaikunProjectCommit(ctx, apiClient, id); err != nil {
-				return diag.FromErr(err)
+				return utils.DiagnosticsFromError(err)
 			}
 
 			if err = resourceTaikunProjectWaitForStatus(ctx, []string{"Ready"}, []string{"Updating", "Pending"}, apiClient, id); err != nil {
-				return diag.FromErr(err)
+				return utils.DiagnosticsFromError(err)
 			}
 
 		} else if newSet.Len() == 0 {
@@ -828,18 +844,18 @@ func resourceTaikunProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 			serversToPurge := resourceTaikunProjectFlattenServersData(oldBastions, oldKubeMasters, oldKubeWorkers)
 			err = resourceTaikunProjectPurgeServers(ctx, serversToPurge, apiClient, id)
 			if err != nil {
-				return diag.FromErr(err)
+				return utils.DiagnosticsFromError(err)
 			}
 			if err = resourceTaikunProjectWaitForStatus(ctx, []string{"Ready"}, []string{"Updating", "Pending"}, apiClient, id); err != nil {
-				return diag.FromErr(err)
+				return utils.DiagnosticsFromError(err)
 			}
 			if err = resourceTaikunProjectUpdateToggleServices(ctx, d, apiClient); err != nil {
-				return diag.FromErr(err)
+				return utils.DiagnosticsFromError(err)
 			}
 		}
 	} else {
 		if err = resourceTaikunProjectUpdateToggleServices(ctx, d, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 		if d.HasChange("server_kubeworker") {
 			o, n := d.GetChange("server_kubeworker")
@@ -864,11 +880,11 @@ func resourceTaikunProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 
 				res, err := apiClient.Client.ProjectDeploymentAPI.ProjectDeploymentDelete(ctx).ProjectDeploymentDeleteServersCommand(deleteServerBody).Execute()
 				if err != nil {
-					return diag.FromErr(tk.CreateError(res, err))
+					return utils.DiagnosticsFromApiError(res, err)
 				}
 
 				if err = resourceTaikunProjectWaitForStatus(ctx, []string{"Ready"}, []string{"Deleting", "PendingDelete"}, apiClient, id); err != nil {
-					return diag.FromErr(err)
+					return utils.DiagnosticsFromError(err)
 				}
 			}
 			// Create
@@ -912,7 +928,7 @@ func resourceTaikunProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 
 					serverCreateResponse, response, newErr := apiClient.Client.ServersAPI.ServersCreate(ctx).ServerForCreateDto(serverCreateBody).Execute()
 					if newErr != nil {
-						return diag.FromErr(tk.CreateError(response, newErr))
+						return utils.DiagnosticsFromApiError(response, newErr)
 					}
 					kubeWorkerMap["id"] = serverCreateResponse.GetId()
 
@@ -921,15 +937,15 @@ func resourceTaikunProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 
 				err = d.Set("server_kubeworker", kubeWorkersList)
 				if err != nil {
-					return diag.FromErr(err)
+					return utils.DiagnosticsFromError(err)
 				}
 
 				if err = resourceTaikunProjectCommit(ctx, apiClient, id); err != nil {
-					return diag.FromErr(err)
+					return utils.DiagnosticsFromError(err)
 				}
 
 				if err = resourceTaikunProjectWaitForStatus(ctx, []string{"Ready"}, []string{"Updating", "Pending"}, apiClient, id); err != nil {
-					return diag.FromErr(err)
+					return utils.DiagnosticsFromError(err)
 				}
 			}
 		}
@@ -938,7 +954,7 @@ func resourceTaikunProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 	if d.HasChange("vm") {
 		err = resourceTaikunProjectUpdateVMs(ctx, d, apiClient, id)
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -950,7 +966,7 @@ func resourceTaikunProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 	// Vm spots do not collide with anything
 	if spotVmsChange {
 		if err = resourceTaikunProjectToggleVmsSpot(ctx, d, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 	// Full and Worker chanege can collide if there was a change on remote
@@ -959,12 +975,12 @@ func resourceTaikunProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 	}
 	if spotFullChange {
 		if err = resourceTaikunProjectToggleFullSpot(ctx, d, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 	if spotWorkerChange {
 		if err = resourceTaikunProjectToggleWorkerSpot(ctx, d, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -976,12 +992,12 @@ func resourceTaikunProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 		if iWishToDisable {
 			// Disable autoscaler
 			if err := resourceTaikunProjectDisableAutoscaler(ctx, d, apiClient); err != nil {
-				return diag.FromErr(err)
+				return utils.DiagnosticsFromError(err)
 			}
 		} else {
 			// Enable or Recreate autoscaler with changes
 			if err := resourceTaikunProjectRecreateAutoscaler(ctx, d, apiClient); err != nil {
-				return diag.FromErr(err)
+				return utils.DiagnosticsFromError(err)
 			}
 			iJustRecreated = true
 		}
@@ -991,7 +1007,7 @@ func resourceTaikunProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 	// Precedence: medium. Worst case, autoscaler was just recreated
 	if (d.HasChange("autoscaler_min_size") || d.HasChange("autoscaler_max_size")) && !iWishToDisable && !iJustRecreated {
 		if err := resourceTaikunProjectUpdateAutoscaler(ctx, d, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -999,13 +1015,13 @@ func resourceTaikunProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 	// Precedence: low, autoscaler flavors should not interfere
 	if d.HasChange("flavors") {
 		if err = resourceTaikunProjectEditFlavors(ctx, d, apiClient, id); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
 	if d.Get("lock").(bool) {
 		if err := resourceTaikunProjectLock(ctx, id, true, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -1017,11 +1033,17 @@ func resourceTaikunProjectDelete(ctx context.Context, d *schema.ResourceData, me
 
 	id, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
+	}
+
+	unlock, err := utils.LockProject(ctx, meta, id)
+	if err != nil {
+		return utils.DiagnosticsFromError(err)
 	}
+	defer unlock()
 
 	if err = resourceTaikunProjectUnlockIfLocked(ctx, id, apiClient); err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	serversToPurge := resourceTaikunProjectFlattenServersData(
@@ -1033,7 +1055,7 @@ func resourceTaikunProjectDelete(ctx context.Context, d *schema.ResourceData, me
 	// Get all autoscaler servers
 	autoscalerData, res, err := apiClient.Client.ServersAPI.ServersList(ctx).AutoscalingGroup(d.Get("autoscaler_name").(string)).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 	// Add the ids to the list of servers about to be deleted
 	var i int64 = 0
@@ -1044,19 +1066,19 @@ func resourceTaikunProjectDelete(ctx context.Context, d *schema.ResourceData, me
 	if len(serversToPurge) != 0 {
 		err = resourceTaikunProjectPurgeServers(ctx, serversToPurge, apiClient, id)
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 		if err = resourceTaikunProjectWaitForStatus(ctx, []string{"Ready"}, []string{"PendingPurge", "Purging"}, apiClient, id); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 	if vms := d.Get("vm").([]interface{}); len(vms) != 0 {
 		err = resourceTaikunProjectPurgeVMs(ctx, vms, apiClient, id)
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 		if err = resourceTaikunProjectWaitForStatus(ctx, []string{"Ready"}, []string{"PendingPurge", "Purging"}, apiClient, id); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -1066,7 +1088,7 @@ func resourceTaikunProjectDelete(ctx context.Context, d *schema.ResourceData, me
 	body.SetIsForceDelete(false)
 	res, err = apiClient.Client.ProjectsAPI.ProjectsDelete(ctx).DeleteProjectCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	d.SetId("")
@@ -1076,7 +1098,7 @@ func resourceTaikunProjectDelete(ctx context.Context, d *schema.ResourceData, me
 func resourceTaikunProjectUnlockIfLocked(ctx context.Context, projectID int32, apiClient *tk.Client) error {
 	response, res, err := apiClient.Client.ServersAPI.ServersDetails(ctx, projectID).Execute()
 	if err != nil {
-		return tk.CreateError(res, err)
+		return utils.NewApiError(res, err)
 	}
 
 	project := response.GetProject()
@@ -1089,7 +1111,7 @@ func resourceTaikunProjectUnlockIfLocked(ctx context.Context, projectID int32, a
 	return nil
 }
 
-func resourceTaikunProjectEditQuotas(ctx context.Context, d *schema.ResourceData, apiClient *tk.Client, projectID int32) (err error) {
+func resourceTaikunProjectEditQuotas(ctx context.Context, d *schema.ResourceData, apiClient *tk.Client, projectID int32) error {
 
 	body := tkcore.UpdateQuotaCommand{}
 	body.SetQuotaId(projectID)
@@ -1118,8 +1140,8 @@ func resourceTaikunProjectEditQuotas(ctx context.Context, d *schema.ResourceData
 		body.SetVmVolumeSize(float64(vmVolume.(int))) // No conversion needed, API takes GBs
 	}
 
-	_, err = apiClient.Client.ProjectQuotasAPI.ProjectquotasUpdate(ctx).UpdateQuotaCommand(body).Execute()
-	return
+	res, err := apiClient.Client.ProjectQuotasAPI.ProjectquotasUpdate(ctx).UpdateQuotaCommand(body).Execute()
+	return utils.NewApiError(res, err)
 }
 
 func flattenTaikunProject(
@@ -1340,7 +1362,7 @@ func flattenTaikunProject(
 func resourceTaikunProjectGetDeleteOnExpiration(ctx context.Context, projectID int32, apiClient *tk.Client) (bool, error) {
 	data, response, err := apiClient.Client.ProjectsAPI.ProjectsList(ctx).Id(projectID).Execute()
 	if err != nil {
-		return false, tk.CreateError(response, err)
+		return false, utils.NewApiError(response, err)
 	}
 	return data.GetData()[0].GetDeleteOnExpiration(), nil
 }
@@ -1427,7 +1449,7 @@ func resourceTaikunProjectWaitForStatus(ctx context.Context, targetList []string
 		ContinuousTargetOccurence: 2,
 	}
 
-	_, err := createStateConf.WaitForStateContext(ctx)
+	_, err := utils.WaitForState(ctx, createStateConf)
 	if err != nil {
 		return fmt.Errorf("error waiting for project (%d) to be in status %s: %s", projectID, targetList, err)
 	}
@@ -1538,7 +1560,7 @@ func resourceTaikunProjectLock(ctx context.Context, id int32, lock bool, apiClie
 	body.SetId(id)
 	body.SetMode(utils.GetLockMode(lock))
 	res, err := apiClient.Client.ProjectsAPI.ProjectsLockManager(ctx).ProjectLockManagerCommand(body).Execute()
-	return tk.CreateError(res, err)
+	return utils.NewApiError(res, err)
 }
 
 func resourceTaikunProjectQuotaIsSet(d *schema.ResourceData) bool {
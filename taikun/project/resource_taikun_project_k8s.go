@@ -188,7 +188,7 @@ func resourceTaikunProjectSetServers(ctx context.Context, d *schema.ResourceData
 
 	serverCreateResponse, res, err := apiClient.Client.ServersAPI.ServersCreate(ctx).ServerForCreateDto(serverCreateBody).Execute()
 	if err != nil {
-		return tk.CreateError(res, err)
+		return utils.NewApiError(res, err)
 	}
 	bastion["id"] = serverCreateResponse.GetId()
 	err = d.Set("server_bastion", []map[string]interface{}{bastion})
@@ -217,7 +217,7 @@ func resourceTaikunProjectSetServers(ctx context.Context, d *schema.ResourceData
 
 		serverCreateResponse, res, newErr := apiClient.Client.ServersAPI.ServersCreate(ctx).ServerForCreateDto(serverCreateBody).Execute()
 		if newErr != nil {
-			return tk.CreateError(res, newErr)
+			return utils.NewApiError(res, newErr)
 		}
 		kubeMasterMap["id"] = serverCreateResponse.GetId()
 	}
@@ -261,7 +261,7 @@ func resourceTaikunProjectSetServers(ctx context.Context, d *schema.ResourceData
 
 		serverCreateResponse, res, newErr := apiClient.Client.ServersAPI.ServersCreate(ctx).ServerForCreateDto(serverCreateBody).Execute()
 		if newErr != nil {
-			return tk.CreateError(res, newErr)
+			return utils.NewApiError(res, newErr)
 		}
 		kubeWorkerMap["id"] = serverCreateResponse.GetId()
 	}
@@ -294,7 +294,7 @@ func resourceTaikunProjectCommit(ctx context.Context, apiClient *tk.Client, proj
 	commitCommand := &tkcore.ProjectDeploymentCommitCommand{ProjectId: &projectID}
 	res, err := apiClient.Client.ProjectDeploymentAPI.ProjectDeploymentCommit(ctx).ProjectDeploymentCommitCommand(*commitCommand).Execute()
 	if err != nil {
-		return tk.CreateError(res, err)
+		return utils.NewApiError(res, err)
 	}
 	return nil
 }
@@ -321,7 +321,7 @@ func resourceTaikunProjectPurgeServers(ctx context.Context, serversToPurge []int
 
 		res, err := apiClient.Client.ProjectDeploymentAPI.ProjectDeploymentDelete(ctx).ProjectDeploymentDeleteServersCommand(deleteServerBody).Execute()
 		if err != nil {
-			return tk.CreateError(res, err)
+			return utils.NewApiError(res, err)
 		}
 	}
 	return nil
@@ -381,7 +381,7 @@ func resourceTaikunProjectUpdateToggleMonitoring(ctx context.Context, d *schema.
 		// Get the current state of monitoring. If its already disabled, skip disabling query.
 		data, response, err := apiClient.Client.ServersAPI.ServersDetails(ctx, projectID).Execute()
 		if err != nil {
-			return tk.CreateError(response, err)
+			return utils.NewApiError(response, err)
 		}
 		project := data.GetProject()
 		monitoringCurrentyEnabled := project.GetIsMonitoringEnabled()
@@ -390,7 +390,7 @@ func resourceTaikunProjectUpdateToggleMonitoring(ctx context.Context, d *schema.
 			disableBody.SetProjectId(projectID)
 			res, err := apiClient.Client.ProjectDeploymentAPI.ProjectDeploymentDisableMonitoring(ctx).DeploymentDisableMonitoringCommand(disableBody).Execute()
 			if err != nil {
-				return tk.CreateError(res, err)
+				return utils.NewApiError(res, err)
 			}
 		}
 
@@ -419,7 +419,7 @@ func resourceTaikunProjectUpdateToggleMonitoring(ctx context.Context, d *schema.
 				MinTimeout:                5 * time.Second,
 				ContinuousTargetOccurence: 1,
 			}
-			_, err := disableStateConf.WaitForStateContext(ctx)
+			_, err := utils.WaitForState(ctx, disableStateConf)
 			if err != nil {
 				return fmt.Errorf("error waiting for project (%s) to enable monitoring: %s", d.Id(), err)
 			}
@@ -428,7 +428,7 @@ func resourceTaikunProjectUpdateToggleMonitoring(ctx context.Context, d *schema.
 			enableBody.SetProjectId(projectID)
 			res, err := apiClient.Client.ProjectDeploymentAPI.ProjectDeploymentEnableMonitoring(ctx).DeploymentEnableMonitoringCommand(enableBody).Execute()
 			if err != nil {
-				return tk.CreateError(res, err)
+				return utils.NewApiError(res, err)
 			}
 		}
 
@@ -446,7 +446,7 @@ func resourceTaikunProjectUpdateToggleBackup(ctx context.Context, d *schema.Reso
 		// Get the current state of backups. If they are already disabled, skip disabling query.
 		data, response, err := apiClient.Client.ServersAPI.ServersDetails(ctx, projectID).Execute()
 		if err != nil {
-			return tk.CreateError(response, err)
+			return utils.NewApiError(response, err)
 		}
 		project := data.GetProject()
 		backupCurrentyEnabled := project.GetIsBackupEnabled()
@@ -455,7 +455,7 @@ func resourceTaikunProjectUpdateToggleBackup(ctx context.Context, d *schema.Reso
 			disableBody.SetProjectId(projectID)
 			res, err := apiClient.Client.ProjectDeploymentAPI.ProjectDeploymentDisableBackup(ctx).DeploymentDisableBackupCommand(disableBody).Execute()
 			if err != nil {
-				return tk.CreateError(res, err)
+				return utils.NewApiError(res, err)
 			}
 		}
 
@@ -487,7 +487,7 @@ func resourceTaikunProjectUpdateToggleBackup(ctx context.Context, d *schema.Reso
 				MinTimeout:                5 * time.Second,
 				ContinuousTargetOccurence: 1,
 			}
-			_, err := disableStateConf.WaitForStateContext(ctx)
+			_, err := utils.WaitForState(ctx, disableStateConf)
 			if err != nil {
 				return fmt.Errorf("error waiting for project (%s) to disable backup: %s", d.Id(), err)
 			}
@@ -497,7 +497,7 @@ func resourceTaikunProjectUpdateToggleBackup(ctx context.Context, d *schema.Reso
 			enableBody.SetS3CredentialId(newCredentialID)
 			res, err := apiClient.Client.ProjectDeploymentAPI.ProjectDeploymentEnableBackup(ctx).DeploymentEnableBackupCommand(enableBody).Execute()
 			if err != nil {
-				return tk.CreateError(res, err)
+				return utils.NewApiError(res, err)
 			}
 		}
 
@@ -521,7 +521,7 @@ func resourceTaikunProjectUpdateToggleOPA(ctx context.Context, d *schema.Resourc
 			res, err := apiClient.Client.ProjectDeploymentAPI.ProjectDeploymentDisableOpa(ctx).DeploymentDisableOpaCommand(disableBody).Execute()
 
 			if err != nil {
-				return tk.CreateError(res, err)
+				return utils.NewApiError(res, err)
 			}
 
 		}
@@ -543,7 +543,7 @@ func resourceTaikunProjectUpdateToggleOPA(ctx context.Context, d *schema.Resourc
 				Refresh: func() (interface{}, string, error) {
 					response, res, err := apiClient.Client.ServersAPI.ServersDetails(ctx, projectID).Execute()
 					if err != nil {
-						return 0, "", tk.CreateError(res, err)
+						return 0, "", utils.NewApiError(res, err)
 					}
 
 					project := response.GetProject()
@@ -554,7 +554,7 @@ func resourceTaikunProjectUpdateToggleOPA(ctx context.Context, d *schema.Resourc
 				MinTimeout:                5 * time.Second,
 				ContinuousTargetOccurence: 1,
 			}
-			_, err := disableStateConf.WaitForStateContext(ctx)
+			_, err := utils.WaitForState(ctx, disableStateConf)
 			if err != nil {
 				return fmt.Errorf("error waiting for project (%s) to disable OPA: %s", d.Id(), err)
 			}
@@ -565,7 +565,7 @@ func resourceTaikunProjectUpdateToggleOPA(ctx context.Context, d *schema.Resourc
 
 			res, err := apiClient.Client.ProjectDeploymentAPI.ProjectDeploymentEnableOpa(ctx).DeploymentOpaEnableCommand(enableBody).Execute()
 			if err != nil {
-				return tk.CreateError(res, err)
+				return utils.NewApiError(res, err)
 			}
 		}
 
@@ -597,7 +597,7 @@ func resourceTaikunProjectEditFlavors(ctx context.Context, d *schema.ResourceDat
 		unbindBody.SetIds(flavorBindingsToUndo)
 		res, err := apiClient.Client.FlavorsAPI.FlavorsUnbindFromProject(ctx).UnbindFlavorFromProjectCommand(unbindBody).Execute()
 		if err != nil {
-			return tk.CreateError(res, err)
+			return utils.NewApiError(res, err)
 		}
 	}
 	if len(flavorsToBind) != 0 {
@@ -610,7 +610,7 @@ func resourceTaikunProjectEditFlavors(ctx context.Context, d *schema.ResourceDat
 		bindBody.SetFlavors(flavorsToBindNames)
 		res, err := apiClient.Client.FlavorsAPI.FlavorsBindToProject(ctx).BindFlavorToProjectCommand(bindBody).Execute()
 		if err != nil {
-			return tk.CreateError(res, err)
+			return utils.NewApiError(res, err)
 		}
 	}
 	return nil
@@ -625,7 +625,7 @@ func resourceTaikunProjectUpdateAutoscaler(ctx context.Context, d *schema.Resour
 
 	res, err := apiClient.Client.AutoscalingAPI.AutoscalingEdit(ctx).EditAutoscalingCommand(body).Execute()
 	if err != nil {
-		return tk.CreateError(res, err)
+		return utils.NewApiError(res, err)
 	}
 
 	if err := resourceTaikunProjectWaitForStatus(ctx, []string{"Ready"}, []string{"EnableAutoscaler", "DisableAutoscaler"}, apiClient, projectID); err != nil {
@@ -639,7 +639,7 @@ func resourceTaikunProjectRecreateAutoscaler(ctx context.Context, d *schema.Reso
 	projectID, _ := utils.Atoi32(d.Id())
 	data, response, err := apiClient.Client.ServersAPI.ServersDetails(ctx, projectID).Execute()
 	if err != nil {
-		return tk.CreateError(response, err)
+		return utils.NewApiError(response, err)
 	}
 	if data.GetProject().IsAutoscalingEnabled {
 		// Autoscaler was enabled -> Disable autoscaler
@@ -665,7 +665,7 @@ func resourceTaikunProjectDisableAutoscaler(ctx context.Context, d *schema.Resou
 	bodyDisable.SetProjectId(projectID)
 	res, err := apiClient.Client.AutoscalingAPI.AutoscalingDisable(ctx).DisableAutoscalingCommand(bodyDisable).Execute()
 	if err != nil {
-		return tk.CreateError(res, err)
+		return utils.NewApiError(res, err)
 	}
 	if err := resourceTaikunProjectWaitForStatus(ctx, []string{"Ready"}, []string{"EnableAutoscaler", "DisableAutoscaler"}, apiClient, projectID); err != nil {
 		return err
@@ -685,7 +685,7 @@ func resourceTaikunProjectEnableAutoscaler(ctx context.Context, d *schema.Resour
 
 	res, err := apiClient.Client.AutoscalingAPI.AutoscalingEnable(ctx).EnableAutoscalingCommand(bodyEnable).Execute()
 	if err != nil {
-		return tk.CreateError(res, err)
+		return utils.NewApiError(res, err)
 	}
 
 	if err := resourceTaikunProjectWaitForStatus(ctx, []string{"Ready"}, []string{"EnableAutoscaler", "DisableAutoscaler"}, apiClient, projectID); err != nil {
@@ -707,7 +707,7 @@ func resourceTaikunProjectToggleFullSpot(ctx context.Context, d *schema.Resource
 
 	res, err := apiClient.Client.ProjectsAPI.ProjectsToggleFullSpot(ctx).FullSpotOperationCommand(bodyToggle).Execute()
 	if err != nil {
-		return tk.CreateError(res, err)
+		return utils.NewApiError(res, err)
 	}
 	return nil
 }
@@ -725,7 +725,7 @@ func resourceTaikunProjectToggleWorkerSpot(ctx context.Context, d *schema.Resour
 
 	res, err := apiClient.Client.ProjectsAPI.ProjectsToggleSpotWorkers(ctx).SpotWorkerOperationCommand(bodyToggle).Execute()
 	if err != nil {
-		return tk.CreateError(res, err)
+		return utils.NewApiError(res, err)
 	}
 	return nil
 }
@@ -381,7 +381,7 @@ func resourceTaikunProjectUpdateVMs(ctx context.Context, d *schema.ResourceData,
 
 		res, err := apiClient.Client.ProjectDeploymentAPI.ProjectDeploymentDeleteVms(ctx).ProjectDeploymentDeleteVmsCommand(deleteServerBody).Execute()
 		if err != nil {
-			return tk.CreateError(res, err)
+			return utils.NewApiError(res, err)
 		}
 
 		if err := resourceTaikunProjectWaitForStatus(ctx, []string{"Ready"}, []string{"PendingPurge", "Purging", "Deleting", "PendingDelete"}, apiClient, projectID); err != nil {
@@ -437,7 +437,7 @@ func resourceTaikunProjectUpdateVMs(ctx context.Context, d *schema.ResourceData,
 
 				res, err := apiClient.Client.StandaloneAPI.StandaloneIpManagement(ctx).StandAloneVmIpManagementCommand(body).Execute()
 				if err != nil {
-					return tk.CreateError(res, err)
+					return utils.NewApiError(res, err)
 				}
 			}
 			if hasChanges(old, new, "flavor") {
@@ -448,7 +448,7 @@ func resourceTaikunProjectUpdateVMs(ctx context.Context, d *schema.ResourceData,
 
 				res, err := apiClient.Client.StandaloneAPI.StandaloneUpdateFlavor(ctx).UpdateStandAloneVmFlavorCommand(body).Execute()
 				if err != nil {
-					return tk.CreateError(res, err)
+					return utils.NewApiError(res, err)
 				}
 			}
 
@@ -469,7 +469,7 @@ func resourceTaikunProjectUpdateVMs(ctx context.Context, d *schema.ResourceData,
 		res, err := apiClient.Client.ProjectDeploymentAPI.ProjectDeploymentRepairVm(ctx).ProjectDeploymentRepairVmCommand(body).Execute()
 
 		if err != nil {
-			return tk.CreateError(res, err)
+			return utils.NewApiError(res, err)
 		}
 		if err := resourceTaikunProjectWaitForStatus(ctx, []string{"Ready"}, []string{"Updating", "Pending"}, apiClient, projectID); err != nil {
 			return err
@@ -508,7 +508,7 @@ func resourceTaikunProjectUpdateVMDisks(ctx context.Context, oldDisks interface{
 		}
 		response, err := apiClient.Client.ProjectDeploymentAPI.ProjectDeploymentDeleteVmDisks(ctx).DeleteVmDiskCommand(body).Execute()
 		if err != nil {
-			return tk.CreateError(response, err)
+			return utils.NewApiError(response, err)
 		}
 		if err := resourceTaikunProjectWaitForStatus(ctx, []string{"Ready"}, []string{"Updating", "Pending"}, apiClient, projectID); err != nil {
 			return err
@@ -533,7 +533,7 @@ func resourceTaikunProjectUpdateVMDisks(ctx context.Context, oldDisks interface{
 
 				res, err := apiClient.Client.StandaloneVMDisksAPI.StandalonevmdisksUpdateSize(ctx).UpdateStandaloneVmDiskSizeCommand(body).Execute()
 				if err != nil {
-					return tk.CreateError(res, err)
+					return utils.NewApiError(res, err)
 				}
 			}
 		}
@@ -626,7 +626,7 @@ func resourceTaikunProjectAddVM(ctx context.Context, vmMap map[string]interface{
 
 	vmCreateResponse, res, err := apiClient.Client.StandaloneAPI.StandaloneCreate(ctx).CreateStandAloneVmCommand(vmCreateBody).Execute()
 	if err != nil {
-		return "", nil, tk.CreateError(res, err)
+		return "", nil, utils.NewApiError(res, err)
 	}
 
 	return vmCreateResponse.GetId(), unreadableProperties, nil
@@ -643,7 +643,7 @@ func resourceTaikunProjectAddDisk(ctx context.Context, diskMap map[string]interf
 
 	_, res, err := apiClient.Client.StandaloneVMDisksAPI.StandalonevmdisksCreate(ctx).CreateStandAloneDiskCommand(diskCreateBody).Execute()
 	if err != nil {
-		return tk.CreateError(res, err)
+		return utils.NewApiError(res, err)
 	}
 
 	return nil
@@ -654,7 +654,7 @@ func resourceTaikunProjectStandaloneCommit(ctx context.Context, apiClient *tk.Cl
 	body.SetProjectId(projectID)
 	res, err := apiClient.Client.ProjectDeploymentAPI.ProjectDeploymentCommitVm(ctx).DeploymentCommitVmCommand(body).Execute()
 	if err != nil {
-		return tk.CreateError(res, err)
+		return utils.NewApiError(res, err)
 	}
 	return nil
 }
@@ -699,7 +699,7 @@ func resourceTaikunProjectEditImages(ctx context.Context, d *schema.ResourceData
 		unbindBody.SetIds(imageBindingsToUndo)
 		res, err := apiClient.Client.ImagesAPI.ImagesUnbindImagesFromProject(ctx).DeleteImageFromProjectCommand(unbindBody).Execute()
 		if err != nil {
-			return tk.CreateError(res, err)
+			return utils.NewApiError(res, err)
 		}
 	}
 	if len(imagesToBind) != 0 {
@@ -712,7 +712,7 @@ func resourceTaikunProjectEditImages(ctx context.Context, d *schema.ResourceData
 		bindBody.SetImages(imagesToBindNames)
 		res, err := apiClient.Client.ImagesAPI.ImagesBindImagesToProject(ctx).BindImageToProjectCommand(bindBody).Execute()
 		if err != nil {
-			return tk.CreateError(res, err)
+			return utils.NewApiError(res, err)
 		}
 	}
 	return nil
@@ -739,7 +739,7 @@ func resourceTaikunProjectPurgeVMs(ctx context.Context, vmsToPurge []interface{}
 		res, err := apiClient.Client.ProjectDeploymentAPI.ProjectDeploymentDeleteVms(ctx).ProjectDeploymentDeleteVmsCommand(deleteServerBody).Execute()
 
 		if err != nil {
-			return tk.CreateError(res, err)
+			return utils.NewApiError(res, err)
 		}
 	}
 	return nil
@@ -758,7 +758,7 @@ func resourceTaikunProjectToggleVmsSpot(ctx context.Context, d *schema.ResourceD
 
 	res, err := apiClient.Client.ProjectsAPI.ProjectsToggleSpotVms(ctx).SpotVmOperationCommand(bodyToggle).Execute()
 	if err != nil {
-		return tk.CreateError(res, err)
+		return utils.NewApiError(res, err)
 	}
 	return nil
 }
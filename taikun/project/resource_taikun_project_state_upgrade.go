@@ -0,0 +1,38 @@
+package project
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+)
+
+// Version 0 hashed the servers with the values of their Kubernetes node labels in a random order.
+// The state stores sets as lists, their hashes are computed again when it is read, but a server could have been stored twice under two hashes.
+// The servers are deduplicated on the attributes they are hashed with, the first one being kept.
+func resourceTaikunProjectStateUpgraders() []schema.StateUpgrader {
+	return []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    (&schema.Resource{Schema: resourceTaikunProjectSchema()}).CoreConfigSchema().ImpliedType(),
+			Upgrade: resourceTaikunProjectStateUpgradeV0,
+		},
+	}
+}
+
+func resourceTaikunProjectStateUpgradeV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+	hashAttributes := map[string][]string{
+		"server_bastion":    serverBastionHashAttributes,
+		"server_kubemaster": serverKubemasterHashAttributes,
+		"server_kubeworker": serverKubeworkerHashAttributes,
+	}
+	for attribute, keys := range hashAttributes {
+		if servers, ok := rawState[attribute].([]interface{}); ok {
+			rawState[attribute] = utils.DeduplicateSetElements(servers, keys...)
+		}
+	}
+	return rawState, nil
+}
@@ -0,0 +1,64 @@
+//go:build sweep
+
+package project
+
+import (
+	"context"
+	"errors"
+
+	tk "github.com/itera-io/taikungoclient"
+	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+)
+
+// Delete the projects left behind by the acceptance tests, once their applications and virtual clusters are deleted.
+// Projects are what costs the most, their servers and standalone VMs are purged with them.
+func AddTestSweepers(client utils.SweeperClientFunc) {
+	utils.AddTestSweeper("taikun_project", client, []string{"taikun_app_instance", "taikun_virtual_cluster"}, sweepProjects)
+}
+
+func sweepProjects(ctx context.Context, apiClient *tk.Client) error {
+	var offset int32 = 0
+
+	var projectIDs []int32
+	for {
+		response, res, err := apiClient.Client.ProjectsAPI.ProjectsList(ctx).Offset(offset).Execute()
+		if err != nil {
+			return tk.CreateError(res, err)
+		}
+		for _, project := range response.GetData() {
+			if !project.GetIsVirtualCluster() && utils.IsSweepable(project.GetName()) {
+				projectIDs = append(projectIDs, project.GetId())
+			}
+		}
+		offset += int32(len(response.GetData()))
+		if len(response.GetData()) == 0 || int(offset) >= int(response.GetTotalCount()) {
+			break
+		}
+	}
+
+	var errs []error
+	for _, projectID := range projectIDs {
+		errs = append(errs, sweepProject(ctx, projectID, apiClient))
+	}
+	return errors.Join(errs...)
+}
+
+// The resource only purges the servers and VMs in its state, leftover projects are force deleted instead
+func sweepProject(ctx context.Context, projectID int32, apiClient *tk.Client) error {
+	unlock, err := utils.LockProject(ctx, apiClient, projectID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := resourceTaikunProjectUnlockIfLocked(ctx, projectID, apiClient); err != nil {
+		return err
+	}
+
+	body := tkcore.DeleteProjectCommand{}
+	body.SetProjectId(projectID)
+	body.SetIsForceDelete(true)
+	res, err := apiClient.Client.ProjectsAPI.ProjectsDelete(ctx).DeleteProjectCommand(body).Execute()
+	return tk.CreateError(res, err)
+}
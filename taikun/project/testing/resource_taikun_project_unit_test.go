@@ -0,0 +1,114 @@
+package testing
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils_testing"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils_testing/faketaikun"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testUnitResourceTaikunProjectConfig = `
+resource "taikun_cloud_credential_zadara" "foo" {
+  name              = "zadara"
+  url               = "https://zadara.example.com"
+  region            = "symphony"
+  volume_type       = "gp2"
+  access_key_id     = "access-key"
+  secret_access_key = "secret-key"
+}
+
+resource "taikun_kubernetes_profile" "foo" {
+  name = "profile"
+}
+
+resource "taikun_project" "foo" {
+  name                  = "project"
+  cloud_credential_id   = taikun_cloud_credential_zadara.foo.id
+  kubernetes_profile_id = taikun_kubernetes_profile.foo.id
+  flavors               = ["small"]
+
+  server_bastion {
+    name   = "bastion"
+    flavor = "small"
+  }
+  server_kubemaster {
+    name   = "master"
+    flavor = "small"
+  }
+  server_kubeworker {
+    name   = "worker"
+    flavor = "small"
+  }
+  %s
+}
+`
+
+const testUnitResourceTaikunProjectSecondWorker = `
+  server_kubeworker {
+    name      = "second-worker"
+    flavor    = "small"
+    disk_size = 50
+  }
+`
+
+// TestUnitResourceTaikunProject runs against the fake Taikun server.
+// The project and its servers go through Pending and Updating before they are Ready, a worker is then added and removed.
+func TestUnitResourceTaikunProject(t *testing.T) {
+	faketaikun.Start(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: utils_testing.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckTaikunProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testUnitResourceTaikunProjectConfig, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunProjectExists,
+					resource.TestCheckResourceAttr("taikun_project.foo", "name", "project"),
+					resource.TestCheckResourceAttrPair("taikun_project.foo", "cloud_credential_id", "taikun_cloud_credential_zadara.foo", "id"),
+					resource.TestCheckResourceAttrPair("taikun_project.foo", "kubernetes_profile_id", "taikun_kubernetes_profile.foo", "id"),
+					resource.TestCheckResourceAttrSet("taikun_project.foo", "kubernetes_version"),
+					resource.TestCheckResourceAttr("taikun_project.foo", "flavors.#", "1"),
+					resource.TestCheckResourceAttr("taikun_project.foo", "quota_cpu_units", "300"),
+					resource.TestCheckResourceAttr("taikun_project.foo", "quota_ram_size", "500"),
+					resource.TestCheckResourceAttr("taikun_project.foo", "quota_disk_size", "2048"),
+					resource.TestCheckResourceAttr("taikun_project.foo", "server_bastion.#", "1"),
+					resource.TestCheckResourceAttr("taikun_project.foo", "server_bastion.0.status", "Ready"),
+					resource.TestCheckResourceAttr("taikun_project.foo", "server_kubemaster.#", "1"),
+					resource.TestCheckResourceAttr("taikun_project.foo", "server_kubemaster.0.status", "Ready"),
+					resource.TestCheckResourceAttr("taikun_project.foo", "server_kubeworker.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("taikun_project.foo", "server_kubeworker.*", map[string]string{
+						"name":      "worker",
+						"disk_size": "30",
+						"status":    "Ready",
+					}),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testUnitResourceTaikunProjectConfig, testUnitResourceTaikunProjectSecondWorker),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunProjectExists,
+					resource.TestCheckResourceAttr("taikun_project.foo", "server_kubeworker.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("taikun_project.foo", "server_kubeworker.*", map[string]string{
+						"name":      "second-worker",
+						"disk_size": "50",
+						"status":    "Ready",
+					}),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testUnitResourceTaikunProjectConfig, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunProjectExists,
+					resource.TestCheckResourceAttr("taikun_project.foo", "server_kubeworker.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("taikun_project.foo", "server_kubeworker.*", map[string]string{
+						"name": "worker",
+					}),
+				),
+			},
+		},
+	})
+}
@@ -93,7 +93,7 @@ func dataSourceTaikunProjectSubnetsRead(ctx context.Context, d *schema.ResourceD
 
 	response, res, err := apiClient.Client.ServersAPI.ServersDetails(ctx, projectId).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	project := response.GetProject()
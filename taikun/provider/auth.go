@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	tk "github.com/itera-io/taikungoclient"
+	tkcore "github.com/itera-io/taikungoclient/client"
+	tkshowback "github.com/itera-io/taikungoclient/showbackclient"
+)
+
+// Tokens are refreshed this long before they expire, so that a request is never sent with a token about to be rejected
+const tokenExpiryMargin = 30 * time.Second
+
+// A tokenSource hands out the bearer token sent with every request to the Taikun API
+type tokenSource interface {
+	Token(ctx context.Context) (string, error)
+	// Invalidate is called when the API rejected the token, the next call to Token must not return it again
+	Invalidate()
+}
+
+// Pre-issued token, set with `token`
+type staticTokenSource struct {
+	token string
+}
+
+func (s *staticTokenSource) Token(_ context.Context) (string, error) {
+	return s.token, nil
+}
+
+func (s *staticTokenSource) Invalidate() {}
+
+// Token read from a file, set with `token_file`.
+// The file is read again whenever it changes on disk or the API rejects the token, so that tokens rotated by an external agent are picked up.
+type fileTokenSource struct {
+	path string
+
+	mutex   sync.Mutex
+	token   string
+	modTime time.Time
+}
+
+func (s *fileTokenSource) Token(_ context.Context) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	fileInfo, err := os.Stat(s.path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read token_file %s: %s", s.path, err)
+	}
+	if s.token != "" && fileInfo.ModTime().Equal(s.modTime) {
+		return s.token, nil
+	}
+
+	content, err := os.ReadFile(s.path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read token_file %s: %s", s.path, err)
+	}
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", fmt.Errorf("token_file %s is empty", s.path)
+	}
+
+	s.token = token
+	s.modTime = fileInfo.ModTime()
+	return s.token, nil
+}
+
+func (s *fileTokenSource) Invalidate() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.token = ""
+}
+
+// Short-lived token minted by an OIDC identity provider with the client credentials grant, set with `oidc_*`
+type oidcTokenSource struct {
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	scopes       []string
+	audience     string
+	httpClient   *http.Client
+
+	mutex  sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+type oidcTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+func (s *oidcTokenSource) Token(ctx context.Context) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.token != "" && (s.expiry.IsZero() || time.Now().Add(tokenExpiryMargin).Before(s.expiry)) {
+		return s.token, nil
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", s.clientID)
+	form.Set("client_secret", s.clientSecret)
+	if len(s.scopes) != 0 {
+		form.Set("scope", strings.Join(s.scopes, " "))
+	}
+	if s.audience != "" {
+		form.Set("audience", s.audience)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("unable to get a token from %s: %s", s.tokenURL, err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", fmt.Errorf("unable to get a token from %s: %s", s.tokenURL, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unable to get a token from %s: %s: %s", s.tokenURL, response.Status, strings.TrimSpace(string(body)))
+	}
+
+	var tokenResponse oidcTokenResponse
+	if err := json.Unmarshal(body, &tokenResponse); err != nil {
+		return "", fmt.Errorf("unable to parse token response from %s: %s", s.tokenURL, err)
+	}
+	if tokenResponse.AccessToken == "" {
+		return "", fmt.Errorf("token response from %s does not contain an access_token", s.tokenURL)
+	}
+
+	s.token = tokenResponse.AccessToken
+	s.expiry = time.Time{}
+	if tokenResponse.ExpiresIn > 0 {
+		s.expiry = time.Now().Add(time.Duration(tokenResponse.ExpiresIn) * time.Second)
+	}
+	return s.token, nil
+}
+
+func (s *oidcTokenSource) Invalidate() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.token = ""
+}
+
+// Adds the bearer token to every request.
+// If the API answers 401, the token is invalidated and the request is sent once more with a fresh token.
+type bearerTokenTransport struct {
+	source tokenSource
+	base   http.RoundTripper
+}
+
+func (t *bearerTokenTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	response, err := t.roundTripWithToken(request)
+	if err != nil || response.StatusCode != http.StatusUnauthorized {
+		return response, err
+	}
+
+	// The body was already consumed, only retry if it can be rewound
+	if request.Body != nil && request.GetBody == nil {
+		return response, nil
+	}
+	retryRequest := request.Clone(request.Context())
+	if request.GetBody != nil {
+		body, errBody := request.GetBody()
+		if errBody != nil {
+			return response, nil
+		}
+		retryRequest.Body = body
+	}
+
+	t.source.Invalidate()
+	_, _ = io.Copy(io.Discard, response.Body)
+	response.Body.Close()
+	return t.roundTripWithToken(retryRequest)
+}
+
+func (t *bearerTokenTransport) roundTripWithToken(request *http.Request) (*http.Response, error) {
+	token, err := t.source.Token(request.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	authenticatedRequest := request.Clone(request.Context())
+	authenticatedRequest.Header.Set("Authorization", "Bearer "+token)
+	return t.base.RoundTrip(authenticatedRequest)
+}
+
+// Build a Taikun client which authenticates every request with a token from the given source.
+// The API host may start with a scheme, an http:// host is used by the fake Taikun server of the unit tests.
+func newClientFromTokenSource(apiHost string, source tokenSource) *tk.Client {
+	scheme := "https"
+	if hostScheme, host, found := strings.Cut(apiHost, "://"); found {
+		scheme = hostScheme
+		apiHost = host
+	}
+
+	httpClient := &http.Client{
+		Transport: &bearerTokenTransport{
+			source: source,
+			base:   http.DefaultTransport,
+		},
+	}
+
+	coreConfiguration := tkcore.NewConfiguration()
+	coreConfiguration.Host = apiHost
+	coreConfiguration.Scheme = scheme
+	coreConfiguration.HTTPClient = httpClient
+
+	showbackConfiguration := tkshowback.NewConfiguration()
+	showbackConfiguration.Host = apiHost
+	showbackConfiguration.Scheme = scheme
+	showbackConfiguration.HTTPClient = httpClient
+
+	return &tk.Client{
+		Client:         tkcore.NewAPIClient(coreConfiguration),
+		ShowbackClient: tkshowback.NewAPIClient(showbackConfiguration),
+	}
+}
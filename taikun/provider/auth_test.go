@@ -0,0 +1,270 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func writeTokenFile(t *testing.T, path string, token string, modTime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(token+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// Rotations may happen within the resolution of the file system clock
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileTokenSourceRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	start := time.Now().Add(-time.Hour)
+	writeTokenFile(t, path, "first-token", start)
+	source := &fileTokenSource{path: path}
+
+	token, err := source.Token(context.Background())
+	if err != nil || token != "first-token" {
+		t.Fatalf("Token() = %q, %v, expected first-token", token, err)
+	}
+
+	// The file is not read again while it does not change
+	if err := os.WriteFile(path, []byte("unseen-token"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, start, start); err != nil {
+		t.Fatal(err)
+	}
+	if token, _ := source.Token(context.Background()); token != "first-token" {
+		t.Fatalf("Token() = %q after a write keeping the modification time, expected first-token", token)
+	}
+
+	// A rotated file is read again
+	writeTokenFile(t, path, "second-token", start.Add(time.Minute))
+	if token, _ := source.Token(context.Background()); token != "second-token" {
+		t.Fatalf("Token() = %q after the rotation, expected second-token", token)
+	}
+
+	// An invalidated token is read again, even if the file did not change
+	writeTokenFile(t, path, "third-token", start.Add(time.Minute))
+	source.Invalidate()
+	if token, _ := source.Token(context.Background()); token != "third-token" {
+		t.Fatalf("Token() = %q after Invalidate, expected third-token", token)
+	}
+}
+
+func TestFileTokenSourceErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := (&fileTokenSource{path: filepath.Join(dir, "missing")}).Token(context.Background()); err == nil {
+		t.Fatal("expected an error for a missing token_file")
+	}
+
+	empty := filepath.Join(dir, "empty")
+	writeTokenFile(t, empty, "  ", time.Now())
+	if _, err := (&fileTokenSource{path: empty}).Token(context.Background()); err == nil || !strings.Contains(err.Error(), "is empty") {
+		t.Fatalf("expected an error for an empty token_file, got %v", err)
+	}
+}
+
+// Identity provider minting numbered tokens with the client credentials grant
+func newOIDCServer(t *testing.T, expiresIn int64) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var issued atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.PostForm.Get("grant_type") != "client_credentials" || r.PostForm.Get("client_id") != "client" || r.PostForm.Get("client_secret") != "secret" {
+			http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+			return
+		}
+		if r.PostForm.Get("scope") != "taikun openid" || r.PostForm.Get("audience") != "taikun-api" {
+			http.Error(w, `{"error":"invalid_scope"}`, http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":%d}`, issued.Add(1), expiresIn)
+	}))
+	t.Cleanup(server.Close)
+	return server, &issued
+}
+
+func newTestOIDCTokenSource(tokenURL string, clientSecret string) *oidcTokenSource {
+	return &oidcTokenSource{
+		tokenURL:     tokenURL,
+		clientID:     "client",
+		clientSecret: clientSecret,
+		scopes:       []string{"taikun", "openid"},
+		audience:     "taikun-api",
+		httpClient:   http.DefaultClient,
+	}
+}
+
+func TestOIDCTokenSourceClientCredentials(t *testing.T) {
+	server, issued := newOIDCServer(t, 3600)
+	source := newTestOIDCTokenSource(server.URL, "secret")
+
+	for range 2 {
+		token, err := source.Token(context.Background())
+		if err != nil || token != "token-1" {
+			t.Fatalf("Token() = %q, %v, expected token-1", token, err)
+		}
+	}
+	if issued.Load() != 1 {
+		t.Fatalf("expected the token to be reused until it expires, %d tokens were issued", issued.Load())
+	}
+
+	source.Invalidate()
+	if token, _ := source.Token(context.Background()); token != "token-2" {
+		t.Fatalf("Token() = %q after Invalidate, expected token-2", token)
+	}
+}
+
+func TestOIDCTokenSourceExpiry(t *testing.T) {
+	// Tokens expiring within tokenExpiryMargin are exchanged again
+	server, issued := newOIDCServer(t, int64(tokenExpiryMargin/time.Second)-1)
+	source := newTestOIDCTokenSource(server.URL, "secret")
+
+	for _, expected := range []string{"token-1", "token-2"} {
+		if token, err := source.Token(context.Background()); err != nil || token != expected {
+			t.Fatalf("Token() = %q, %v, expected %s", token, err, expected)
+		}
+	}
+	if issued.Load() != 2 {
+		t.Fatalf("expected a token about to expire to be exchanged again, %d tokens were issued", issued.Load())
+	}
+}
+
+func TestOIDCTokenSourceRejected(t *testing.T) {
+	server, _ := newOIDCServer(t, 3600)
+	source := newTestOIDCTokenSource(server.URL, "wrong-secret")
+
+	if _, err := source.Token(context.Background()); err == nil || !strings.Contains(err.Error(), "invalid_client") {
+		t.Fatalf("expected the error of the identity provider, got %v", err)
+	}
+}
+
+// Token source handing out numbered tokens, a new one after each invalidation
+type countingTokenSource struct {
+	current     atomic.Int32
+	invalidated atomic.Int32
+}
+
+func (s *countingTokenSource) Token(_ context.Context) (string, error) {
+	return fmt.Sprintf("token-%d", s.current.Load()), nil
+}
+
+func (s *countingTokenSource) Invalidate() {
+	s.invalidated.Add(1)
+	s.current.Add(1)
+}
+
+// API accepting only the given token, recording the body of every request
+func newBearerServer(t *testing.T, validToken string) (*httptest.Server, *[]string) {
+	t.Helper()
+	bodies := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if r.Header.Get("Authorization") != "Bearer "+validToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, &bodies
+}
+
+func TestBearerTokenTransportRetriesOnce(t *testing.T) {
+	testCases := []struct {
+		name               string
+		validToken         string
+		expectedStatus     int
+		expectedRequests   int
+		expectedInvalidate int32
+	}{
+		{
+			name:               "valid token",
+			validToken:         "token-0",
+			expectedStatus:     http.StatusOK,
+			expectedRequests:   1,
+			expectedInvalidate: 0,
+		},
+		{
+			name:               "rejected token replaced by a fresh one",
+			validToken:         "token-1",
+			expectedStatus:     http.StatusOK,
+			expectedRequests:   2,
+			expectedInvalidate: 1,
+		},
+		{
+			name:               "fresh token rejected as well",
+			validToken:         "token-2",
+			expectedStatus:     http.StatusUnauthorized,
+			expectedRequests:   2,
+			expectedInvalidate: 1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server, bodies := newBearerServer(t, testCase.validToken)
+			source := &countingTokenSource{}
+			client := &http.Client{Transport: &bearerTokenTransport{source: source, base: http.DefaultTransport}}
+
+			response, err := client.Post(server.URL, "application/json", strings.NewReader(`{"name":"foo"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = response.Body.Close()
+
+			if response.StatusCode != testCase.expectedStatus {
+				t.Errorf("status = %d, expected %d", response.StatusCode, testCase.expectedStatus)
+			}
+			if len(*bodies) != testCase.expectedRequests {
+				t.Errorf("%d requests sent, expected %d", len(*bodies), testCase.expectedRequests)
+			}
+			for _, body := range *bodies {
+				if body != `{"name":"foo"}` {
+					t.Errorf("body = %q, expected the body to be sent again with the retry", body)
+				}
+			}
+			if source.invalidated.Load() != testCase.expectedInvalidate {
+				t.Errorf("token invalidated %d times, expected %d", source.invalidated.Load(), testCase.expectedInvalidate)
+			}
+		})
+	}
+}
+
+// A body which cannot be rewound is not sent twice, the 401 is returned as it is
+func TestBearerTokenTransportBodyNotRewindable(t *testing.T) {
+	server, bodies := newBearerServer(t, "token-1")
+	source := &countingTokenSource{}
+	transport := &bearerTokenTransport{source: source, base: http.DefaultTransport}
+
+	request, err := http.NewRequest(http.MethodPost, server.URL, io.NopCloser(strings.NewReader(`{"name":"foo"}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	request.GetBody = nil
+	response, err := transport.RoundTrip(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = response.Body.Close()
+
+	if response.StatusCode != http.StatusUnauthorized || len(*bodies) != 1 || source.invalidated.Load() != 0 {
+		t.Fatalf("status = %d after %d requests and %d invalidations, expected a single rejected request", response.StatusCode, len(*bodies), source.invalidated.Load())
+	}
+}
@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tk "github.com/itera-io/taikungoclient"
+	"github.com/itera-io/terraform-provider-taikun/taikun/functions"
+	"github.com/itera-io/terraform-provider-taikun/taikun/kubeconfig"
+)
+
+// Provider written with terraform-plugin-framework, served next to the SDKv2 provider.
+// New resources, data sources, ephemeral resources and functions can be implemented with the framework and registered here.
+// It has no configuration logic of its own: the SDKv2 provider is configured first and its Taikun client is handed to the framework resources.
+type frameworkProvider struct {
+	sdkProvider *schema.Provider
+}
+
+var (
+	_ fwprovider.Provider                       = &frameworkProvider{}
+	_ fwprovider.ProviderWithEphemeralResources = &frameworkProvider{}
+	_ fwprovider.ProviderWithFunctions          = &frameworkProvider{}
+)
+
+func NewFrameworkProvider(sdkProvider *schema.Provider) func() fwprovider.Provider {
+	return func() fwprovider.Provider {
+		return &frameworkProvider{sdkProvider: sdkProvider}
+	}
+}
+
+// Serve the SDKv2 and framework providers as a single provider.
+// The SDKv2 provider must come first, as the mux server configures providers in order and the framework provider reuses its client.
+func NewMuxServer(ctx context.Context, sdkProvider *schema.Provider) (tfprotov5.ProviderServer, error) {
+	muxServer, err := tf5muxserver.NewMuxServer(ctx,
+		sdkProvider.GRPCProvider,
+		providerserver.NewProtocol5(NewFrameworkProvider(sdkProvider)()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return muxServer.ProviderServer(), nil
+}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ fwprovider.MetadataRequest, resp *fwprovider.MetadataResponse) {
+	resp.TypeName = "taikun"
+}
+
+func (p *frameworkProvider) Schema(ctx context.Context, _ fwprovider.SchemaRequest, resp *fwprovider.SchemaResponse) {
+	providerSchema, err := frameworkProviderSchema(ctx, p.sdkProvider)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to build the provider schema", err.Error())
+		return
+	}
+	resp.Schema = providerSchema
+}
+
+func (p *frameworkProvider) Configure(_ context.Context, _ fwprovider.ConfigureRequest, resp *fwprovider.ConfigureResponse) {
+	apiClient, ok := p.sdkProvider.Meta().(*tk.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Provider not configured", "The Taikun client is created when configuring the SDKv2 provider, which must be served before the framework provider.")
+		return
+	}
+
+	resp.DataSourceData = apiClient
+	resp.ResourceData = apiClient
+	resp.EphemeralResourceData = apiClient
+	resp.ListResourceData = apiClient
+}
+
+func (p *frameworkProvider) Resources(_ context.Context) []func() resource.Resource {
+	return []func() resource.Resource{}
+}
+
+func (p *frameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{}
+}
+
+func (p *frameworkProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		kubeconfig.NewEphemeralTaikunKubeconfig,
+	}
+}
+
+func (p *frameworkProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		functions.NewContinentShorthandFunction,
+		functions.NewExpirationDateFunction,
+		functions.NewParseKubeconfigFunction,
+		functions.NewVersionCompareFunction,
+	}
+}
+
+// The mux server requires identical provider schemas, the framework schema is therefore derived from the SDKv2 one
+func frameworkProviderSchema(ctx context.Context, sdkProvider *schema.Provider) (fwschema.Schema, error) {
+	resp, err := schema.NewGRPCProviderServer(sdkProvider).GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		return fwschema.Schema{}, err
+	}
+	block := resp.Provider.Block
+	if len(block.BlockTypes) != 0 {
+		return fwschema.Schema{}, fmt.Errorf("provider schema blocks are not supported")
+	}
+
+	attributes := make(map[string]fwschema.Attribute, len(block.Attributes))
+	for _, attribute := range block.Attributes {
+		frameworkAttribute, err := frameworkProviderAttribute(attribute)
+		if err != nil {
+			return fwschema.Schema{}, fmt.Errorf("attribute %s: %s", attribute.Name, err)
+		}
+		attributes[attribute.Name] = frameworkAttribute
+	}
+
+	return fwschema.Schema{Attributes: attributes}, nil
+}
+
+func frameworkProviderAttribute(attribute *tfprotov5.SchemaAttribute) (fwschema.Attribute, error) {
+	var description, markdownDescription string
+	if attribute.DescriptionKind == tfprotov5.StringKindMarkdown {
+		markdownDescription = attribute.Description
+	} else {
+		description = attribute.Description
+	}
+
+	var deprecationMessage string
+	if attribute.Deprecated {
+		deprecationMessage = "This attribute is deprecated."
+	}
+
+	switch {
+	case attribute.Type.Is(tftypes.String):
+		return fwschema.StringAttribute{
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+			Required:            attribute.Required,
+			Optional:            attribute.Optional,
+			Sensitive:           attribute.Sensitive,
+		}, nil
+	case attribute.Type.Is(tftypes.Bool):
+		return fwschema.BoolAttribute{
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+			Required:            attribute.Required,
+			Optional:            attribute.Optional,
+			Sensitive:           attribute.Sensitive,
+		}, nil
+	case attribute.Type.Is(tftypes.Number):
+		return fwschema.NumberAttribute{
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+			Required:            attribute.Required,
+			Optional:            attribute.Optional,
+			Sensitive:           attribute.Sensitive,
+		}, nil
+	case attribute.Type.Is(tftypes.List{}):
+		elementType, err := frameworkElementType(attribute.Type.(tftypes.List).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return fwschema.ListAttribute{
+			ElementType:         elementType,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+			Required:            attribute.Required,
+			Optional:            attribute.Optional,
+			Sensitive:           attribute.Sensitive,
+		}, nil
+	case attribute.Type.Is(tftypes.Set{}):
+		elementType, err := frameworkElementType(attribute.Type.(tftypes.Set).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return fwschema.SetAttribute{
+			ElementType:         elementType,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+			Required:            attribute.Required,
+			Optional:            attribute.Optional,
+			Sensitive:           attribute.Sensitive,
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", attribute.Type)
+}
+
+func frameworkElementType(elementType tftypes.Type) (attr.Type, error) {
+	switch {
+	case elementType.Is(tftypes.String):
+		return types.StringType, nil
+	case elementType.Is(tftypes.Bool):
+		return types.BoolType, nil
+	case elementType.Is(tftypes.Number):
+		return types.NumberType, nil
+	}
+	return nil, fmt.Errorf("unsupported element type %s", elementType)
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"strings"
+	"time"
 
 	"github.com/itera-io/terraform-provider-taikun/taikun/account"
 	"github.com/itera-io/terraform-provider-taikun/taikun/access_profile"
@@ -14,6 +16,7 @@ import (
 	"github.com/itera-io/terraform-provider-taikun/taikun/backup_policy"
 	"github.com/itera-io/terraform-provider-taikun/taikun/billing"
 	"github.com/itera-io/terraform-provider-taikun/taikun/catalog"
+	"github.com/itera-io/terraform-provider-taikun/taikun/catalog_application"
 	"github.com/itera-io/terraform-provider-taikun/taikun/catalog_project_binding"
 	"github.com/itera-io/terraform-provider-taikun/taikun/cc_aws"
 	"github.com/itera-io/terraform-provider-taikun/taikun/cc_azure"
@@ -36,6 +39,7 @@ import (
 	"github.com/itera-io/terraform-provider-taikun/taikun/slack"
 	"github.com/itera-io/terraform-provider-taikun/taikun/standalone_profile"
 	"github.com/itera-io/terraform-provider-taikun/taikun/user"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
 	"github.com/itera-io/terraform-provider-taikun/taikun/virtual_cluster"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -144,6 +148,7 @@ func Provider() *schema.Provider {
 			"taikun_kubernetes_profiles":         kubernetes_profile.DataSourceTaikunKubernetesProfiles(),
 			"taikun_organization":                organization.DataSourceTaikunOrganization(),
 			"taikun_organizations":               organization.DataSourceTaikunOrganizations(),
+			"taikun_packages":                    repository.DataSourceTaikunPackages(),
 			"taikun_policy_profile":              policy_profile.DataSourceTaikunPolicyProfile(),
 			"taikun_policy_profiles":             policy_profile.DataSourceTaikunPolicyProfiles(),
 			"taikun_project":                     project.DataSourceTaikunProject(),
@@ -170,12 +175,14 @@ func Provider() *schema.Provider {
 			"taikun_account":                              account.ResourceTaikunAccount(),
 			"taikun_access_profile":                       access_profile.ResourceTaikunAccessProfile(),
 			"taikun_alerting_profile":                     alerting_profile.ResourceTaikunAlertingProfile(),
+			"taikun_app_deployment":                       app_instance.ResourceTaikunAppDeployment(),
 			"taikun_app_instance":                         app_instance.ResourceTaikunAppInstance(),
 			"taikun_backup_credential":                    backup_credential.ResourceTaikunBackupCredential(),
 			"taikun_backup_policy":                        backup_policy.ResourceTaikunBackupPolicy(),
 			"taikun_billing_credential":                   billing.ResourceTaikunBillingCredential(),
 			"taikun_billing_rule":                         billing.ResourceTaikunBillingRule(),
 			"taikun_catalog":                              catalog.ResourceTaikunCatalog(),
+			"taikun_catalog_application":                  catalog_application.ResourceTaikunCatalogApplication(),
 			"taikun_catalog_project_binding":              catalog_project_binding.ResourceTaikunCatalogProjectBinding(),
 			"taikun_cloud_credential_aws":                 cc_aws.ResourceTaikunCloudCredentialAWS(),
 			"taikun_cloud_credential_azure":               cc_azure.ResourceTaikunCloudCredentialAzure(),
@@ -213,8 +220,8 @@ func Provider() *schema.Provider {
 			},
 			"account_name": {
 				Type:         schema.TypeString,
-				Description:  "Custom Taikun account_name. Can be set with TAIKUN_ACCOUNT_NAME.",
-				Required:     true,
+				Description:  "Custom Taikun account_name, used to log in with email/password, keycloak_email or access_key. Tokens are sent as they are, without it. Can be set with TAIKUN_ACCOUNT_NAME.",
+				Optional:     true,
 				DefaultFunc:  schema.EnvDefaultFunc("TAIKUN_ACCOUNT_NAME", "taikun"),
 				ValidateFunc: validation.StringIsNotEmpty,
 			},
@@ -239,7 +246,7 @@ func Provider() *schema.Provider {
 			},
 			"access_key": {
 				Type:          schema.TypeString,
-				Description:   "Taikun access key. Can be set with TAIKUN_ACCESS_KEY.",
+				Description:   "Taikun access key, a robot user's access key from `taikun_robot` can be used directly. Can be set with TAIKUN_ACCESS_KEY.",
 				Optional:      true,
 				DefaultFunc:   schema.EnvDefaultFunc("TAIKUN_ACCESS_KEY", nil),
 				ConflictsWith: []string{"email", "keycloak_email"},
@@ -248,7 +255,7 @@ func Provider() *schema.Provider {
 			},
 			"secret_key": {
 				Type:          schema.TypeString,
-				Description:   "Taikun secret key. Can be set with TAIKUN_SECRET_KEY.",
+				Description:   "Taikun secret key, a robot user's secret key from `taikun_robot` can be used directly. Can be set with TAIKUN_SECRET_KEY.",
 				Optional:      true,
 				Sensitive:     true,
 				DefaultFunc:   schema.EnvDefaultFunc("TAIKUN_SECRET_KEY", nil),
@@ -275,23 +282,116 @@ func Provider() *schema.Provider {
 				RequiredWith:  []string{"keycloak_email"},
 				ValidateFunc:  validation.StringIsNotEmpty,
 			},
+			"default_organization_id": {
+				Type:             schema.TypeString,
+				Description:      "ID of the organization used by resources which do not specify `organization_id`. Can be set with TAIKUN_DEFAULT_ORGANIZATION_ID.",
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("TAIKUN_DEFAULT_ORGANIZATION_ID", nil),
+				ValidateDiagFunc: utils.StringIsInt,
+			},
+			"project_lock_dir": {
+				Type:         schema.TypeString,
+				Description:  "Directory for advisory lock files, so that Terraform processes sharing it do not operate on the same project concurrently. Operations on a project are always serialised within a single provider. Can be set with TAIKUN_PROJECT_LOCK_DIR.",
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("TAIKUN_PROJECT_LOCK_DIR", nil),
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"token": {
+				Type:          schema.TypeString,
+				Description:   "Pre-issued Taikun bearer token. Can be set with TAIKUN_TOKEN.",
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.EnvDefaultFunc("TAIKUN_TOKEN", nil),
+				ConflictsWith: []string{"email", "keycloak_email", "access_key", "token_file", "oidc_client_id"},
+				ValidateFunc:  validation.StringIsNotEmpty,
+			},
+			"token_file": {
+				Type:          schema.TypeString,
+				Description:   "Path to a file containing a Taikun bearer token. The file is read again whenever it changes, so the token can be rotated during a run. Can be set with TAIKUN_TOKEN_FILE.",
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("TAIKUN_TOKEN_FILE", nil),
+				ConflictsWith: []string{"email", "keycloak_email", "access_key", "token", "oidc_client_id"},
+				ValidateFunc:  validation.StringIsNotEmpty,
+			},
+			"oidc_token_url": {
+				Type:         schema.TypeString,
+				Description:  "Token endpoint of the OIDC identity provider used with the client credentials grant. Can be set with TAIKUN_OIDC_TOKEN_URL.",
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("TAIKUN_OIDC_TOKEN_URL", nil),
+				RequiredWith: []string{"oidc_client_id", "oidc_client_secret"},
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"oidc_client_id": {
+				Type:          schema.TypeString,
+				Description:   "OIDC client ID used with the client credentials grant. Can be set with TAIKUN_OIDC_CLIENT_ID.",
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("TAIKUN_OIDC_CLIENT_ID", nil),
+				ConflictsWith: []string{"email", "keycloak_email", "access_key", "token", "token_file"},
+				RequiredWith:  []string{"oidc_token_url", "oidc_client_secret"},
+				ValidateFunc:  validation.StringIsNotEmpty,
+			},
+			"oidc_client_secret": {
+				Type:         schema.TypeString,
+				Description:  "OIDC client secret used with the client credentials grant. Can be set with TAIKUN_OIDC_CLIENT_SECRET.",
+				Optional:     true,
+				Sensitive:    true,
+				DefaultFunc:  schema.EnvDefaultFunc("TAIKUN_OIDC_CLIENT_SECRET", nil),
+				RequiredWith: []string{"oidc_token_url", "oidc_client_id"},
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"oidc_scopes": {
+				Type:         schema.TypeList,
+				Description:  "Scopes requested from the OIDC identity provider.",
+				Optional:     true,
+				RequiredWith: []string{"oidc_client_id"},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+			"oidc_audience": {
+				Type:         schema.TypeString,
+				Description:  "Audience requested from the OIDC identity provider. Can be set with TAIKUN_OIDC_AUDIENCE.",
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("TAIKUN_OIDC_AUDIENCE", nil),
+				RequiredWith: []string{"oidc_client_id"},
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
 		},
 		ConfigureContextFunc: configureContextFunc,
 	}
 }
 
-func configureContextFunc(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	// Get account name
-	rawAccountName, ok := d.GetOk("account_name")
-	if !ok {
-		return nil, diag.Errorf("account_name is required")
+func configureContextFunc(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	apiClient, diags := newClientFromConfiguration(ctx, d)
+	if diags != nil {
+		return nil, diags
 	}
-	// Casting account name to string
-	accountName, ok := rawAccountName.(string)
-	if !ok {
-		return nil, diag.Errorf("casting to string failed, account_name must be a string")
+
+	// Acceptance tests record the requests to Taikun with TAIKUN_RECORD=1 and replay them with TAIKUN_REPLAY=1
+	if utils.HTTPClientHook != nil {
+		apiClient.Client.GetConfig().HTTPClient = utils.HTTPClientHook(apiClient.Client.GetConfig().HTTPClient)
+		apiClient.ShowbackClient.GetConfig().HTTPClient = utils.HTTPClientHook(apiClient.ShowbackClient.GetConfig().HTTPClient)
 	}
 
+	utils.SetProviderConfig(apiClient, utils.ProviderConfig{
+		DefaultOrganizationID: d.Get("default_organization_id").(string),
+		ProjectLockDir:        d.Get("project_lock_dir").(string),
+	})
+
+	return apiClient, nil
+}
+
+// The account name is only needed to log in to Taikun, tokens are sent as they are
+func getAccountName(d *schema.ResourceData) (string, diag.Diagnostics) {
+	accountName, ok := d.Get("account_name").(string)
+	if !ok || accountName == "" {
+		return "", diag.Errorf("account_name is required to log in with keycloak_email, email/password or access_key/secret_key")
+	}
+	return accountName, nil
+}
+
+func newClientFromConfiguration(ctx context.Context, d *schema.ResourceData) (*tk.Client, diag.Diagnostics) {
 	// Get API host
 	apiHost, ok := d.Get("api_host").(string)
 	if !ok {
@@ -310,6 +410,10 @@ func configureContextFunc(_ context.Context, d *schema.ResourceData) (interface{
 			return nil, diag.Errorf("casting failed, keycloak_password must be a string")
 		}
 		authMode := "keycloak"
+		accountName, diags := getAccountName(d)
+		if diags != nil {
+			return nil, diags
+		}
 		return tk.NewClientFromCredentials(accountName, email, password, authMode, apiHost), nil
 	}
 
@@ -325,6 +429,10 @@ func configureContextFunc(_ context.Context, d *schema.ResourceData) (interface{
 			return nil, diag.Errorf("casting failed, password must be a string")
 		}
 		authMode := ""
+		accountName, diags := getAccountName(d)
+		if diags != nil {
+			return nil, diags
+		}
 		return tk.NewClientFromCredentials(accountName, email, password, authMode, apiHost), nil
 	}
 
@@ -339,8 +447,54 @@ func configureContextFunc(_ context.Context, d *schema.ResourceData) (interface{
 		if !ok1 {
 			return nil, diag.Errorf("casting failed, secret_key must be a string")
 		}
+		accountName, diags := getAccountName(d)
+		if diags != nil {
+			return nil, diags
+		}
 		return tk.NewClientFromAccessKey(accountName, accessKey, secretKey, apiHost), nil
 	}
 
-	return nil, diag.Errorf("You must define credentials using either keycloak_email, email/password, or access_key/secret_key")
+	// Try pre-issued token
+	if rawToken, ok := d.GetOk("token"); ok {
+		token, ok1 := rawToken.(string)
+		if !ok1 {
+			return nil, diag.Errorf("casting failed, token must be a string")
+		}
+		return newClientFromTokenSource(apiHost, &staticTokenSource{token: token}), nil
+	}
+
+	// Try token file
+	if rawTokenFile, ok := d.GetOk("token_file"); ok {
+		tokenFile, ok1 := rawTokenFile.(string)
+		if !ok1 {
+			return nil, diag.Errorf("casting failed, token_file must be a string")
+		}
+		source := &fileTokenSource{path: tokenFile}
+		if _, err := source.Token(ctx); err != nil {
+			return nil, diag.FromErr(err)
+		}
+		return newClientFromTokenSource(apiHost, source), nil
+	}
+
+	// Try OIDC client credentials
+	if rawClientID, ok := d.GetOk("oidc_client_id"); ok {
+		clientID, ok1 := rawClientID.(string)
+		if !ok1 {
+			return nil, diag.Errorf("casting failed, oidc_client_id must be a string")
+		}
+		source := &oidcTokenSource{
+			tokenURL:     d.Get("oidc_token_url").(string),
+			clientID:     clientID,
+			clientSecret: d.Get("oidc_client_secret").(string),
+			scopes:       utils.ResourceGetStringList(d.Get("oidc_scopes")),
+			audience:     d.Get("oidc_audience").(string),
+			httpClient:   &http.Client{Timeout: 30 * time.Second},
+		}
+		if _, err := source.Token(ctx); err != nil {
+			return nil, diag.FromErr(err)
+		}
+		return newClientFromTokenSource(apiHost, source), nil
+	}
+
+	return nil, diag.Errorf("You must define credentials using either keycloak_email, email/password, access_key/secret_key, token, token_file or oidc_client_id")
 }
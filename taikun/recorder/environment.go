@@ -0,0 +1,96 @@
+package recorder
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// Values of the environment variables required by the recorded tests.
+// Tests read them to build their configuration, they are restored before a replay so that the requests match the cassettes.
+var environmentPath = filepath.Join(cassetteDir, "environment.json")
+
+var requiredEnv struct {
+	mutex sync.Mutex
+	names map[string]bool
+}
+
+// Remember environment variables required by the running test, their values are saved with its cassette
+func RequireEnv(names ...string) {
+	if !Recording() {
+		return
+	}
+	requiredEnv.mutex.Lock()
+	defer requiredEnv.mutex.Unlock()
+	if requiredEnv.names == nil {
+		requiredEnv.names = map[string]bool{}
+	}
+	for _, name := range names {
+		requiredEnv.names[name] = true
+	}
+}
+
+// Merge the required environment variables into the environment of the package, secrets are saved as their placeholder
+func saveEnvironment() error {
+	environment, err := readEnvironment()
+	if err != nil {
+		return err
+	}
+
+	requiredEnv.mutex.Lock()
+	names := make([]string, 0, len(requiredEnv.names))
+	for name := range requiredEnv.names {
+		names = append(names, name)
+	}
+	requiredEnv.mutex.Unlock()
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := os.Getenv(name)
+		if isSecretEnv(name) {
+			value = placeholder(name)
+		}
+		environment[name] = value
+	}
+	return writeJSONFile(environmentPath, environment)
+}
+
+// Set the recorded environment variables which are not already set.
+// It must be called before the tests build their configuration, it does nothing unless TAIKUN_REPLAY is set.
+func LoadEnvironment() error {
+	if !Replaying() {
+		return nil
+	}
+	environment, err := readEnvironment()
+	if err != nil {
+		return err
+	}
+	for name, value := range environment {
+		if _, set := os.LookupEnv(name); set {
+			continue
+		}
+		if err := os.Setenv(name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readEnvironment() (map[string]string, error) {
+	environment := map[string]string{}
+	content, err := os.ReadFile(environmentPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return environment, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, &environment); err != nil {
+		return nil, err
+	}
+	return environment, nil
+}
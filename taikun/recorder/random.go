@@ -0,0 +1,73 @@
+package recorder
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// Same characters as acctest.RandString
+const charSetAlphaNum = "abcdefghijklmnopqrstuvwxyz012346789"
+
+// Names of the objects created by a test end up in the requests, they must be the same when recording and replaying.
+// Each test function therefore draws its random values from its own generator, seeded with its name.
+var generators struct {
+	mutex      sync.Mutex
+	byTestName map[string]*rand.Rand
+}
+
+func RandomString(length int) string {
+	generator := testGenerator()
+	result := make([]byte, length)
+	for i := range result {
+		result[i] = charSetAlphaNum[generator.Intn(len(charSetAlphaNum))]
+	}
+	return string(result)
+}
+
+// Return an integer in the range [0; maxInt[
+func RandomInt(maxInt int) int {
+	return testGenerator().Intn(maxInt)
+}
+
+func testGenerator() *rand.Rand {
+	testName := callingTestName()
+
+	generators.mutex.Lock()
+	defer generators.mutex.Unlock()
+	if generators.byTestName == nil {
+		generators.byTestName = map[string]*rand.Rand{}
+	}
+	generator, found := generators.byTestName[testName]
+	if !found {
+		hash := fnv.New64a()
+		_, _ = hash.Write([]byte(testName))
+		generator = rand.New(rand.NewSource(int64(hash.Sum64())))
+		generators.byTestName[testName] = generator
+	}
+	return generator
+}
+
+// Qualified name of the first Test function found in the call stack, the closures of a test share its generator.
+// Values drawn outside of a test share a single generator, they are only deterministic if drawn in the same order.
+func callingTestName() string {
+	programCounters := make([]uintptr, 64)
+	frames := runtime.CallersFrames(programCounters[:runtime.Callers(2, programCounters)])
+	for {
+		frame, more := frames.Next()
+		// github.com/owner/repo/taikun/pkg/testing.TestAccX.func1 -> github.com/owner/repo/taikun/pkg/testing.TestAccX
+		packagePath, function := "", frame.Function
+		if slash := strings.LastIndex(function, "/"); slash >= 0 {
+			packagePath, function = function[:slash+1], function[slash+1:]
+		}
+		parts := strings.Split(function, ".")
+		if len(parts) >= 2 && strings.HasPrefix(parts[1], "Test") {
+			return packagePath + parts[0] + "." + parts[1]
+		}
+		if !more {
+			return ""
+		}
+	}
+}
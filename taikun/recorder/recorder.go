@@ -0,0 +1,169 @@
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Environment variables enabling the recorder, only one of them may be set to 1
+const (
+	RecordEnv = "TAIKUN_RECORD"
+	ReplayEnv = "TAIKUN_REPLAY"
+)
+
+// Cassettes are stored next to the tests, one file per test
+const cassetteDir = "testdata/cassettes"
+
+// Requests sent to Taikun during a test and the responses it returned, secrets are replaced by placeholders
+type Cassette struct {
+	Name         string        `json:"name"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method string `json:"method"`
+	URI    string `json:"uri"`
+	Body   string `json:"body,omitempty"`
+}
+
+type Response struct {
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body,omitempty"`
+}
+
+// Subset of testing.TB used by the recorder, the provider binary does not import the testing package
+type T interface {
+	Helper()
+	Name() string
+	Failed() bool
+	Cleanup(func())
+	Fatalf(format string, args ...interface{})
+	Logf(format string, args ...interface{})
+}
+
+func Recording() bool {
+	return os.Getenv(RecordEnv) == "1"
+}
+
+func Replaying() bool {
+	return os.Getenv(ReplayEnv) == "1"
+}
+
+func Enabled() bool {
+	return Recording() || Replaying()
+}
+
+// Cassette of the running test, requests are recorded in it or answered from it.
+// Tests share this state, record and replay therefore require -parallel=1.
+var current struct {
+	mutex    sync.Mutex
+	cassette *Cassette
+	replay   *replayer
+}
+
+// Start recording or replaying the cassette of the test, until the end of the test.
+// It does nothing unless TAIKUN_RECORD or TAIKUN_REPLAY is set.
+func Start(t T) {
+	t.Helper()
+
+	if Recording() && Replaying() {
+		t.Fatalf("%s and %s cannot be set at the same time", RecordEnv, ReplayEnv)
+	}
+	if !Enabled() {
+		return
+	}
+
+	// Tests requiring several kinds of credentials run several pre-checks
+	current.mutex.Lock()
+	started := current.cassette != nil && current.cassette.Name == t.Name()
+	current.mutex.Unlock()
+	if started {
+		return
+	}
+
+	path := cassettePath(t.Name())
+	cassette := &Cassette{Name: t.Name(), Interactions: []Interaction{}}
+	var replay *replayer
+	if Replaying() {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("no cassette recorded for %s, run the test once with %s=1: %s", t.Name(), RecordEnv, err)
+		}
+		if err := json.Unmarshal(content, cassette); err != nil {
+			t.Fatalf("unable to parse cassette %s: %s", path, err)
+		}
+		replay = newReplayer(cassette)
+	}
+
+	current.mutex.Lock()
+	current.cassette = cassette
+	current.replay = replay
+	current.mutex.Unlock()
+
+	t.Cleanup(func() {
+		current.mutex.Lock()
+		defer current.mutex.Unlock()
+		current.cassette = nil
+		current.replay = nil
+
+		// A failed test may have stopped halfway, its cassette would not be replayable
+		if !Recording() || t.Failed() {
+			return
+		}
+		if err := writeJSONFile(path, cassette); err != nil {
+			t.Fatalf("unable to save cassette %s: %s", path, err)
+		}
+		if err := saveEnvironment(); err != nil {
+			t.Fatalf("unable to save the environment of %s: %s", t.Name(), err)
+		}
+		t.Logf("recorded %d interactions in %s", len(cassette.Interactions), path)
+	})
+}
+
+// Subtests have a slash in their name, all cassettes of a package are kept in the same directory
+func cassettePath(testName string) string {
+	fileName := strings.NewReplacer("/", "_", " ", "_").Replace(testName) + ".json"
+	return filepath.Join(cassetteDir, fileName)
+}
+
+func writeJSONFile(path string, value interface{}) error {
+	var content bytes.Buffer
+	encoder := json.NewEncoder(&content)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content.Bytes(), 0o644)
+}
+
+func recordInteraction(interaction Interaction) {
+	current.mutex.Lock()
+	defer current.mutex.Unlock()
+	if current.cassette != nil {
+		current.cassette.Interactions = append(current.cassette.Interactions, interaction)
+	}
+}
+
+func replayInteraction(request Request) (Response, error) {
+	current.mutex.Lock()
+	defer current.mutex.Unlock()
+	if current.replay == nil {
+		return Response{}, fmt.Errorf("%s is set but no cassette was started, the test must call utils_testing.TestAccPreCheck", ReplayEnv)
+	}
+	return current.replay.next(request)
+}
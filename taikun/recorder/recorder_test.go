@@ -0,0 +1,67 @@
+package recorder
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type roundTripperFunc func(request *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+func jsonResponse(request *http.Request, body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+		Request:    request,
+	}
+}
+
+// Replay the cassette committed in testdata, recorded from a Taikun-like server, without any server
+func TestReplayCassette(t *testing.T) {
+	t.Setenv(RecordEnv, "")
+	t.Setenv(ReplayEnv, "1")
+	Start(t)
+
+	base := roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		t.Fatalf("%s %s reached the network during a replay", request.Method, request.URL)
+		return nil, nil
+	})
+	client := &http.Client{Transport: NewTransport(base)}
+
+	send := func(method string, uri string, body string) (int, string) {
+		request, _ := http.NewRequest(method, "https://api.example.com"+uri, strings.NewReader(body))
+		response, err := client.Do(request)
+		if err != nil {
+			t.Fatalf("%s %s: %s", method, uri, err)
+		}
+		defer response.Body.Close()
+		content, _ := io.ReadAll(response.Body)
+		return response.StatusCode, string(content)
+	}
+
+	// The secret key is sanitized the same way as when recording, the fields of the body may be in any order
+	if _, body := send(http.MethodPost, "/api/v1/auth/login", `{"secretKey":"another-secret","mode":"token","accessKey":"access"}`); body != `{"refreshToken":"REDACTED","refreshTokenExpireTime":"2030-01-01T00:00:00Z","token":"REDACTED"}` {
+		t.Errorf("unexpected login response %s", body)
+	}
+	if status, body := send(http.MethodPost, "/api/v1/s3credentials", `{"s3Name":"tf-acc-test-replay","s3AccessKeyId":"access-key","s3SecretAccessKey":"s3-secret","s3Endpoint":"https://s3.example.com","s3Region":"eu-central-1"}`); status != http.StatusOK || !strings.Contains(body, `"id":"42"`) {
+		t.Errorf("unexpected create response %d %s", status, body)
+	}
+	// Waiters poll more often than when recorded, the last response is returned again
+	for i := 0; i < 3; i++ {
+		if _, body := send(http.MethodGet, "/api/v1/s3credentials/list?Id=42", ""); !strings.Contains(body, `"s3Name":"tf-acc-test-replay"`) {
+			t.Errorf("unexpected list response %s", body)
+		}
+	}
+
+	request, _ := http.NewRequest(http.MethodGet, "https://api.example.com/api/v1/s3credentials/list?Id=43", nil)
+	if _, err := client.Do(request); err == nil || !strings.Contains(err.Error(), "no response recorded") {
+		t.Errorf("expected a request missing from the cassette to fail, got %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package recorder
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Environment variables whose value is a secret, they are replaced by a <NAME> placeholder in the cassettes
+var secretEnvRegexp = regexp.MustCompile(`SECRET|PASSWORD|TOKEN|_KEY`)
+
+// Shorter values are too likely to appear by chance in the bodies
+const minSecretLength = 4
+
+// JSON fields holding credentials, such as the tokens issued by Taikun which are not in the environment.
+// They are redacted by the end of their name, which also covers prefixed fields such as s3SecretAccessKey or refreshToken.
+var secretFieldSuffixes = []string{
+	"token",
+	"password",
+	"secret",
+	"secretkey",
+	"secretaccesskey",
+}
+
+const redacted = "REDACTED"
+
+func isSecretEnv(name string) bool {
+	return secretEnvRegexp.MatchString(strings.ToUpper(name))
+}
+
+func placeholder(name string) string {
+	return fmt.Sprintf("<%s>", name)
+}
+
+// Replace the secrets of the environment by their placeholder, then redact the secret fields and kubeconfigs of JSON bodies
+func sanitizeBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	sanitized := secretReplacer().Replace(string(body))
+
+	value, err := decodeJSON(sanitized)
+	if err != nil {
+		return sanitized
+	}
+	value, changed := redactValue("", value)
+	if !changed {
+		return sanitized
+	}
+	redactedBody, err := encodeJSON(value)
+	if err != nil {
+		return sanitized
+	}
+	return redactedBody
+}
+
+func secretReplacer() *strings.Replacer {
+	type secret struct{ name, value string }
+	secrets := []secret{}
+	for _, variable := range os.Environ() {
+		name, value, _ := strings.Cut(variable, "=")
+		if isSecretEnv(name) && len(value) >= minSecretLength && value != placeholder(name) {
+			secrets = append(secrets, secret{name, value})
+		}
+	}
+	// Longer secrets first, in case one secret contains another
+	sort.Slice(secrets, func(i, j int) bool { return len(secrets[i].value) > len(secrets[j].value) })
+
+	oldNew := make([]string, 0, 2*len(secrets))
+	for _, secret := range secrets {
+		oldNew = append(oldNew, secret.value, placeholder(secret.name))
+	}
+	return strings.NewReplacer(oldNew...)
+}
+
+func isSecretField(key string) bool {
+	key = strings.ToLower(key)
+	for _, suffix := range secretFieldSuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Kubeconfigs are downloaded as JSON strings, the credentials of their users are redacted line by line
+var kubeconfigSecretRegexp = regexp.MustCompile(`(?m)^([ \t]*(?:- )?(?:client-key-data|token|password|id-token|refresh-token|client-secret)[ \t]*:[ \t]*)\S.*$`)
+
+func isKubeconfig(value string) bool {
+	return strings.Contains(value, "kind: Config") && strings.Contains(value, "users:")
+}
+
+// Redact the secrets of a decoded JSON value, the key is the name of the field holding it.
+// Return the redacted value and true if a secret was redacted.
+func redactValue(key string, value interface{}) (interface{}, bool) {
+	switch typed := value.(type) {
+	case string:
+		if typed != "" && isSecretField(key) {
+			return redacted, true
+		}
+		if isKubeconfig(typed) {
+			kubeconfig := kubeconfigSecretRegexp.ReplaceAllString(typed, "${1}"+redacted)
+			return kubeconfig, kubeconfig != typed
+		}
+	case map[string]interface{}:
+		changed := false
+		for fieldKey, field := range typed {
+			if redactedField, fieldChanged := redactValue(fieldKey, field); fieldChanged {
+				typed[fieldKey] = redactedField
+				changed = true
+			}
+		}
+		return typed, changed
+	case []interface{}:
+		changed := false
+		for i, item := range typed {
+			if redactedItem, itemChanged := redactValue("", item); itemChanged {
+				typed[i] = redactedItem
+				changed = true
+			}
+		}
+		return typed, changed
+	}
+	return value, false
+}
+
+// JSON bodies are compared without regard to the order of their fields
+func canonicalBody(body string) string {
+	value, err := decodeJSON(body)
+	if err != nil {
+		return body
+	}
+	canonical, err := encodeJSON(value)
+	if err != nil {
+		return body
+	}
+	return canonical
+}
+
+// Numbers are kept as written, large IDs would lose precision as float64
+func decodeJSON(body string) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Placeholders are kept readable, they are not escaped as HTML
+func encodeJSON(value interface{}) (string, error) {
+	var builder strings.Builder
+	encoder := json.NewEncoder(&builder)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,160 @@
+package recorder
+
+import (
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSanitizeBodyRedactsSecretFields(t *testing.T) {
+	testCases := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{
+			name:     "tokens issued by Taikun",
+			body:     `{"token":"issued-token","refreshToken":"issued-refresh-token","refreshTokenExpireTime":"2030-01-01T00:00:00Z"}`,
+			expected: `{"refreshToken":"REDACTED","refreshTokenExpireTime":"2030-01-01T00:00:00Z","token":"REDACTED"}`,
+		},
+		{
+			name:     "passwords in nested objects and arrays",
+			body:     `{"users":[{"name":"admin","password":"hunter22"}],"repository":{"url":"https://charts.example.com","Password":"chart-password"}}`,
+			expected: `{"repository":{"Password":"REDACTED","url":"https://charts.example.com"},"users":[{"name":"admin","password":"REDACTED"}]}`,
+		},
+		{
+			name:     "prefixed secret fields",
+			body:     `{"s3AccessKeyId":"access-key","s3SecretAccessKey":"s3-secret","azureClientSecret":"azure-secret","secretKey":"taikun-secret"}`,
+			expected: `{"azureClientSecret":"REDACTED","s3AccessKeyId":"access-key","s3SecretAccessKey":"REDACTED","secretKey":"REDACTED"}`,
+		},
+		{
+			name:     "credentials of downloaded kubeconfigs",
+			body:     `"apiVersion: v1\nclusters:\n- cluster:\n    certificate-authority-data: Y2EtZGF0YQ==\n    server: https://k8s.example.com\n  name: project\nkind: Config\nusers:\n- name: admin\n  user:\n    client-certificate-data: Y2VydC1kYXRh\n    client-key-data: a2V5LWRhdGE=\n- name: robot\n  user:\n    token: robot-token\n"`,
+			expected: `"apiVersion: v1\nclusters:\n- cluster:\n    certificate-authority-data: Y2EtZGF0YQ==\n    server: https://k8s.example.com\n  name: project\nkind: Config\nusers:\n- name: admin\n  user:\n    client-certificate-data: Y2VydC1kYXRh\n    client-key-data: REDACTED\n- name: robot\n  user:\n    token: REDACTED\n"`,
+		},
+		{
+			name:     "kubeconfigs inside objects",
+			body:     `{"id":1,"content":"kind: Config\nusers:\n- name: admin\n  user:\n    password: kube-password\n"}`,
+			expected: `{"content":"kind: Config\nusers:\n- name: admin\n  user:\n    password: REDACTED\n","id":1}`,
+		},
+		{
+			name:     "strings which are not kubeconfigs are kept",
+			body:     `"token: not-a-kubeconfig"`,
+			expected: `"token: not-a-kubeconfig"`,
+		},
+		{
+			name:     "empty secrets are kept",
+			body:     `{"password":"","token":null}`,
+			expected: `{"password":"","token":null}`,
+		},
+		{
+			name:     "bodies without secrets are kept as sent",
+			body:     `{"name":"b", "id":1}`,
+			expected: `{"name":"b", "id":1}`,
+		},
+		{
+			name:     "large IDs keep their precision",
+			body:     `{"id":9007199254740993,"token":"issued-token"}`,
+			expected: `{"id":9007199254740993,"token":"REDACTED"}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if sanitized := sanitizeBody([]byte(testCase.body)); sanitized != testCase.expected {
+				t.Errorf("sanitizeBody(%s) = %s, expected %s", testCase.body, sanitized, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestSanitizeBodyReplacesEnvironmentSecrets(t *testing.T) {
+	t.Setenv("TAIKUN_SECRET_KEY", "taikun-secret-value")
+	t.Setenv("TAIKUN_TOKEN", "taikun-token-value")
+	t.Setenv("OS_PASSWORD", "abc")
+	t.Setenv("TAIKUN_API_HOST", "https://api.example.com")
+
+	testCases := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{
+			name:     "secrets inside other values",
+			body:     `{"kubeconfig":"user: taikun-token-value","note":"key taikun-secret-value"}`,
+			expected: `{"kubeconfig":"user: <TAIKUN_TOKEN>","note":"key <TAIKUN_SECRET_KEY>"}`,
+		},
+		{
+			name:     "bodies which are not JSON",
+			body:     `Authorization: Bearer taikun-token-value`,
+			expected: `Authorization: Bearer <TAIKUN_TOKEN>`,
+		},
+		{
+			name:     "short secrets and variables which are not secrets are kept",
+			body:     `{"name":"abc","url":"https://api.example.com"}`,
+			expected: `{"name":"abc","url":"https://api.example.com"}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if sanitized := sanitizeBody([]byte(testCase.body)); sanitized != testCase.expected {
+				t.Errorf("sanitizeBody(%s) = %s, expected %s", testCase.body, sanitized, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestIsSecretEnv(t *testing.T) {
+	for name, expected := range map[string]bool{
+		"TAIKUN_SECRET_KEY":     true,
+		"TAIKUN_ACCESS_KEY":     true,
+		"TAIKUN_TOKEN":          true,
+		"OS_PASSWORD":           true,
+		"AWS_SECRET_ACCESS_KEY": true,
+		"TAIKUN_API_HOST":       false,
+		"OS_USERNAME":           false,
+	} {
+		if isSecretEnv(name) != expected {
+			t.Errorf("isSecretEnv(%s) = %t, expected %t", name, !expected, expected)
+		}
+	}
+}
+
+// The Authorization header carries the bearer token of the provider, only the method, URI and body of the requests are recorded
+func TestRecordedInteractionsHoldNoCredentials(t *testing.T) {
+	t.Setenv(RecordEnv, "1")
+	t.Setenv(ReplayEnv, "")
+	t.Setenv("TAIKUN_SECRET_KEY", "taikun-secret-value")
+	t.Chdir(t.TempDir())
+
+	t.Run("record", func(t *testing.T) {
+		Start(t)
+		base := roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			return jsonResponse(request, `{"token":"issued-token-value","refreshToken":"issued-refresh-value"}`), nil
+		})
+		client := &http.Client{Transport: NewTransport(base)}
+
+		request, _ := http.NewRequest(http.MethodPost, "https://api.example.com/api/v1/auth/login?secret=taikun-secret-value", strings.NewReader(`{"mode":"token","secretKey":"taikun-secret-value"}`))
+		request.Header.Set("Authorization", "Bearer issued-token-value")
+		response, err := client.Do(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = response.Body.Close()
+	})
+
+	content, err := os.ReadFile(cassettePath("TestRecordedInteractionsHoldNoCredentials/record"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"taikun-secret-value", "issued-token-value", "issued-refresh-value", "Bearer"} {
+		if strings.Contains(string(content), secret) {
+			t.Errorf("cassette holds %q:\n%s", secret, content)
+		}
+	}
+	if !strings.Contains(string(content), "secret=<TAIKUN_SECRET_KEY>") {
+		t.Errorf("secret of the URI is not replaced by its placeholder:\n%s", content)
+	}
+}
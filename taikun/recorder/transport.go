@@ -0,0 +1,144 @@
+package recorder
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Transport records the requests sent through the base transport when TAIKUN_RECORD=1, and answers them from the cassette without reaching Taikun when TAIKUN_REPLAY=1.
+type Transport struct {
+	base http.RoundTripper
+}
+
+func NewTransport(base http.RoundTripper) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{base: base}
+}
+
+// Return a copy of the client sending its requests through the recorder, or the client itself when the recorder is disabled
+func WrapClient(client *http.Client) *http.Client {
+	if !Enabled() {
+		return client
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	wrapped := *client
+	wrapped.Transport = NewTransport(client.Transport)
+	return &wrapped
+}
+
+func (t *Transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(request)
+	if err != nil {
+		return nil, err
+	}
+	recordedRequest := Request{
+		Method: request.Method,
+		URI:    secretReplacer().Replace(request.URL.RequestURI()),
+		Body:   sanitizeBody(requestBody),
+	}
+
+	if Replaying() {
+		recordedResponse, err := replayInteraction(recordedRequest)
+		if err != nil {
+			return nil, err
+		}
+		return newResponse(request, recordedResponse), nil
+	}
+
+	response, err := t.base.RoundTrip(request)
+	if err != nil || !Recording() {
+		return response, err
+	}
+
+	responseBody, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	recordInteraction(Interaction{
+		Request: recordedRequest,
+		Response: Response{
+			StatusCode:  response.StatusCode,
+			ContentType: response.Header.Get("Content-Type"),
+			Body:        sanitizeBody(responseBody),
+		},
+	})
+	return response, nil
+}
+
+// Read the body of the request and put it back, so that it can still be sent
+func readRequestBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(request.Body)
+	request.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	request.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+func newResponse(request *http.Request, recordedResponse Response) *http.Response {
+	header := http.Header{}
+	if recordedResponse.ContentType != "" {
+		header.Set("Content-Type", recordedResponse.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recordedResponse.StatusCode, http.StatusText(recordedResponse.StatusCode)),
+		StatusCode:    recordedResponse.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(recordedResponse.Body))),
+		ContentLength: int64(len(recordedResponse.Body)),
+		Request:       request,
+	}
+}
+
+// Replayed responses, grouped by request.
+// Requests of independent resources are sent concurrently by Terraform, they are thus matched by content rather than by order.
+type replayer struct {
+	cassetteName string
+	responses    map[string][]Response
+}
+
+func newReplayer(cassette *Cassette) *replayer {
+	replay := &replayer{
+		cassetteName: cassette.Name,
+		responses:    map[string][]Response{},
+	}
+	for _, interaction := range cassette.Interactions {
+		key := interactionKey(interaction.Request)
+		replay.responses[key] = append(replay.responses[key], interaction.Response)
+	}
+	return replay
+}
+
+// Responses to identical requests are returned in the recorded order.
+// The last one is kept and returned again, waiters may poll once more than when the cassette was recorded.
+func (r *replayer) next(request Request) (Response, error) {
+	key := interactionKey(request)
+	responses := r.responses[key]
+	if len(responses) == 0 {
+		return Response{}, fmt.Errorf("no response recorded for %s %s in the cassette of %s, record it again with %s=1", request.Method, request.URI, r.cassetteName, RecordEnv)
+	}
+	if len(responses) > 1 {
+		r.responses[key] = responses[1:]
+	}
+	return responses[0], nil
+}
+
+func interactionKey(request Request) string {
+	return request.Method + " " + request.URI + "\n" + canonicalBody(request.Body)
+}
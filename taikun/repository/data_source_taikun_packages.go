@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	tk "github.com/itera-io/taikungoclient"
+	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+)
+
+func DataSourceTaikunPackages() *schema.Resource {
+	return &schema.Resource{
+		Description: "Search the packages (Helm charts) offered by the repositories.",
+		ReadContext: dataSourceTaikunPackagesRead,
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Description:  "Only the packages of the repository with this name.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"name_regex": {
+				Description:  "Only the packages whose name matches this regular expression.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"category": {
+				Description:  "Only the packages of this category.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"version_constraint": {
+				Description:  "Only the packages with a version satisfying this constraint, for example `>= 1.2, < 2.0` or `~> 4.1`. The highest such version is given by `matching_version`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateVersionConstraint,
+			},
+			"packages": {
+				Description: "List of retrieved packages.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"package_id": {
+							Description: "The ID of the package.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "The name of the package, to use as the name of a catalog application.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"repository": {
+							Description: "The name of the repository of the package.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"private": {
+							Description: "Indicates whether the repository of the package is private.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"category": {
+							Description: "The category of the package.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"latest_version": {
+							Description: "The latest version of the chart.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"matching_version": {
+							Description: "The highest version of the chart satisfying `version_constraint`, the latest version without a constraint.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"app_version": {
+							Description: "The version of the application packaged by the latest version of the chart.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"description": {
+							Description: "The description of the package.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func validateVersionConstraint(i interface{}, k string) ([]string, []error) {
+	constraint, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if _, err := version.NewConstraint(constraint); err != nil {
+		return nil, []error{fmt.Errorf("%s is not a valid version constraint: %s", k, err)}
+	}
+	return nil, nil
+}
+
+func dataSourceTaikunPackagesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*tk.Client)
+	repositoryName := d.Get("repository").(string)
+	category := d.Get("category").(string)
+
+	var nameRegex *regexp.Regexp
+	if nameRegexData, nameRegexIsSet := d.GetOk("name_regex"); nameRegexIsSet {
+		nameRegex = regexp.MustCompile(nameRegexData.(string))
+	}
+	var constraint version.Constraints
+	if constraintData, constraintIsSet := d.GetOk("version_constraint"); constraintIsSet {
+		constraint, _ = version.NewConstraint(constraintData.(string))
+	}
+
+	// Public packages, then private packages
+	packages := make([]map[string]interface{}, 0)
+	for _, private := range []bool{false, true} {
+		var offset int32 = 0
+		params := apiClient.Client.PackageAPI.PackageList(ctx).IsPrivate(private)
+		if repositoryName != "" {
+			params = params.FilterBy(repositoryName)
+		}
+
+		var packagesList []tkcore.AvailablePackagesDto
+		for {
+			response, res, err := params.Offset(offset).Execute()
+			if err != nil {
+				return utils.DiagnosticsFromApiError(res, err)
+			}
+			packagesList = append(packagesList, response.Data...)
+			if len(packagesList) >= int(response.GetTotalCount()) || len(response.Data) == 0 {
+				break
+			}
+			offset = int32(len(packagesList))
+		}
+
+		for _, rawPackage := range packagesList {
+			// FilterBy also matches the names of the packages, the repository is checked again
+			if repositoryName != "" && rawPackage.Repository.GetName() != repositoryName {
+				continue
+			}
+			if nameRegex != nil && !nameRegex.MatchString(rawPackage.GetName()) {
+				continue
+			}
+			if category != "" && !strings.EqualFold(rawPackage.GetCategory(), category) {
+				continue
+			}
+			matchingVersion := rawPackage.GetVersion()
+			if constraint != nil {
+				versions, err := utils.GetPackageVersions(ctx, rawPackage.Repository.GetName(), rawPackage.GetName(), apiClient)
+				if err != nil {
+					return utils.DiagnosticsFromError(err)
+				}
+				if matchingVersion = highestMatchingVersion(versions, constraint); matchingVersion == "" {
+					continue
+				}
+			}
+			packages = append(packages, flattenTaikunPackage(&rawPackage, private, matchingVersion))
+		}
+	}
+
+	if err := d.Set("packages", packages); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("all")
+
+	return nil
+}
+
+// Highest of the versions satisfying the constraint, as listed by Taikun, empty if none does.
+// Tags which are not versions, such as latest, cannot satisfy a constraint and are skipped.
+func highestMatchingVersion(versions []string, constraint version.Constraints) string {
+	var highest *version.Version
+	highestTag := ""
+	for _, tag := range versions {
+		parsed, err := version.NewVersion(tag)
+		if err != nil || !constraint.Check(parsed) {
+			continue
+		}
+		if highest == nil || parsed.GreaterThan(highest) {
+			highest = parsed
+			highestTag = tag
+		}
+	}
+	return highestTag
+}
+
+func flattenTaikunPackage(rawPackage *tkcore.AvailablePackagesDto, private bool, matchingVersion string) map[string]interface{} {
+	return map[string]interface{}{
+		"package_id":       rawPackage.GetPackageId(),
+		"name":             rawPackage.GetName(),
+		"repository":       rawPackage.Repository.GetName(),
+		"private":          private,
+		"category":         rawPackage.GetCategory(),
+		"latest_version":   rawPackage.GetVersion(),
+		"matching_version": matchingVersion,
+		"app_version":      rawPackage.GetAppVersion(),
+		"description":      rawPackage.GetDescription(),
+	}
+}
@@ -47,7 +47,7 @@ func dataSourceTaikunRepositoriesRead(ctx context.Context, d *schema.ResourceDat
 	for {
 		response, res, err := params.Offset(offsetPublic).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		repositoriesList = append(repositoriesList, response.Data...)
 		if len(repositoriesList) == int(response.GetTotalCount()) {
@@ -63,7 +63,7 @@ func dataSourceTaikunRepositoriesRead(ctx context.Context, d *schema.ResourceDat
 	for {
 		response, res, err := params.Offset(offsetPrivate).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		repositoriesList = append(repositoriesList, response.Data...)
 		if len(repositoriesList) == int(response.GetTotalCount())+publicTotalGot {
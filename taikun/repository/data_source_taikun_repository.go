@@ -17,6 +17,7 @@ func dataSourceTaikunRepositorySchema() map[string]*schema.Schema {
 	utils.SetValidateDiagFuncToSchema(dsSchema, "organization_id", utils.StringIsInt)
 	utils.AddRequiredFieldsToSchema(dsSchema, "private")
 	dsSchema["private"].Type = schema.TypeBool
+	utils.DeleteFieldsFromSchema(dsSchema, "password_wo", "password_wo_version")
 	return dsSchema
 }
 
@@ -42,5 +43,10 @@ func dataSourceTaikunRepositoryRead(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	return generateResourceTaikunRepositoryReadWithoutRetries()(ctx, d, meta)
+	// The resource read drops a repository which does not exist from the state with a warning, a data source reports it as an error instead.
+	diags := generateResourceTaikunRepositoryReadWithoutRetries()(ctx, d, meta)
+	if d.Id() == "" && !diags.HasError() {
+		return diag.Errorf("repository %s not found in organization %s", d.Get("name").(string), d.Get("organization_name").(string))
+	}
+	return diags
 }
@@ -2,12 +2,14 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -17,7 +19,7 @@ import (
 )
 
 func resourceTaikunRepositorySchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
+	resourceSchema := map[string]*schema.Schema{
 		// repositoryId - user does not set
 		"id": {
 			Description: "The ID of the repository.",
@@ -58,11 +60,10 @@ func resourceTaikunRepositorySchema() map[string]*schema.Schema {
 		},
 		// url - Required when private is enabled, otherwise it gets filled from server
 		"url": {
-			Description:  "The URL of the repository (http|https|oci).",
+			Description:  "The URL of the repository (http|https|oci). It can be changed in place for a private repository.",
 			Type:         schema.TypeString,
 			Optional:     true,
 			ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "oci"}),
-			ForceNew:     true,
 		},
 		"enabled": {
 			Description: "Indicates whether the repository is enabled.",
@@ -89,6 +90,8 @@ func resourceTaikunRepositorySchema() map[string]*schema.Schema {
 			ValidateFunc: validation.StringIsNotEmpty,
 		},
 	}
+	utils.AddWriteOnlySecret(resourceSchema, "password")
+	return resourceSchema
 }
 
 func ResourceTaikunRepository() *schema.Resource {
@@ -99,7 +102,67 @@ func ResourceTaikunRepository() *schema.Resource {
 		UpdateContext: resourceTaikunRepositoryUpdate,
 		DeleteContext: resourceTaikunRepositoryDelete, // Skip if public, we cannot delete public.
 		Schema:        resourceTaikunRepositorySchema(),
+		CustomizeDiff: customdiff.All(utils.SetDefaultOrganizationID, forceNewPublicRepositoryUrl),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTaikunRepositoryImport,
+		},
+	}
+}
+
+// Repositories are imported with the ID organization_id/name, or organization_id/organization_name/name
+// if the name is shared by repositories of several organizations of the public registry.
+func resourceTaikunRepositoryImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	apiClient := meta.(*tk.Client)
+
+	var orgIdString, organizationName, repositoryName string
+	if strings.Count(d.Id(), "/") == 2 {
+		parts, err := utils.ParseImportID(d.Id(), "organization_id", "organization_name", "name")
+		if err != nil {
+			return nil, err
+		}
+		orgIdString, organizationName, repositoryName = parts[0], parts[1], parts[2]
+	} else {
+		parts, err := utils.ParseImportID(d.Id(), "organization_id", "name")
+		if err != nil {
+			return nil, err
+		}
+		orgIdString, repositoryName = parts[0], parts[1]
+	}
+	orgId, err := utils.Atoi32(orgIdString)
+	if err != nil {
+		return nil, fmt.Errorf("organization_id isn't valid: %s", orgIdString)
+	}
+
+	// The import ID does not tell whether the repository is private, both lists are searched
+	var matches []tkcore.ArtifactRepositoryDto
+	var matchesPrivate []bool
+	for _, private := range []bool{true, false} {
+		data, response, err := apiClient.Client.AppRepositoriesAPI.RepositoryAvailableList(ctx).IsPrivate(private).Search(repositoryName).OrganizationId(orgId).Execute()
+		if err != nil {
+			return nil, utils.NewApiError(response, err)
+		}
+		for _, repo := range data.GetData() {
+			if repo.GetName() == repositoryName && (organizationName == "" || repo.GetOrganizationName() == organizationName) {
+				matches = append(matches, repo)
+				matchesPrivate = append(matchesPrivate, private)
+			}
+		}
+	}
+
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("could not find the repository %s in organization %d", repositoryName, orgId)
+	}
+	if len(matches) > 1 {
+		return nil, fmt.Errorf("%d repositories are named %s in organization %d, import it with the ID organization_id/organization_name/name", len(matches), repositoryName, orgId)
+	}
+
+	err = utils.SetResourceDataFromMap(d, flattenTaikunRepository(orgId, &matches[0], matchesPrivate[0]))
+	if err != nil {
+		return nil, err
 	}
+	d.SetId(matches[0].GetRepositoryId())
+
+	return []*schema.ResourceData{d}, nil
 }
 
 func resourceTaikunRepositoryDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -107,29 +170,66 @@ func resourceTaikunRepositoryDelete(ctx context.Context, d *schema.ResourceData,
 		apiClient := meta.(*tk.Client)
 		err := ensureDesiredState(ctx, false, d.Get("enabled").(bool), d, meta)
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		deleteCommand := tkcore.DeleteRepositoryCommand{}
 		apprepoId, err := utils.Atoi32(d.Get("id_apprepo").(string))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 		deleteCommand.SetAppRepoId(apprepoId)
 		response, err2 := apiClient.Client.AppRepositoriesAPI.RepositoryDelete(ctx).DeleteRepositoryCommand(deleteCommand).Execute()
 		if err2 != nil {
-			return diag.FromErr(tk.CreateError(response, err2))
+			return utils.DiagnosticsFromApiError(response, err2)
 		}
 	}
 	d.SetId("")
 	return nil
 }
 
+// Only the URL of a private repository can be updated, a public repository is replaced as before
+func forceNewPublicRepositoryUrl(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.Get("private").(bool) && d.HasChange("url") {
+		return d.ForceNew("url")
+	}
+	return nil
+}
+
 func resourceTaikunRepositoryUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*tk.Client)
+
+	// Rotate the credentials or move the private repository, its catalog applications stay bound
+	if d.Get("private").(bool) && (d.HasChanges("url", "username") || utils.SecretHasChange(d, "password")) {
+		password, diags := utils.GetSecret(d, "password")
+		if diags.HasError() {
+			return diags
+		}
+		apprepoId, err := utils.Atoi32(d.Get("id_apprepo").(string))
+		if err != nil {
+			return utils.DiagnosticsFromError(err)
+		}
+
+		body := tkcore.UpdateRepositoryCommand{}
+		body.SetAppRepoId(apprepoId)
+		body.SetUrl(d.Get("url").(string))
+		body.SetUsername(d.Get("username").(string))
+		body.SetPassword(password)
+		response, err := apiClient.Client.AppRepositoriesAPI.RepositoryUpdate(ctx).UpdateRepositoryCommand(body).Execute()
+		if err != nil {
+			return utils.DiagnosticsFromApiError(response, err)
+		}
+
+		// The apps are listed again from the new URL or with the new credentials
+		if err := resourceTaikunPrivateRepositoryWaitForUpdate(ctx, d, meta); err != nil {
+			return utils.DiagnosticsFromError(err)
+		}
+	}
+
 	oldEnabled, newEnabled := d.GetChange("enabled")
 	err := ensureDesiredState(ctx, newEnabled.(bool), oldEnabled.(bool), d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	return utils.ReadAfterUpdateWithRetries(generateResourceTaikunRepositoryReadWithRetries(), ctx, d, meta)
@@ -144,7 +244,7 @@ func resourceTaikunRepositoryCreate(ctx context.Context, d *schema.ResourceData,
 
 	orgId, err := getSpecifiedOrDefaultOrganizationId(ctx, d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	if should_be_private {
@@ -152,7 +252,10 @@ func resourceTaikunRepositoryCreate(ctx context.Context, d *schema.ResourceData,
 		body_private := &tkcore.ImportRepoCommand{}
 		url_private := d.Get("url").(string)
 		username_private := d.Get("username").(string)
-		password_private := d.Get("password").(string)
+		password_private, diags := utils.GetSecret(d, "password")
+		if diags.HasError() {
+			return diags
+		}
 		body_private.SetName(name)
 		body_private.SetUrl(url_private)
 		body_private.SetUsername(username_private)
@@ -161,7 +264,7 @@ func resourceTaikunRepositoryCreate(ctx context.Context, d *schema.ResourceData,
 
 		response, err := apiClient.Client.AppRepositoriesAPI.RepositoryImport(ctx).ImportRepoCommand(*body_private).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(response, err))
+			return utils.DiagnosticsFromApiError(response, err)
 		}
 	}
 
@@ -202,12 +305,12 @@ func generateResourceTaikunRepositoryRead(withRetries bool) schema.ReadContextFu
 		private := d.Get("private").(bool)
 		orgId, err := getSpecifiedOrDefaultOrganizationId(ctx, d, meta)
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		data, response, err := apiClient.Client.AppRepositoriesAPI.RepositoryAvailableList(ctx).IsPrivate(private).Search(repositoryName).IsPrivate(private).OrganizationId(orgId).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(response, err))
+			return utils.DiagnosticsFromApiError(response, err)
 		}
 
 		// Iterate through data to find the correct Repository
@@ -222,17 +325,13 @@ func generateResourceTaikunRepositoryRead(withRetries bool) schema.ReadContextFu
 		}
 
 		if !foundMatch {
-			if withRetries {
-				d.SetId(d.Get("id").(string)) // We need to tell provider that object was created
-				return diag.FromErr(fmt.Errorf("could not find the specified repository (name: %s, organization: %s, private: %t)", repositoryName, organizationName, private))
-			}
-			return nil
+			return utils.ReadNotFound(d, d.Get("id").(string), withRetries)
 		}
 
 		// Load all the found data to the local object
 		err = utils.SetResourceDataFromMap(d, flattenTaikunRepository(orgId, &rawRepository, private))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		d.SetId(d.Get("id").(string)) // We need to tell provider that object was created
@@ -267,7 +366,7 @@ func getSpecifiedOrDefaultOrganizationId(ctx context.Context, d *schema.Resource
 	if !orgIdStringDeclared {
 		data, response, err := apiClient.Client.UsersAPI.UsersUserInfo(ctx).Execute()
 		if err != nil {
-			return -1, tk.CreateError(response, err)
+			return -1, utils.NewApiError(response, err)
 		}
 
 		// checking if user belongs to any organization
@@ -313,7 +412,7 @@ func ensureDesiredState(ctx context.Context, enabledNew bool, enabledCurrent boo
 		body.SetOrganizationId(orgId)
 		response, err := apiClient.Client.AppRepositoriesAPI.RepositoryUnbind(ctx).UnbindAppRepositoryCommand(*body).Execute()
 		if err != nil {
-			return tk.CreateError(response, err)
+			return utils.NewApiError(response, err)
 		}
 	}
 	// Disabled -> Enabled
@@ -326,7 +425,7 @@ func ensureDesiredState(ctx context.Context, enabledNew bool, enabledCurrent boo
 		body.SetOrganizationId(orgId)
 		response, err := apiClient.Client.AppRepositoriesAPI.RepositoryBind(ctx).BindAppRepositoryCommand(*body).Execute()
 		if err != nil {
-			return tk.CreateError(response, err)
+			return utils.NewApiError(response, err)
 		}
 	}
 
@@ -355,7 +454,7 @@ func resourceTaikunPrivateRepositoryWaitForApps(repositoryName string, ctx conte
 		Refresh: func() (interface{}, string, error) {
 			data, response, err := apiClient.Client.PackageAPI.PackageList(ctx).IsPrivate(true).FilterBy(repositoryName).Execute()
 			if err != nil {
-				return nil, "", tk.CreateError(response, err)
+				return nil, "", utils.NewApiError(response, err)
 			}
 
 			foundMatch := "pending"
@@ -370,10 +469,76 @@ func resourceTaikunPrivateRepositoryWaitForApps(repositoryName string, ctx conte
 		MinTimeout: 10 * time.Second,
 	}
 
-	_, err := createStateConf.WaitForStateContext(ctx)
+	_, err := utils.WaitForState(ctx, createStateConf)
 	if err != nil {
 		return fmt.Errorf("error waiting for repository (%s) to be read: %s", repositoryName, err)
 	}
 
 	return nil
 }
+
+// After the URL or the credentials of a private repository are updated, the apps listed before may still be those read
+// with the previous ones. The repository is read again once it reports the new URL and the versions of one of its apps
+// can be listed, which reads the repository with the new credentials.
+func resourceTaikunPrivateRepositoryWaitForUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	apiClient := meta.(*tk.Client)
+	repositoryName := d.Get("name").(string)
+	organizationName := d.Get("organization_name").(string)
+	url := d.Get("url").(string)
+	orgId, err := getSpecifiedOrDefaultOrganizationId(ctx, d, meta)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	updateStateConf := &retry.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"finished"},
+		Refresh: func() (interface{}, string, error) {
+			repositories, response, err := apiClient.Client.AppRepositoriesAPI.RepositoryAvailableList(ctx).IsPrivate(true).Search(repositoryName).OrganizationId(orgId).Execute()
+			if err != nil {
+				return nil, "", utils.NewApiError(response, err)
+			}
+			urlUpdated := false
+			for _, repo := range repositories.GetData() {
+				if repo.GetName() == repositoryName && repo.GetOrganizationName() == organizationName {
+					urlUpdated = repo.GetUrl() == url
+					break
+				}
+			}
+			if !urlUpdated {
+				lastErr = fmt.Errorf("the repository does not report the URL %s yet", url)
+				return repositories, "pending", nil
+			}
+
+			packages, response, err := apiClient.Client.PackageAPI.PackageList(ctx).IsPrivate(true).FilterBy(repositoryName).Execute()
+			if err != nil {
+				return nil, "", utils.NewApiError(response, err)
+			}
+			for _, rawPackage := range packages.GetData() {
+				// FilterBy also matches the names of the packages
+				if rawPackage.Repository.GetName() != repositoryName {
+					continue
+				}
+				if _, lastErr = utils.GetPackageVersions(ctx, repositoryName, rawPackage.GetName(), apiClient); lastErr != nil {
+					return packages, "pending", nil
+				}
+				return packages, "finished", nil
+			}
+			lastErr = errors.New("no apps are listed yet")
+			return packages, "pending", nil
+		},
+		Timeout:    5 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	if _, err := utils.WaitForState(ctx, updateStateConf); err != nil {
+		if lastErr != nil {
+			return fmt.Errorf("error waiting for repository (%s) to be read again: %s: %s", repositoryName, err, lastErr)
+		}
+		return fmt.Errorf("error waiting for repository (%s) to be read again: %s", repositoryName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package testing
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils_testing"
+	"testing"
+)
+
+const testAccDataSourceTaikunPackagesConfig = `
+data "taikun_packages" "foo" {
+  repository = "%s"
+  name_regex = "%s"
+}
+`
+
+func TestAccDataSourceTaikunPackages(t *testing.T) {
+	repositoryName := "taikun-managed-apps"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { utils_testing.TestAccPreCheck(t) },
+		ProviderFactories: utils_testing.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccDataSourceTaikunPackagesConfig,
+					repositoryName,
+					"^wordpress$",
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.taikun_packages.foo", "packages.#", "1"),
+					resource.TestCheckResourceAttr("data.taikun_packages.foo", "packages.0.name", "wordpress"),
+					resource.TestCheckResourceAttr("data.taikun_packages.foo", "packages.0.repository", repositoryName),
+					resource.TestCheckResourceAttrSet("data.taikun_packages.foo", "packages.0.package_id"),
+					resource.TestCheckResourceAttrSet("data.taikun_packages.foo", "packages.0.latest_version"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccDataSourceTaikunPackagesConfig,
+					repositoryName,
+					"^no-such-package-[0-9]+$",
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.taikun_packages.foo", "packages.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,59 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils_testing"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils_testing/faketaikun"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testUnitDataSourceTaikunPackagesConfig = `
+data "taikun_packages" "all" {
+  repository = "taikun-managed-apps"
+}
+
+data "taikun_packages" "ingress" {
+  repository         = "taikun-managed-apps"
+  name_regex         = "^ingress-nginx$"
+  version_constraint = ">= 4.0, < 4.11"
+}
+
+data "taikun_packages" "none" {
+  repository         = "taikun-managed-apps"
+  version_constraint = ">= 10.0"
+}
+`
+
+// TestUnitDataSourceTaikunPackages runs against the fake Taikun server.
+// The constraint is checked against every version of the packages, not only the latest one, and tags which are not versions are skipped.
+func TestUnitDataSourceTaikunPackages(t *testing.T) {
+	server := faketaikun.Start(t)
+	server.AddPackage("taikun-managed-apps", "ingress-nginx", "latest", "4.11.2", "4.10.1", "4.9.0", "3.40.0")
+	server.AddPackage("taikun-managed-apps", "wordpress", "24.0.0", "23.1.0")
+	server.AddPackage("other-apps", "wordpress", "1.0.0")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: utils_testing.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitDataSourceTaikunPackagesConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.taikun_packages.all", "packages.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.taikun_packages.all", "packages.*", map[string]string{
+						"name":             "wordpress",
+						"repository":       "taikun-managed-apps",
+						"latest_version":   "24.0.0",
+						"matching_version": "24.0.0",
+					}),
+					resource.TestCheckResourceAttr("data.taikun_packages.ingress", "packages.#", "1"),
+					resource.TestCheckResourceAttr("data.taikun_packages.ingress", "packages.0.name", "ingress-nginx"),
+					resource.TestCheckResourceAttr("data.taikun_packages.ingress", "packages.0.latest_version", "latest"),
+					resource.TestCheckResourceAttr("data.taikun_packages.ingress", "packages.0.matching_version", "4.10.1"),
+					resource.TestCheckResourceAttr("data.taikun_packages.none", "packages.#", "0"),
+				),
+			},
+		},
+	})
+}
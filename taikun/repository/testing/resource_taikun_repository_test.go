@@ -10,6 +10,7 @@ import (
 	tk "github.com/itera-io/taikungoclient"
 	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
 	"github.com/itera-io/terraform-provider-taikun/taikun/utils_testing"
+	"os"
 	"testing"
 )
 
@@ -47,6 +48,12 @@ func TestAccResourceTaikunRepository(t *testing.T) {
 					resource.TestCheckResourceAttr("taikun_repository.foo", "enabled", repositoryEnabled),
 				),
 			},
+			{
+				ResourceName:      "taikun_repository.foo",
+				ImportState:       true,
+				ImportStateIdFunc: testAccResourceTaikunRepositoryImportStateId,
+				ImportStateVerify: true,
+			},
 			// We cannot guarantee that argoproj project will be present in staging and dev and we cannot gurantee that taikun-managed-apps will be disableable.
 			//{
 			//	Config: fmt.Sprintf(testAccResourceTaikunRepositoryConfig,
@@ -66,6 +73,95 @@ func TestAccResourceTaikunRepository(t *testing.T) {
 	})
 }
 
+const testAccResourceTaikunPrivateRepositoryConfig = `
+resource "taikun_repository" "foo" {
+  name                = "%s"
+  private             = true
+  url                 = "%s"
+  username            = "%s"
+  password_wo         = "%s"
+  password_wo_version = %d
+}
+`
+
+// testAccPreCheckPrivateRepository ensures a private Helm repository and two sets of credentials for it are available.
+func testAccPreCheckPrivateRepository(t *testing.T) {
+	t.Helper()
+	utils_testing.TestAccPreCheck(t)
+	for _, name := range []string{
+		"TAIKUN_PRIVATE_REPOSITORY_URL",
+		"TAIKUN_PRIVATE_REPOSITORY_USERNAME",
+		"TAIKUN_PRIVATE_REPOSITORY_PASSWORD",
+		"TAIKUN_PRIVATE_REPOSITORY_OTHER_USERNAME",
+		"TAIKUN_PRIVATE_REPOSITORY_OTHER_PASSWORD",
+	} {
+		if os.Getenv(name) == "" {
+			t.Skipf("%s must be set to run this test", name)
+		}
+	}
+}
+
+// TestAccResourceTaikunPrivateRepository verifies that the credentials of a private repository are rotated in place
+func TestAccResourceTaikunPrivateRepository(t *testing.T) {
+	repositoryName := utils.ShortRandomTestName()
+	url := os.Getenv("TAIKUN_PRIVATE_REPOSITORY_URL")
+	var repositoryId string
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckPrivateRepository(t) },
+		ProviderFactories: utils_testing.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckTaikunRepositoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccResourceTaikunPrivateRepositoryConfig,
+					repositoryName,
+					url,
+					os.Getenv("TAIKUN_PRIVATE_REPOSITORY_USERNAME"),
+					os.Getenv("TAIKUN_PRIVATE_REPOSITORY_PASSWORD"),
+					1,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunRepositoryExists,
+					resource.TestCheckResourceAttr("taikun_repository.foo", "private", "true"),
+					resource.TestCheckResourceAttr("taikun_repository.foo", "url", url),
+					func(state *terraform.State) error {
+						repositoryId = state.RootModule().Resources["taikun_repository.foo"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccResourceTaikunPrivateRepositoryConfig,
+					repositoryName,
+					url,
+					os.Getenv("TAIKUN_PRIVATE_REPOSITORY_OTHER_USERNAME"),
+					os.Getenv("TAIKUN_PRIVATE_REPOSITORY_OTHER_PASSWORD"),
+					2,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunRepositoryExists,
+					resource.TestCheckResourceAttr("taikun_repository.foo", "username", os.Getenv("TAIKUN_PRIVATE_REPOSITORY_OTHER_USERNAME")),
+					resource.TestCheckResourceAttr("taikun_repository.foo", "password_wo_version", "2"),
+					func(state *terraform.State) error {
+						if id := state.RootModule().Resources["taikun_repository.foo"].Primary.ID; id != repositoryId {
+							return fmt.Errorf("expected the repository %s to be updated in place, got the repository %s", repositoryId, id)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceTaikunRepositoryImportStateId(state *terraform.State) (string, error) {
+	rs, found := state.RootModule().Resources["taikun_repository.foo"]
+	if !found {
+		return "", errors.New("taikun_repository.foo not found in state")
+	}
+	return fmt.Sprintf("%s/%s/%s", rs.Primary.Attributes["organization_id"], rs.Primary.Attributes["organization_name"], rs.Primary.Attributes["name"]), nil
+}
+
 func testAccCheckTaikunRepositoryExists(state *terraform.State) error {
 	client := utils_testing.TestAccProvider.Meta().(*tk.Client)
 
@@ -30,6 +30,7 @@ func resourceTaikunRobotSchema() map[string]*schema.Schema {
 			Description: "Organization ID for the robot user.",
 			Type:        schema.TypeInt,
 			Optional:    true,
+			Computed:    true,
 			ForceNew:    true,
 		},
 		"description": {
@@ -97,6 +98,7 @@ func ResourceTaikunRobot() *schema.Resource {
 		UpdateContext: resourceTaikunRobotUpdate,
 		DeleteContext: resourceTaikunRobotDelete,
 		Schema:        resourceTaikunRobotSchema(),
+		CustomizeDiff: utils.SetDefaultOrganizationID,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -141,7 +143,7 @@ func resourceTaikunRobotCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	response, res, err := apiClient.Client.RobotAPI.RobotCreate(ctx).CreateRobotUserCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	_ = d.Set("access_key", response.GetAccessKey())
@@ -179,11 +181,7 @@ func generateResourceTaikunRobotRead(withRetries bool) schema.ReadContextFunc {
 		}
 
 		if robot == nil {
-			if withRetries {
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-			}
-			d.SetId("")
-			return nil
+			return utils.ReadNotFound(d, d.Id(), withRetries)
 		}
 
 		err := utils.SetResourceDataFromMap(d, flattenTaikunRobot(robot))
@@ -222,7 +220,7 @@ func resourceTaikunRobotUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 		res, err := apiClient.Client.RobotAPI.RobotUpdate(ctx).EditRobotUserCommand(body).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 	}
 
@@ -242,7 +240,7 @@ func resourceTaikunRobotUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 		res, err := apiClient.Client.RobotAPI.RobotUpdateScope(ctx).UpdateRobotScopeCommand(body).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 	}
 
@@ -258,7 +256,7 @@ func resourceTaikunRobotUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 		response, res, err := apiClient.Client.RobotAPI.RobotRegenerate(ctx).RegenerateRobotTokenCommand(body).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 
 		_ = d.Set("access_key", response.GetAccessKey())
@@ -276,7 +274,7 @@ func resourceTaikunRobotDelete(ctx context.Context, d *schema.ResourceData, meta
 		if res != nil && res.StatusCode == 404 {
 			return nil
 		}
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	d.SetId("")
@@ -286,7 +284,7 @@ func resourceTaikunRobotDelete(ctx context.Context, d *schema.ResourceData, meta
 func findRobotByUserId(ctx context.Context, apiClient *tk.Client, userId string) (*tkcore.RobotUsersListDto, diag.Diagnostics) {
 	response, res, err := apiClient.Client.RobotAPI.RobotList(ctx).SearchId(userId).Execute()
 	if err != nil {
-		return nil, diag.FromErr(tk.CreateError(res, err))
+		return nil, utils.DiagnosticsFromApiError(res, err)
 	}
 
 	for i := range response.GetData() {
@@ -306,7 +304,7 @@ func findRobotByAccessKey(ctx context.Context, apiClient *tk.Client, accountId i
 	}
 	response, res, err := request.Execute()
 	if err != nil {
-		return nil, diag.FromErr(tk.CreateError(res, err))
+		return nil, utils.DiagnosticsFromApiError(res, err)
 	}
 
 	for i := range response.GetData() {
@@ -12,6 +12,7 @@ func dataSourceTaikunShowbackCredentialSchema() map[string]*schema.Schema {
 	dsSchema := utils.DataSourceSchemaFromResourceSchema(resourceTaikunShowbackCredentialSchema())
 	utils.AddRequiredFieldsToSchema(dsSchema, "id")
 	utils.SetValidateDiagFuncToSchema(dsSchema, "id", utils.StringIsInt)
+	utils.DeleteFieldsFromSchema(dsSchema, "password_wo", "password_wo_version")
 	return dsSchema
 }
 
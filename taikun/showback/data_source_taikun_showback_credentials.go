@@ -54,7 +54,7 @@ func dataSourceTaikunShowbackCredentialsRead(ctx context.Context, d *schema.Reso
 	for {
 		response, resp, err := params.Offset(offset).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(resp, err))
+			return utils.DiagnosticsFromApiError(resp, err)
 		}
 		showbackCredentialsList = append(showbackCredentialsList, response.GetData()...)
 		if len(showbackCredentialsList) == int(response.GetTotalCount()) {
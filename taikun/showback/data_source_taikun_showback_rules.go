@@ -54,7 +54,7 @@ func dataSourceTaikunShowbackRulesRead(ctx context.Context, d *schema.ResourceDa
 	for {
 		response, resp, err := params.Offset(offset).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(resp, err))
+			return utils.DiagnosticsFromApiError(resp, err)
 		}
 		showbackRulesList = append(showbackRulesList, response.GetData()...)
 		if len(showbackRulesList) == int(response.GetTotalCount()) {
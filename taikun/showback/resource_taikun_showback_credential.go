@@ -13,7 +13,7 @@ import (
 )
 
 func resourceTaikunShowbackCredentialSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
+	resourceSchema := map[string]*schema.Schema{
 		"created_by": {
 			Description: "The creator of the showback credential.",
 			Type:        schema.TypeString,
@@ -83,6 +83,8 @@ func resourceTaikunShowbackCredentialSchema() map[string]*schema.Schema {
 			ValidateFunc: validation.StringIsNotEmpty,
 		},
 	}
+	utils.AddWriteOnlySecret(resourceSchema, "password")
+	return resourceSchema
 }
 
 func ResourceTaikunShowbackCredential() *schema.Resource {
@@ -93,6 +95,7 @@ func ResourceTaikunShowbackCredential() *schema.Resource {
 		UpdateContext: resourceTaikunShowbackCredentialUpdate,
 		DeleteContext: resourceTaikunShowbackCredentialDelete,
 		Schema:        resourceTaikunShowbackCredentialSchema(),
+		CustomizeDiff: utils.SetDefaultOrganizationID,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -102,9 +105,14 @@ func ResourceTaikunShowbackCredential() *schema.Resource {
 func resourceTaikunShowbackCredentialCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(*tk.Client)
 
+	password, diags := utils.GetRequiredSecret(d, "password")
+	if diags.HasError() {
+		return diags
+	}
+
 	body := tkshowback.CreateShowbackCredentialCommand{}
 	body.SetName(d.Get("name").(string))
-	body.SetPassword(d.Get("password").(string))
+	body.SetPassword(password)
 	body.SetUrl(d.Get("url").(string))
 	body.SetUsername(d.Get("username").(string))
 
@@ -113,25 +121,25 @@ func resourceTaikunShowbackCredentialCreate(ctx context.Context, d *schema.Resou
 		organizationId, err := utils.Atoi32(organizationIDData.(string))
 		if err != nil {
 			//return diag.Errorf("organization_id isn't valid: %s", d.Get("organization_id").(string))
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 		body.SetOrganizationId(organizationId)
 	}
 
 	createResult, resp, err := apiClient.ShowbackClient.ShowbackCredentialsAPI.ShowbackcredentialsCreate(ctx).CreateShowbackCredentialCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(resp, err))
+		return utils.DiagnosticsFromApiError(resp, err)
 	}
 	id, err := utils.Atoi32(createResult.GetId())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	d.SetId(createResult.GetId())
 
 	if d.Get("lock").(bool) {
 		if err := resourceTaikunShowbackCredentialLock(ctx, id, true, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -149,26 +157,22 @@ func generateResourceTaikunShowbackCredentialRead(withRetries bool) schema.ReadC
 		id, err := utils.Atoi32(d.Id())
 		d.SetId("")
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		response, resp, err := apiClient.ShowbackClient.ShowbackCredentialsAPI.ShowbackcredentialsList(ctx).Id(id).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(resp, err))
+			return utils.DiagnosticsFromApiError(resp, err)
 		}
 		if len(response.GetData()) != 1 {
-			if withRetries {
-				d.SetId(utils.I32toa(id))
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-			}
-			return nil
+			return utils.ReadNotFound(d, utils.I32toa(id), withRetries)
 		}
 
 		rawShowbackCredential := response.GetData()[0]
 
 		err = utils.SetResourceDataFromMap(d, flattenTaikunShowbackCredential(&rawShowbackCredential))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		d.SetId(utils.I32toa(id))
@@ -181,12 +185,12 @@ func resourceTaikunShowbackCredentialUpdate(ctx context.Context, d *schema.Resou
 	apiClient := meta.(*tk.Client)
 	id, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	if d.HasChange("lock") {
 		if err := resourceTaikunShowbackCredentialLock(ctx, id, d.Get("lock").(bool), apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -197,12 +201,12 @@ func resourceTaikunShowbackCredentialDelete(ctx context.Context, d *schema.Resou
 	apiClient := meta.(*tk.Client)
 	id, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	resp, err := apiClient.ShowbackClient.ShowbackCredentialsAPI.ShowbackcredentialsDelete(ctx, id).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(resp, err))
+		return utils.DiagnosticsFromApiError(resp, err)
 	}
 
 	d.SetId("")
@@ -232,5 +236,5 @@ func resourceTaikunShowbackCredentialLock(ctx context.Context, id int32, lock bo
 	body.SetMode(utils.GetLockMode(lock))
 
 	resp, err := apiClient.ShowbackClient.ShowbackCredentialsAPI.ShowbackcredentialsLockManagement(ctx).ShowbackCredentialLockCommand(body).Execute()
-	return tk.CreateError(resp, err)
+	return utils.NewApiError(resp, err)
 }
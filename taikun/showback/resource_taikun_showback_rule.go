@@ -140,6 +140,7 @@ func ResourceTaikunShowbackRule() *schema.Resource {
 		UpdateContext: resourceTaikunShowbackRuleUpdate,
 		DeleteContext: resourceTaikunShowbackRuleDelete,
 		Schema:        resourceTaikunShowbackRuleSchema(),
+		CustomizeDiff: utils.SetDefaultOrganizationID,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -188,7 +189,7 @@ func resourceTaikunShowbackRuleCreate(ctx context.Context, d *schema.ResourceDat
 
 	createResult, resp, err := apiClient.ShowbackClient.ShowbackRulesAPI.ShowbackrulesCreate(ctx).CreateShowbackRuleCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(resp, err))
+		return utils.DiagnosticsFromApiError(resp, err)
 	}
 
 	d.SetId(createResult.GetId())
@@ -212,14 +213,10 @@ func generateResourceTaikunShowbackRuleRead(withRetries bool) schema.ReadContext
 
 		response, resp, err := apiClient.ShowbackClient.ShowbackRulesAPI.ShowbackrulesList(ctx).Id(id).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(resp, err))
+			return utils.DiagnosticsFromApiError(resp, err)
 		}
 		if len(response.GetData()) != 1 {
-			if withRetries {
-				d.SetId(utils.I32toa(id))
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-			}
-			return nil
+			return utils.ReadNotFound(d, utils.I32toa(id), withRetries)
 		}
 
 		rawShowbackRule := response.GetData()[0]
@@ -264,7 +261,7 @@ func resourceTaikunShowbackRuleUpdate(ctx context.Context, d *schema.ResourceDat
 
 	resp, err := apiClient.ShowbackClient.ShowbackRulesAPI.ShowbackrulesUpdate(ctx).UpdateShowbackRuleCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(resp, err))
+		return utils.DiagnosticsFromApiError(resp, err)
 	}
 
 	return utils.ReadAfterUpdateWithRetries(generateResourceTaikunShowbackRuleReadWithRetries(), ctx, d, meta)
@@ -279,7 +276,7 @@ func resourceTaikunShowbackRuleDelete(ctx context.Context, d *schema.ResourceDat
 
 	resp, err := apiClient.ShowbackClient.ShowbackRulesAPI.ShowbackrulesDelete(ctx, id).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(resp, err))
+		return utils.DiagnosticsFromApiError(resp, err)
 	}
 
 	d.SetId("")
@@ -0,0 +1,28 @@
+//go:build sweep
+
+package showback
+
+import (
+	"context"
+
+	tk "github.com/itera-io/taikungoclient"
+	tkshowback "github.com/itera-io/taikungoclient/showbackclient"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+)
+
+// Delete the showback credentials left behind by the acceptance tests, once the projects using them are deleted
+func AddTestSweepers(client utils.SweeperClientFunc) {
+	utils.AddListTestSweeper("taikun_showback_credential", client, []string{"taikun_project"},
+		func(ctx context.Context, apiClient *tk.Client, offset int32) ([]tkshowback.ShowbackCredentialsListDto, int, error) {
+			response, res, err := apiClient.ShowbackClient.ShowbackCredentialsAPI.ShowbackcredentialsList(ctx).Offset(offset).Execute()
+			if err != nil {
+				return nil, 0, utils.NewApiError(res, err)
+			}
+			return response.GetData(), int(response.GetTotalCount()), nil
+		},
+		(*tkshowback.ShowbackCredentialsListDto).GetName,
+		func(ctx context.Context, apiClient *tk.Client, showbackCredential *tkshowback.ShowbackCredentialsListDto) error {
+			return utils.SweepLockableResource(ctx, ResourceTaikunShowbackCredential(), showbackCredential.GetId(), showbackCredential.GetIsLocked(), resourceTaikunShowbackCredentialLock, apiClient)
+		},
+	)
+}
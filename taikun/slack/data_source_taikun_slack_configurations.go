@@ -54,7 +54,7 @@ func dataSourceTaikunSlackConfigurationsRead(ctx context.Context, d *schema.Reso
 	for {
 		response, res, err := params.Offset(offset).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		slackConfigurationsList = append(slackConfigurationsList, response.Data...)
 		if len(slackConfigurationsList) == int(response.GetTotalCount()) {
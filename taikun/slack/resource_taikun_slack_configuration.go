@@ -76,7 +76,8 @@ func ResourceTaikunSlackConfiguration() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Schema: resourceTaikunSlackConfigurationSchema(),
+		Schema:        resourceTaikunSlackConfigurationSchema(),
+		CustomizeDiff: utils.SetDefaultOrganizationID,
 	}
 }
 
@@ -99,7 +100,7 @@ func resourceTaikunSlackConfigurationCreate(ctx context.Context, d *schema.Resou
 
 	response, res, err := apiClient.Client.SlackAPI.SlackCreate(ctx).CreateSlackConfigurationCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	d.SetId(response.GetId())
@@ -124,14 +125,10 @@ func generateResourceTaikunSlackConfigurationRead(withRetries bool) schema.ReadC
 
 		response, res, err := apiClient.Client.SlackAPI.SlackList(ctx).Id(id).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		if len(response.Data) != 1 {
-			if withRetries {
-				d.SetId(utils.I32toa(id))
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-			}
-			return nil
+			return utils.ReadNotFound(d, utils.I32toa(id), withRetries)
 		}
 
 		rawSlackConfiguration := response.Data[0]
@@ -170,7 +167,7 @@ func resourceTaikunSlackConfigurationUpdate(ctx context.Context, d *schema.Resou
 	}
 
 	if res, err := apiClient.Client.SlackAPI.SlackUpdate(ctx, id).UpdateSlackConfigurationDto(body).Execute(); err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	return utils.ReadAfterUpdateWithRetries(generateResourceTaikunSlackConfigurationReadWithRetries(), ctx, d, meta)
@@ -188,7 +185,7 @@ func resourceTaikunSlackConfigurationDelete(ctx context.Context, d *schema.Resou
 	res, err := apiClient.Client.SlackAPI.SlackDeleteMultiple(ctx).DeleteSlackConfigCommand(body).Execute()
 
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	d.SetId("")
@@ -54,7 +54,7 @@ func dataSourceTaikunStandaloneProfilesRead(ctx context.Context, d *schema.Resou
 	for {
 		response, res, err := params.Offset(offset).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		standaloneProfilesListDtos = append(standaloneProfilesListDtos, response.GetData()...)
 		if len(standaloneProfilesListDtos) == int(response.GetTotalCount()) {
@@ -68,7 +68,7 @@ func dataSourceTaikunStandaloneProfilesRead(ctx context.Context, d *schema.Resou
 
 		securityGroupResponse, res, err := apiClient.Client.SecurityGroupAPI.SecuritygroupList(ctx, rawStandaloneProfile.GetId()).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 
 		standaloneProfiles[i] = flattenTaikunStandaloneProfile(&rawStandaloneProfile, securityGroupResponse)
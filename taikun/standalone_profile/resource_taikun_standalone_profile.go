@@ -133,6 +133,7 @@ func ResourceTaikunStandaloneProfile() *schema.Resource {
 		UpdateContext: resourceTaikunStandaloneProfileUpdate,
 		DeleteContext: resourceTaikunStandaloneProfileDelete,
 		Schema:        resourceTaikunStandaloneProfileSchema(),
+		CustomizeDiff: utils.SetDefaultOrganizationID,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -172,18 +173,18 @@ func resourceTaikunStandaloneProfileCreate(ctx context.Context, d *schema.Resour
 
 	createResult, res, err := apiClient.Client.StandaloneProfileAPI.StandaloneprofileCreate(ctx).StandAloneProfileCreateCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 	id, err := utils.Atoi32(createResult.GetId())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	d.SetId(createResult.GetId())
 
 	if d.Get("lock").(bool) {
 		if err := resourceTaikunStandaloneProfileLock(ctx, id, true, apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -201,19 +202,15 @@ func generateResourceTaikunStandaloneProfileRead(withRetries bool) schema.ReadCo
 		id, err := utils.Atoi32(d.Id())
 		d.SetId("")
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		response, res, err := apiClient.Client.StandaloneProfileAPI.StandaloneprofileList(ctx).Id(id).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		if len(response.GetData()) != 1 {
-			if withRetries {
-				d.SetId(utils.I32toa(id))
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-			}
-			return nil
+			return utils.ReadNotFound(d, utils.I32toa(id), withRetries)
 		}
 
 		rawStandaloneProfile := response.GetData()[0]
@@ -228,12 +225,12 @@ func generateResourceTaikunStandaloneProfileRead(withRetries bool) schema.ReadCo
 				}
 
 			*/
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 
 		err = utils.SetResourceDataFromMap(d, flattenTaikunStandaloneProfile(&rawStandaloneProfile, securityGroupResponse))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		d.SetId(utils.I32toa(id))
@@ -247,7 +244,7 @@ func resourceTaikunStandaloneProfileUpdate(ctx context.Context, d *schema.Resour
 
 	id, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	if d.HasChange("name") {
@@ -257,13 +254,13 @@ func resourceTaikunStandaloneProfileUpdate(ctx context.Context, d *schema.Resour
 
 		res, err := apiClient.Client.StandaloneProfileAPI.StandaloneprofileEdit(ctx).StandAloneProfileUpdateCommand(body).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 	}
 
 	if d.HasChange("lock") {
 		if err := resourceTaikunStandaloneProfileLock(ctx, id, d.Get("lock").(bool), apiClient); err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 	}
 
@@ -276,11 +273,11 @@ func resourceTaikunStandaloneProfileUpdate(ctx context.Context, d *schema.Resour
 			rawSecurityGroup := e.(map[string]interface{})
 			secId, err := utils.Atoi32(rawSecurityGroup["id"].(string))
 			if err != nil {
-				return diag.FromErr(err)
+				return utils.DiagnosticsFromError(err)
 			}
 			res, err := apiClient.Client.SecurityGroupAPI.SecuritygroupDelete(ctx, secId).Execute()
 			if err != nil {
-				return diag.FromErr(tk.CreateError(res, err))
+				return utils.DiagnosticsFromApiError(res, err)
 			}
 		}
 
@@ -298,7 +295,7 @@ func resourceTaikunStandaloneProfileUpdate(ctx context.Context, d *schema.Resour
 
 			_, res, err := apiClient.Client.SecurityGroupAPI.SecuritygroupCreate(ctx).CreateSecurityGroupCommand(body).Execute()
 			if err != nil {
-				return diag.FromErr(tk.CreateError(res, err))
+				return utils.DiagnosticsFromApiError(res, err)
 			}
 		}
 	}
@@ -310,7 +307,7 @@ func resourceTaikunStandaloneProfileDelete(ctx context.Context, d *schema.Resour
 	apiClient := meta.(*tk.Client)
 	id, err := utils.Atoi32(d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	body := tkcore.DeleteStandAloneProfileCommand{}
@@ -318,7 +315,7 @@ func resourceTaikunStandaloneProfileDelete(ctx context.Context, d *schema.Resour
 
 	res, err := apiClient.Client.StandaloneProfileAPI.StandaloneprofileDelete(ctx).DeleteStandAloneProfileCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	d.SetId("")
@@ -360,5 +357,5 @@ func resourceTaikunStandaloneProfileLock(ctx context.Context, id int32, lock boo
 	body.SetMode(utils.GetLockMode(lock))
 
 	res, err := apiClient.Client.StandaloneProfileAPI.StandaloneprofileLockManagement(ctx).StandAloneProfileLockManagementCommand(body).Execute()
-	return tk.CreateError(res, err)
+	return utils.NewApiError(res, err)
 }
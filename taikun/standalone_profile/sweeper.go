@@ -0,0 +1,28 @@
+//go:build sweep
+
+package standalone_profile
+
+import (
+	"context"
+
+	tk "github.com/itera-io/taikungoclient"
+	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+)
+
+// Delete the standalone profiles left behind by the acceptance tests, once the projects using them are deleted
+func AddTestSweepers(client utils.SweeperClientFunc) {
+	utils.AddListTestSweeper("taikun_standalone_profile", client, []string{"taikun_project"},
+		func(ctx context.Context, apiClient *tk.Client, offset int32) ([]tkcore.StandAloneProfilesListDto, int, error) {
+			response, res, err := apiClient.Client.StandaloneProfileAPI.StandaloneprofileList(ctx).Offset(offset).Execute()
+			if err != nil {
+				return nil, 0, utils.NewApiError(res, err)
+			}
+			return response.GetData(), int(response.GetTotalCount()), nil
+		},
+		(*tkcore.StandAloneProfilesListDto).GetName,
+		func(ctx context.Context, apiClient *tk.Client, standaloneProfile *tkcore.StandAloneProfilesListDto) error {
+			return utils.SweepLockableResource(ctx, ResourceTaikunStandaloneProfile(), standaloneProfile.GetId(), standaloneProfile.GetIsLocked(), resourceTaikunStandaloneProfileLock, apiClient)
+		},
+	)
+}
@@ -0,0 +1,77 @@
+//go:build sweep
+
+package sweep
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	tk "github.com/itera-io/taikungoclient"
+	"github.com/itera-io/terraform-provider-taikun/taikun/access_profile"
+	"github.com/itera-io/terraform-provider-taikun/taikun/alerting_profile"
+	"github.com/itera-io/terraform-provider-taikun/taikun/app_instance"
+	"github.com/itera-io/terraform-provider-taikun/taikun/backup_credential"
+	"github.com/itera-io/terraform-provider-taikun/taikun/billing"
+	"github.com/itera-io/terraform-provider-taikun/taikun/cc_aws"
+	"github.com/itera-io/terraform-provider-taikun/taikun/cc_azure"
+	"github.com/itera-io/terraform-provider-taikun/taikun/cc_gcp"
+	"github.com/itera-io/terraform-provider-taikun/taikun/cc_openstack"
+	"github.com/itera-io/terraform-provider-taikun/taikun/cc_proxmox"
+	"github.com/itera-io/terraform-provider-taikun/taikun/cc_vsphere"
+	"github.com/itera-io/terraform-provider-taikun/taikun/cc_zadara"
+	"github.com/itera-io/terraform-provider-taikun/taikun/kubernetes_profile"
+	"github.com/itera-io/terraform-provider-taikun/taikun/organization"
+	"github.com/itera-io/terraform-provider-taikun/taikun/policy_profile"
+	"github.com/itera-io/terraform-provider-taikun/taikun/project"
+	"github.com/itera-io/terraform-provider-taikun/taikun/provider"
+	"github.com/itera-io/terraform-provider-taikun/taikun/showback"
+	"github.com/itera-io/terraform-provider-taikun/taikun/standalone_profile"
+	"github.com/itera-io/terraform-provider-taikun/taikun/user"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+	"github.com/itera-io/terraform-provider-taikun/taikun/virtual_cluster"
+)
+
+// Run the sweepers with `make sweep`, they delete every object named with the prefix of the acceptance tests
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	for _, addTestSweepers := range []func(utils.SweeperClientFunc){
+		app_instance.AddTestSweepers,
+		virtual_cluster.AddTestSweepers,
+		project.AddTestSweepers,
+		access_profile.AddTestSweepers,
+		alerting_profile.AddTestSweepers,
+		kubernetes_profile.AddTestSweepers,
+		policy_profile.AddTestSweepers,
+		standalone_profile.AddTestSweepers,
+		cc_aws.AddTestSweepers,
+		cc_azure.AddTestSweepers,
+		cc_gcp.AddTestSweepers,
+		cc_openstack.AddTestSweepers,
+		cc_proxmox.AddTestSweepers,
+		cc_vsphere.AddTestSweepers,
+		cc_zadara.AddTestSweepers,
+		backup_credential.AddTestSweepers,
+		billing.AddTestSweepers,
+		showback.AddTestSweepers,
+		user.AddTestSweepers,
+		organization.AddTestSweepers,
+	} {
+		addTestSweepers(sharedClient)
+	}
+}
+
+// The provider is configured once from the environment, like in the acceptance tests
+var sharedClient = sync.OnceValues(func() (*tk.Client, error) {
+	taikunProvider := provider.Provider()
+	if diagnostics := taikunProvider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{})); diagnostics.HasError() {
+		return nil, fmt.Errorf("unable to configure the provider: %v", diagnostics)
+	}
+	return taikunProvider.Meta().(*tk.Client), nil
+})
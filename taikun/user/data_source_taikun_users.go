@@ -50,7 +50,7 @@ func dataSourceTaikunUsersRead(ctx context.Context, d *schema.ResourceData, meta
 
 		dropdownRes, res, err := apiClient.Client.UsersAPI.UsersDropdown(ctx).OrganizationId(organizationID).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 
 		for _, u := range dropdownRes.GetData() {
@@ -70,7 +70,7 @@ func dataSourceTaikunUsersRead(ctx context.Context, d *schema.ResourceData, meta
 		searchBody := tkcore.UsersSearchCommand{}
 		searchRes, res, err := apiClient.Client.SearchAPI.SearchUsers(ctx).UsersSearchCommand(searchBody).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 		rawUserList = searchRes.GetData()
 	}
@@ -113,7 +113,7 @@ func resourceTaikunUserCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	result, res, err := apiClient.Client.UsersAPI.UsersCreate(ctx).CreateUserCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	d.SetId(result.GetId())
@@ -139,7 +139,7 @@ func generateResourceTaikunUserRead(withRetries bool) schema.ReadContextFunc {
 		searchBody.SetSearchTerm(id)
 		searchRes, res, err := apiClient.Client.SearchAPI.SearchUsers(ctx).UsersSearchCommand(searchBody).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 
 		var accountId int32
@@ -153,16 +153,12 @@ func generateResourceTaikunUserRead(withRetries bool) schema.ReadContextFunc {
 		}
 
 		if !found {
-			if withRetries {
-				d.SetId(id)
-				return diag.Errorf(utils.NotFoundAfterCreateOrUpdateError)
-			}
-			return nil
+			return utils.ReadNotFound(d, id, withRetries)
 		}
 
 		response, res, err := apiClient.Client.AccountsAPI.AccountsAccountUserDetails(ctx, accountId, id).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 
 		err = utils.SetResourceDataFromMap(d, flattenTaikunUser(response, accountId))
@@ -188,7 +184,7 @@ func resourceTaikunUserUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 	res, err := apiClient.Client.UsersAPI.UsersUpdateUser(ctx).UpdateUserCommand(body).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	return utils.ReadAfterUpdateWithRetries(generateResourceTaikunUserReadWithRetries(), ctx, d, meta)
@@ -199,7 +195,7 @@ func resourceTaikunUserDelete(ctx context.Context, d *schema.ResourceData, meta
 
 	res, err := apiClient.Client.UsersAPI.UsersDelete(ctx, d.Id()).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return utils.DiagnosticsFromApiError(res, err)
 	}
 
 	d.SetId("")
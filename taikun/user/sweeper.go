@@ -0,0 +1,35 @@
+//go:build sweep
+
+package user
+
+import (
+	"context"
+	"errors"
+
+	tk "github.com/itera-io/taikungoclient"
+	tkcore "github.com/itera-io/taikungoclient/client"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+)
+
+// Delete the users left behind by the acceptance tests, once the projects they are attached to are deleted
+func AddTestSweepers(client utils.SweeperClientFunc) {
+	utils.AddTestSweeper("taikun_user", client, []string{"taikun_project"}, sweepUsers)
+}
+
+func sweepUsers(ctx context.Context, apiClient *tk.Client) error {
+	searchBody := tkcore.UsersSearchCommand{}
+	searchBody.SetSearchTerm(utils.SweepablePrefix)
+	response, res, err := apiClient.Client.SearchAPI.SearchUsers(ctx).UsersSearchCommand(searchBody).Execute()
+	if err != nil {
+		return tk.CreateError(res, err)
+	}
+
+	var errs []error
+	for _, user := range response.GetData() {
+		if !utils.IsSweepable(user.GetName()) {
+			continue
+		}
+		errs = append(errs, utils.SweepResource(ctx, ResourceTaikunUser(), user.GetId(), nil, apiClient))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,321 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	tk "github.com/itera-io/taikungoclient"
+)
+
+// Error payload returned by the Taikun API.
+// Validation errors come either as a map of field names to messages or as a list of objects naming the field.
+type apiErrorPayload struct {
+	Title   string          `json:"title"`
+	Detail  string          `json:"detail"`
+	Message string          `json:"message"`
+	Status  int             `json:"status"`
+	Errors  json.RawMessage `json:"errors"`
+}
+
+type apiFieldError struct {
+	Field   string
+	Message string
+}
+
+// Field names used by the API which do not match the name of the Terraform attribute once converted to snake case
+var apiFieldToAttribute = map[string]string{
+	"opa_profile_id":      "policy_profile_id",
+	"s3credential_id":     "backup_credential_id",
+	"expired_at":          "expiration_date",
+	"expire_at":           "expiration_date",
+	"kube_config_role_id": "role",
+	"ttl":                 "validity_period",
+	"extra_values":        "parameters_base64",
+	"quota_cpu":           "quota_cpu_units",
+	"server_cpu":          "quota_cpu_units",
+	"server_ram":          "quota_ram_size",
+	"server_disk_size":    "quota_disk_size",
+	"vm_cpu":              "quota_vm_cpu_units",
+	"vm_ram":              "quota_vm_ram_size",
+	"vm_volume_size":      "quota_vm_volume_size",
+}
+
+// Hints shown with errors whose message matches, the first match wins
+var apiErrorHints = []struct {
+	pattern *regexp.Regexp
+	hint    string
+}{
+	{regexp.MustCompile(`(?i)quota`), "Raise the project quota (`quota_*` attributes of `taikun_project`) or request fewer resources."},
+	{regexp.MustCompile(`(?i)flavor.*not (bound|assigned)`), "Add the flavor to the `flavors` of the project before using it."},
+	{regexp.MustCompile(`(?i)image.*not (bound|assigned)`), "Add the image to the `images` of the project before using it."},
+	{regexp.MustCompile(`(?i)not (bound|assigned) to (the )?project`), "Bind it to the project before using it."},
+	{regexp.MustCompile(`(?i)already exists|duplicate`), "Choose another name, or import the existing object with `terraform import`."},
+	{regexp.MustCompile(`(?i)(is|are) (busy|locked)|in progress|pending operation`), "Another operation is running on this object, wait for it to finish and apply again."},
+}
+
+// Hints shown when no message specific hint matched
+var apiStatusHints = map[int]string{
+	http.StatusUnauthorized: "Check the credentials configured in the provider block.",
+	http.StatusForbidden:    "The user or robot user the provider authenticates with lacks the role or scope for this operation, or the object belongs to another organization.",
+	http.StatusNotFound:     "The object was not found, it may have been deleted outside of Terraform.",
+	http.StatusConflict:     "The object was modified concurrently or conflicts with an existing one, apply again once other operations have finished.",
+}
+
+// Error returned by the Taikun API, with the body of its response.
+// Helpers returning an error return it in place of tk.CreateError, so that their callers can still turn it into
+// diagnostics pointing at attributes with DiagnosticsFromError.
+type ApiError struct {
+	statusCode int
+	body       []byte
+	err        error
+	message    string
+}
+
+// Wrap an error returned by the Taikun API, nil if there is no error
+func NewApiError(res *http.Response, err error) error {
+	if err == nil {
+		return nil
+	}
+	var apiErr *ApiError
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+
+	apiErr = &ApiError{err: err, body: readApiErrorBody(res, err)}
+	if res != nil {
+		apiErr.statusCode = res.StatusCode
+	}
+	apiErr.message = tk.CreateError(res, err).Error()
+	return apiErr
+}
+
+func (e *ApiError) Error() string {
+	return e.message
+}
+
+func (e *ApiError) Unwrap() error {
+	return e.err
+}
+
+// Convert an error returned by the Taikun API into diagnostics.
+// Validation errors get one diagnostic each, pointing at the attribute they relate to.
+func DiagnosticsFromApiError(res *http.Response, err error) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+	return NewApiError(res, err).(*ApiError).diagnostics()
+}
+
+// Diagnostics of an error returned by a helper, those of DiagnosticsFromApiError if it is or wraps an ApiError
+func DiagnosticsFromError(err error) diag.Diagnostics {
+	var apiErr *ApiError
+	if errors.As(err, &apiErr) {
+		return apiErr.diagnostics()
+	}
+	return diag.FromErr(err)
+}
+
+func (e *ApiError) diagnostics() diag.Diagnostics {
+	payload, ok := parseApiErrorPayload(e.body)
+	if !ok {
+		return diag.FromErr(e)
+	}
+	statusCode := e.statusCode
+	if payload.Status != 0 {
+		statusCode = payload.Status
+	}
+
+	summary := apiErrorSummary(statusCode, payload)
+	fieldErrors := parseApiFieldErrors(payload.Errors)
+	if len(fieldErrors) == 0 {
+		message := firstNonEmpty(payload.Detail, payload.Message, payload.Title, e.err.Error())
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   withHint(message, statusCode),
+		}}
+	}
+
+	diagnostics := make(diag.Diagnostics, 0, len(fieldErrors))
+	for _, fieldError := range fieldErrors {
+		diagnostic := diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   withHint(fieldError.Message, statusCode),
+		}
+		if attribute := ApiFieldToAttribute(fieldError.Field); attribute != "" {
+			diagnostic.Summary = fmt.Sprintf("%s: %s", summary, attribute)
+			diagnostic.AttributePath = cty.GetAttrPath(attribute)
+		}
+		diagnostics = append(diagnostics, diagnostic)
+	}
+	return diagnostics
+}
+
+// The generated clients keep the response body in the error, fall back on the response itself
+func readApiErrorBody(res *http.Response, err error) []byte {
+	var bodyError interface{ Body() []byte }
+	if errors.As(err, &bodyError) && len(bodyError.Body()) != 0 {
+		return bodyError.Body()
+	}
+
+	if res == nil || res.Body == nil {
+		return nil
+	}
+	body, readErr := io.ReadAll(res.Body)
+	if readErr != nil {
+		return nil
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body)) // Leave the body readable for tk.CreateError
+	return body
+}
+
+func parseApiErrorPayload(body []byte) (apiErrorPayload, bool) {
+	var payload apiErrorPayload
+	if len(bytes.TrimSpace(body)) == 0 {
+		return payload, false
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return payload, false
+	}
+	if payload.Title == "" && payload.Detail == "" && payload.Message == "" && len(payload.Errors) == 0 {
+		return payload, false
+	}
+	return payload, true
+}
+
+func parseApiFieldErrors(raw json.RawMessage) []apiFieldError {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	// {"Name": ["Name is required"]}
+	var byField map[string][]string
+	if err := json.Unmarshal(raw, &byField); err == nil {
+		fields := make([]string, 0, len(byField))
+		for field := range byField {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		fieldErrors := make([]apiFieldError, 0)
+		for _, field := range fields {
+			for _, message := range byField[field] {
+				fieldErrors = append(fieldErrors, apiFieldError{Field: field, Message: message})
+			}
+		}
+		return fieldErrors
+	}
+
+	// [{"propertyName": "Name", "errorMessage": "Name is required"}]
+	var list []struct {
+		PropertyName string `json:"propertyName"`
+		Field        string `json:"field"`
+		ErrorMessage string `json:"errorMessage"`
+		Message      string `json:"message"`
+	}
+	if err := json.Unmarshal(raw, &list); err == nil {
+		fieldErrors := make([]apiFieldError, 0, len(list))
+		for _, item := range list {
+			fieldErrors = append(fieldErrors, apiFieldError{
+				Field:   firstNonEmpty(item.PropertyName, item.Field),
+				Message: firstNonEmpty(item.ErrorMessage, item.Message),
+			})
+		}
+		return fieldErrors
+	}
+
+	// ["Name is required"]
+	var messages []string
+	if err := json.Unmarshal(raw, &messages); err == nil {
+		fieldErrors := make([]apiFieldError, 0, len(messages))
+		for _, message := range messages {
+			fieldErrors = append(fieldErrors, apiFieldError{Message: message})
+		}
+		return fieldErrors
+	}
+
+	return nil
+}
+
+// Convert an API field name such as `$.kubernetesProfileId` or `Servers[0].Flavor` to the top level attribute it relates to
+func ApiFieldToAttribute(field string) string {
+	field = strings.TrimPrefix(field, "$.")
+	if i := strings.IndexAny(field, ".["); i != -1 {
+		field = field[:i]
+	}
+	if field == "" {
+		return ""
+	}
+
+	var builder strings.Builder
+	runes := []rune(field)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Start a new word, except inside acronyms such as "ID" in "CloudCredentialID"
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				builder.WriteRune('_')
+			}
+			builder.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		builder.WriteRune(r)
+	}
+
+	attribute := builder.String()
+	if alias, ok := apiFieldToAttribute[attribute]; ok {
+		return alias
+	}
+	return attribute
+}
+
+func apiErrorSummary(statusCode int, payload apiErrorPayload) string {
+	switch {
+	case statusCode == http.StatusBadRequest && len(payload.Errors) != 0:
+		return "Invalid value rejected by Taikun"
+	case statusCode == http.StatusNotFound:
+		return "Not found in Taikun"
+	case statusCode == http.StatusConflict:
+		return "Conflict in Taikun"
+	case statusCode == http.StatusForbidden:
+		return "Forbidden by Taikun"
+	case statusCode == http.StatusUnauthorized:
+		return "Not authenticated to Taikun"
+	case payload.Title != "":
+		return payload.Title
+	case statusCode != 0:
+		return fmt.Sprintf("Taikun API error (%d %s)", statusCode, http.StatusText(statusCode))
+	}
+	return "Taikun API error"
+}
+
+func withHint(message string, statusCode int) string {
+	for _, hint := range apiErrorHints {
+		if hint.pattern.MatchString(message) {
+			return message + "\n\n" + hint.hint
+		}
+	}
+	if hint, ok := apiStatusHints[statusCode]; ok {
+		return message + "\n\n" + hint
+	}
+	return message
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
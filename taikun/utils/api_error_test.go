@@ -0,0 +1,234 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// Error of the generated clients, which keep the response body
+type testBodyError struct {
+	body []byte
+}
+
+func (e testBodyError) Error() string {
+	return "400 Bad Request"
+}
+
+func (e testBodyError) Body() []byte {
+	return e.body
+}
+
+func testApiErrorResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Status:     fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Header:     http.Header{},
+	}
+}
+
+func TestApiFieldToAttribute(t *testing.T) {
+	testCases := []struct {
+		field     string
+		attribute string
+	}{
+		{"Name", "name"},
+		{"name", "name"},
+		{"$.kubernetesProfileId", "kubernetes_profile_id"},
+		{"CloudCredentialID", "cloud_credential_id"},
+		{"AccessProfileId", "access_profile_id"},
+		{"Servers[0].Flavor", "servers"},
+		{"Quota.ServerCpu", "quota"},
+		{"OpaProfileId", "policy_profile_id"},
+		{"S3CredentialId", "backup_credential_id"},
+		{"ExpiredAt", "expiration_date"},
+		{"ExtraValues", "parameters_base64"},
+		{"ServerCpu", "quota_cpu_units"},
+		{"ServerRam", "quota_ram_size"},
+		{"ServerDiskSize", "quota_disk_size"},
+		{"VmCpu", "quota_vm_cpu_units"},
+		{"VmRam", "quota_vm_ram_size"},
+		{"VmVolumeSize", "quota_vm_volume_size"},
+		{"TTL", "validity_period"},
+		{"", ""},
+		{"$.", ""},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.field, func(t *testing.T) {
+			if attribute := ApiFieldToAttribute(testCase.field); attribute != testCase.attribute {
+				t.Errorf("expected %q, got %q", testCase.attribute, attribute)
+			}
+		})
+	}
+}
+
+func TestParseApiFieldErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		raw      string
+		expected []apiFieldError
+	}{
+		{
+			name: "map of fields, sorted",
+			raw:  `{"Name": ["Name is required", "Name is too short"], "Flavor": ["Unknown flavor"]}`,
+			expected: []apiFieldError{
+				{Field: "Flavor", Message: "Unknown flavor"},
+				{Field: "Name", Message: "Name is required"},
+				{Field: "Name", Message: "Name is too short"},
+			},
+		},
+		{
+			name: "list of objects",
+			raw:  `[{"propertyName": "Name", "errorMessage": "Name is required"}, {"field": "ServerCpu", "message": "Too many CPUs"}]`,
+			expected: []apiFieldError{
+				{Field: "Name", Message: "Name is required"},
+				{Field: "ServerCpu", Message: "Too many CPUs"},
+			},
+		},
+		{
+			name:     "list of messages",
+			raw:      `["Something is wrong"]`,
+			expected: []apiFieldError{{Message: "Something is wrong"}},
+		},
+		{
+			name:     "empty",
+			raw:      ``,
+			expected: nil,
+		},
+		{
+			name:     "unknown format",
+			raw:      `42`,
+			expected: nil,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			fieldErrors := parseApiFieldErrors([]byte(testCase.raw))
+			if len(fieldErrors) != len(testCase.expected) {
+				t.Fatalf("expected %v, got %v", testCase.expected, fieldErrors)
+			}
+			for i := range fieldErrors {
+				if fieldErrors[i] != testCase.expected[i] {
+					t.Errorf("expected %v, got %v", testCase.expected[i], fieldErrors[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWithHint(t *testing.T) {
+	testCases := []struct {
+		message    string
+		statusCode int
+		hint       string
+	}{
+		{"CPU quota exceeded", http.StatusBadRequest, "Raise the project quota"},
+		{"Flavor m1.small is not bound to the project", http.StatusBadRequest, "Add the flavor to the `flavors`"},
+		{"Image ubuntu is not assigned to the project", http.StatusBadRequest, "Add the image to the `images`"},
+		{"Cloud credential is not bound to project", http.StatusBadRequest, "Bind it to the project"},
+		{"Project with this name already exists", http.StatusBadRequest, "Choose another name"},
+		{"Duplicate name", http.StatusConflict, "Choose another name"},
+		{"Project is locked", http.StatusBadRequest, "Another operation is running"},
+		{"Operation in progress", http.StatusConflict, "Another operation is running"},
+		{"Invalid token", http.StatusUnauthorized, "Check the credentials"},
+		{"Access denied", http.StatusForbidden, "lacks the role or scope"},
+		{"Project 42", http.StatusNotFound, "deleted outside of Terraform"},
+		{"Version mismatch", http.StatusConflict, "modified concurrently"},
+		{"Name is required", http.StatusBadRequest, ""},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.message, func(t *testing.T) {
+			detail := withHint(testCase.message, testCase.statusCode)
+			if testCase.hint == "" {
+				if detail != testCase.message {
+					t.Errorf("expected no hint, got %q", detail)
+				}
+				return
+			}
+			if !strings.HasPrefix(detail, testCase.message+"\n\n") || !strings.Contains(detail, testCase.hint) {
+				t.Errorf("expected the hint %q, got %q", testCase.hint, detail)
+			}
+		})
+	}
+}
+
+func TestDiagnosticsFromApiErrorValidation(t *testing.T) {
+	body := `{"title": "One or more validation errors occurred.", "status": 400, "errors": {"ServerCpu": ["CPU quota exceeded"], "Name": ["Name is required"]}}`
+	diagnostics := DiagnosticsFromApiError(testApiErrorResponse(http.StatusBadRequest, body), testBodyError{body: []byte(body)})
+
+	if len(diagnostics) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %v", diagnostics)
+	}
+	expected := []struct {
+		attribute string
+		detail    string
+	}{
+		{"name", "Name is required"},
+		{"quota_cpu_units", "CPU quota exceeded"},
+	}
+	for i, diagnostic := range diagnostics {
+		if diagnostic.Severity != diag.Error {
+			t.Errorf("expected diagnostic %d to be an error", i)
+		}
+		if !diagnostic.AttributePath.Equals(cty.GetAttrPath(expected[i].attribute)) {
+			t.Errorf("expected diagnostic %d to point at %s, got %#v", i, expected[i].attribute, diagnostic.AttributePath)
+		}
+		if diagnostic.Summary != "Invalid value rejected by Taikun: "+expected[i].attribute {
+			t.Errorf("unexpected summary of diagnostic %d: %q", i, diagnostic.Summary)
+		}
+		if !strings.HasPrefix(diagnostic.Detail, expected[i].detail) {
+			t.Errorf("unexpected detail of diagnostic %d: %q", i, diagnostic.Detail)
+		}
+	}
+}
+
+func TestDiagnosticsFromApiErrorReadsResponse(t *testing.T) {
+	body := `{"title": "Not Found", "status": 404, "detail": "Project 42 not found"}`
+	diagnostics := DiagnosticsFromApiError(testApiErrorResponse(http.StatusNotFound, body), errors.New("404 Not Found"))
+
+	if len(diagnostics) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %v", diagnostics)
+	}
+	if diagnostics[0].Summary != "Not found in Taikun" {
+		t.Errorf("unexpected summary %q", diagnostics[0].Summary)
+	}
+	if !strings.HasPrefix(diagnostics[0].Detail, "Project 42 not found\n\n") {
+		t.Errorf("unexpected detail %q", diagnostics[0].Detail)
+	}
+}
+
+// Helpers return an ApiError, which keeps pointing at the attribute once returned by them, even wrapped
+func TestDiagnosticsFromError(t *testing.T) {
+	body := `{"title": "Bad Request", "status": 400, "errors": [{"propertyName": "VmRam", "errorMessage": "RAM quota exceeded"}]}`
+	err := NewApiError(testApiErrorResponse(http.StatusBadRequest, body), testBodyError{body: []byte(body)})
+	wrapped := fmt.Errorf("unable to edit the quotas: %w", err)
+
+	diagnostics := DiagnosticsFromError(wrapped)
+	if len(diagnostics) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %v", diagnostics)
+	}
+	if !diagnostics[0].AttributePath.Equals(cty.GetAttrPath("quota_vm_ram_size")) {
+		t.Errorf("expected the diagnostic to point at quota_vm_ram_size, got %#v", diagnostics[0].AttributePath)
+	}
+
+	if NewApiError(nil, nil) != nil {
+		t.Error("expected no error without an error")
+	}
+	if NewApiError(nil, err) != err {
+		t.Error("expected an ApiError to be returned as is")
+	}
+}
+
+func TestDiagnosticsFromErrorOtherErrors(t *testing.T) {
+	diagnostics := DiagnosticsFromError(errors.New("not from the API"))
+	if len(diagnostics) != 1 || diagnostics[0].Summary != "not from the API" || diagnostics[0].AttributePath != nil {
+		t.Fatalf("expected the error as is, got %v", diagnostics)
+	}
+}
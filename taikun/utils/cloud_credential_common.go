@@ -2,9 +2,12 @@ package utils
 
 import (
 	"context"
+	"fmt"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	tk "github.com/itera-io/taikungoclient"
+	tkcore "github.com/itera-io/taikungoclient/client"
 )
 
 func ResourceTaikunCloudCredentialDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -16,9 +19,60 @@ func ResourceTaikunCloudCredentialDelete(ctx context.Context, d *schema.Resource
 
 	res, err := apiClient.Client.CloudCredentialAPI.CloudcredentialsDelete(ctx, id).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(res, err))
+		return DiagnosticsFromApiError(res, err)
 	}
 
 	d.SetId("")
 	return nil
 }
+
+// Returns the cloud type of a cloud credential, such as project.ResourceTaikunProjectGetCloudType
+type CloudTypeFunc func(ctx context.Context, cloudCredentialID int32, apiClient *tk.Client) (string, error)
+
+// Import a cloud credential by ID, after checking it is a credential of the cloud managed by the resource.
+// Secrets are not returned by Taikun, they are left empty in the state and must be supplied in the configuration.
+func CloudCredentialImporter(cloudType tkcore.CloudType, getCloudType CloudTypeFunc) *schema.ResourceImporter {
+	return &schema.ResourceImporter{
+		StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			apiClient := meta.(*tk.Client)
+			id, err := Atoi32(d.Id())
+			if err != nil {
+				return nil, fmt.Errorf("the ID of a cloud credential must be a number, got %q", d.Id())
+			}
+
+			actualCloudType, err := getCloudType(ctx, id, apiClient)
+			if err != nil {
+				return nil, err
+			}
+			if actualCloudType != string(cloudType) {
+				return nil, fmt.Errorf("cloud credential with ID %d is a %s cloud credential, it cannot be imported as a %s cloud credential", id, actualCloudType, cloudType)
+			}
+
+			return []*schema.ResourceData{d}, nil
+		},
+	}
+}
+
+// Replace the cloud credential when one of the attributes changes, unless the attribute is absent from the state.
+// Taikun does not return these attributes, an import leaves them out of the state: setting them after an import stores them
+// in place with the next apply, changing them afterwards replaces the cloud credential.
+func ForceNewUnlessImported(keys ...string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+		if d.Id() == "" {
+			return nil
+		}
+		rawState := d.GetRawState()
+		for _, key := range keys {
+			if !d.HasChange(key) {
+				continue
+			}
+			if !rawState.IsNull() && rawState.IsKnown() && rawState.GetAttr(key).IsNull() {
+				continue
+			}
+			if err := d.ForceNew(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
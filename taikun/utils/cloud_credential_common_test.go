@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestForceNewUnlessImported(t *testing.T) {
+	resource := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {Type: schema.TypeString, Required: true},
+			"url":  {Type: schema.TypeString, Required: true},
+		},
+		CustomizeDiff: ForceNewUnlessImported("url"),
+	}
+
+	testCases := []struct {
+		name        string
+		attributes  map[string]string
+		rawState    cty.Value
+		config      map[string]interface{}
+		requiresNew bool
+	}{
+		{
+			name:        "changed",
+			attributes:  map[string]string{"id": "1", "name": "a", "url": "https://old"},
+			rawState:    cty.ObjectVal(map[string]cty.Value{"id": cty.StringVal("1"), "name": cty.StringVal("a"), "url": cty.StringVal("https://old")}),
+			config:      map[string]interface{}{"name": "a", "url": "https://new"},
+			requiresNew: true,
+		},
+		{
+			name:        "set after an import",
+			attributes:  map[string]string{"id": "1", "name": "a"},
+			rawState:    cty.ObjectVal(map[string]cty.Value{"id": cty.StringVal("1"), "name": cty.StringVal("a"), "url": cty.NullVal(cty.String)}),
+			config:      map[string]interface{}{"name": "a", "url": "https://new"},
+			requiresNew: false,
+		},
+		{
+			name:        "other attribute changed",
+			attributes:  map[string]string{"id": "1", "name": "a", "url": "https://old"},
+			rawState:    cty.ObjectVal(map[string]cty.Value{"id": cty.StringVal("1"), "name": cty.StringVal("a"), "url": cty.StringVal("https://old")}),
+			config:      map[string]interface{}{"name": "b", "url": "https://old"},
+			requiresNew: false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			state := &terraform.InstanceState{ID: "1", Attributes: testCase.attributes, RawState: testCase.rawState}
+			diff, err := resource.Diff(context.Background(), state, terraform.NewResourceConfigRaw(testCase.config), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff == nil {
+				t.Fatal("expected a diff")
+			}
+			if diff.RequiresNew() != testCase.requiresNew {
+				t.Errorf("expected the cloud credential to be replaced: %t, got %t", testCase.requiresNew, diff.RequiresNew())
+			}
+		})
+	}
+}
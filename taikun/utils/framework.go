@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"github.com/hashicorp/go-cty/cty"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// Convert SDKv2 diagnostics, such as the ones returned by DiagnosticsFromApiError, for resources written with the plugin framework
+func FrameworkDiagnostics(diagnostics diag.Diagnostics) fwdiag.Diagnostics {
+	frameworkDiagnostics := fwdiag.Diagnostics{}
+	for _, diagnostic := range diagnostics {
+		var attributePath *path.Path
+		if len(diagnostic.AttributePath) != 0 {
+			if step, ok := diagnostic.AttributePath[0].(cty.GetAttrStep); ok {
+				root := path.Root(step.Name)
+				attributePath = &root
+			}
+		}
+
+		switch {
+		case diagnostic.Severity == diag.Warning && attributePath != nil:
+			frameworkDiagnostics.AddAttributeWarning(*attributePath, diagnostic.Summary, diagnostic.Detail)
+		case diagnostic.Severity == diag.Warning:
+			frameworkDiagnostics.AddWarning(diagnostic.Summary, diagnostic.Detail)
+		case attributePath != nil:
+			frameworkDiagnostics.AddAttributeError(*attributePath, diagnostic.Summary, diagnostic.Detail)
+		default:
+			frameworkDiagnostics.AddError(diagnostic.Summary, diagnostic.Detail)
+		}
+	}
+	return frameworkDiagnostics
+}
@@ -202,12 +202,16 @@ func ShortRandomTestName() string {
 	return randomName(testNamePrefix, 5)
 }
 
+// Recorded tests must send the same names when replayed, random values are then drawn from a generator seeded by the test
 func randomName(prefix string, length int) string {
+	if RandomStringHook != nil {
+		return fmt.Sprintf("%s%s", prefix, RandomStringHook(length))
+	}
 	return fmt.Sprintf("%s%s", prefix, acctest.RandString(length))
 }
 
 func RandomString() string {
-	return fmt.Sprintf("%s%s", "tf", acctest.RandString(rand.Int()%6+7)) // Taikun can have problems with strings starting with numbers
+	return randomName("tf", RandomInt(6)+7) // Taikun can have problems with strings starting with numbers
 }
 
 func RandomURL() string {
@@ -219,11 +223,14 @@ func RandomEmail() string {
 }
 
 func RandomBool() bool {
-	return rand.Int()%2 == 0
+	return RandomInt(2) == 0
 }
 
 // Return an integer in the range [0; maxInt[
 func RandomInt(maxInt int) int {
+	if RandomIntHook != nil {
+		return RandomIntHook(maxInt)
+	}
 	return rand.Int() % maxInt
 }
 
@@ -383,7 +390,7 @@ func GetLastCharacter(zoneString string) string {
 func GetProxmoxStorageStringForServer(ctx context.Context, projectID int32, apiClient *tk.Client) (string, error) {
 	data, response, err := apiClient.Client.ServersAPI.ServersDetails(ctx, projectID).Execute()
 	if err != nil {
-		return "", tk.CreateError(response, err)
+		return "", NewApiError(response, err)
 	}
 
 	kubernetesProfile := data.GetProject()
@@ -399,3 +406,12 @@ func GetProxmoxStorageStringForServer(ctx context.Context, projectID int32, apiC
 		return "", fmt.Errorf("parsed an unrecognised Proxmox Storage type from Kubernetes Profile for this project")
 	}
 }
+
+// Versions of a package of a repository, as listed by Taikun
+func GetPackageVersions(ctx context.Context, repositoryName string, packageName string, apiClient *tk.Client) ([]string, error) {
+	data, response, err := apiClient.Client.PackageAPI.PackageVersions(ctx, repositoryName, packageName).Execute()
+	if err != nil {
+		return nil, NewApiError(response, err)
+	}
+	return data, nil
+}
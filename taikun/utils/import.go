@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Split the ID given to terraform import into its parts, for example "42/my-catalog" for the format "organization_id/name"
+func ParseImportID(id string, format ...string) ([]string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != len(format) {
+		return nil, fmt.Errorf("unexpected format of ID (%q), expected %s", id, strings.Join(format, "/"))
+	}
+	for i, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("unexpected format of ID (%q), %s is empty", id, format[i])
+		}
+	}
+	return parts, nil
+}
@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// How often a lock held by another Terraform process is tried again
+const projectFileLockPollInterval = 2 * time.Second
+
+// Taikun rejects concurrent operations on the same project.
+// Every resource which modifies a project holds its lock for the whole operation, so that operations on a project are serialised across resources.
+type keyedMutex struct {
+	mutex sync.Mutex
+	locks map[string]chan struct{}
+}
+
+var projectLocks = keyedMutex{locks: map[string]chan struct{}{}}
+
+func (m *keyedMutex) get(key string) chan struct{} {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	lock, ok := m.locks[key]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		m.locks[key] = lock
+	}
+	return lock
+}
+
+// Wait for the lock until the context is done
+func (m *keyedMutex) Lock(ctx context.Context, key string) error {
+	select {
+	case m.get(key) <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (m *keyedMutex) Unlock(key string) {
+	<-m.get(key)
+}
+
+// Lock the project for the current operation and return the function releasing it.
+// If the provider sets project_lock_dir, an advisory file lock is also taken, so that Terraform processes sharing the directory wait for each other.
+func LockProject(ctx context.Context, meta interface{}, projectID int32) (func(), error) {
+	key := I32toa(projectID)
+	if err := projectLocks.Lock(ctx, key); err != nil {
+		return nil, fmt.Errorf("timed out waiting for other operations on project %d: %s", projectID, err)
+	}
+
+	lockDir := GetProviderConfig(meta).ProjectLockDir
+	if lockDir == "" {
+		return func() { projectLocks.Unlock(key) }, nil
+	}
+
+	file, err := lockProjectFile(ctx, lockDir, projectID)
+	if err != nil {
+		projectLocks.Unlock(key)
+		return nil, err
+	}
+
+	return func() {
+		_ = unlockFile(file)
+		_ = file.Close()
+		projectLocks.Unlock(key)
+	}, nil
+}
+
+func lockProjectFile(ctx context.Context, lockDir string, projectID int32) (*os.File, error) {
+	if err := os.MkdirAll(lockDir, 0o700); err != nil {
+		return nil, fmt.Errorf("unable to create project_lock_dir %s: %s", lockDir, err)
+	}
+
+	path := filepath.Join(lockDir, fmt.Sprintf("project-%d.lock", projectID))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open lock file %s: %s", path, err)
+	}
+
+	for {
+		locked, err := tryLockFile(file)
+		if err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("unable to lock %s: %s", path, err)
+		}
+		if locked {
+			return file, nil
+		}
+
+		select {
+		case <-time.After(projectFileLockPollInterval):
+		case <-ctx.Done():
+			_ = file.Close()
+			return nil, fmt.Errorf("timed out waiting for other Terraform processes working on project %d: %s", projectID, ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package utils
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tk "github.com/itera-io/taikungoclient"
+)
+
+func newTestKeyedMutex() *keyedMutex {
+	return &keyedMutex{locks: map[string]chan struct{}{}}
+}
+
+func TestKeyedMutexSameKey(t *testing.T) {
+	m := newTestKeyedMutex()
+	var holders, maxHolders, done atomic.Int32
+	var wg sync.WaitGroup
+
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := m.Lock(context.Background(), "project"); err != nil {
+				t.Error(err)
+				return
+			}
+			current := holders.Add(1)
+			for {
+				previous := maxHolders.Load()
+				if current <= previous || maxHolders.CompareAndSwap(previous, current) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			holders.Add(-1)
+			done.Add(1)
+			m.Unlock("project")
+		}()
+	}
+	wg.Wait()
+
+	if maxHolders.Load() != 1 {
+		t.Fatalf("%d goroutines held the lock at the same time, expected 1", maxHolders.Load())
+	}
+	if done.Load() != 20 {
+		t.Fatalf("%d goroutines got the lock, expected 20", done.Load())
+	}
+}
+
+func TestKeyedMutexDifferentKeys(t *testing.T) {
+	m := newTestKeyedMutex()
+	if err := m.Lock(context.Background(), "first"); err != nil {
+		t.Fatal(err)
+	}
+	defer m.Unlock("first")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := m.Lock(ctx, "second"); err != nil {
+		t.Fatalf("expected the lock of another key to be free, got %s", err)
+	}
+	m.Unlock("second")
+}
+
+func TestKeyedMutexContextCancelled(t *testing.T) {
+	m := newTestKeyedMutex()
+	if err := m.Lock(context.Background(), "project"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error)
+	go func() {
+		result <- m.Lock(ctx, "project")
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	if err := <-result; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the waiting goroutine to give up with context.Canceled, got %v", err)
+	}
+
+	// The goroutine which gave up does not hold the lock
+	m.Unlock("project")
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := m.Lock(ctx, "project"); err != nil {
+		t.Fatalf("expected the lock to be free once released, got %s", err)
+	}
+}
+
+func TestLockProjectTimeout(t *testing.T) {
+	unlock, err := LockProject(context.Background(), nil, 1001)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := LockProject(ctx, nil, 1001); err == nil || !strings.Contains(err.Error(), "timed out waiting for other operations on project 1001") {
+		t.Fatalf("expected a timeout while the project is locked, got %v", err)
+	}
+
+	unlock()
+	unlock, err = LockProject(context.Background(), nil, 1001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlock()
+}
+
+const projectLockHelperEnv = "TAIKUN_TEST_PROJECT_LOCK_DIR"
+
+// Not a test, run by TestLockProjectFileAcrossProcesses as the other Terraform process.
+// It holds the file lock of project 1002 until its standard input is closed.
+func TestLockProjectFileHelperProcess(t *testing.T) {
+	lockDir := os.Getenv(projectLockHelperEnv)
+	if lockDir == "" {
+		return
+	}
+
+	file, err := lockProjectFile(context.Background(), lockDir, 1002)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	_, _ = os.Stdout.WriteString("locked\n")
+	_, _ = bufio.NewReader(os.Stdin).ReadString('\n')
+}
+
+func TestLockProjectFileAcrossProcesses(t *testing.T) {
+	lockDir := t.TempDir()
+	apiClient := &tk.Client{}
+	SetProviderConfig(apiClient, ProviderConfig{ProjectLockDir: lockDir})
+
+	helper := exec.Command(os.Args[0], "-test.run=^TestLockProjectFileHelperProcess$")
+	helper.Env = append(os.Environ(), projectLockHelperEnv+"="+lockDir)
+	helperStdin, err := helper.StdinPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	helperStdout, err := helper.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := helper.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = helper.Wait() }()
+	defer helperStdin.Close()
+
+	if line, err := bufio.NewReader(helperStdout).ReadString('\n'); err != nil || line != "locked\n" {
+		t.Fatalf("the helper process did not lock the project: %q, %v", line, err)
+	}
+
+	// The other process holds the lock
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := LockProject(ctx, apiClient, 1002); err == nil || !strings.Contains(err.Error(), "timed out waiting for other Terraform processes working on project 1002") {
+		t.Fatalf("expected a timeout while another process holds the lock, got %v", err)
+	}
+
+	// The lock is taken once the other process releases it
+	_ = helperStdin.Close()
+	ctx, cancel = context.WithTimeout(context.Background(), 3*projectFileLockPollInterval)
+	defer cancel()
+	unlock, err := LockProject(ctx, apiClient, 1002)
+	if err != nil {
+		t.Fatalf("expected the lock to be taken once the other process released it, got %s", err)
+	}
+	unlock()
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tk "github.com/itera-io/taikungoclient"
+)
+
+// Settings from the provider block which are not part of the Taikun client.
+// They are registered for the client returned by the provider, so that aliased providers keep their own settings.
+type ProviderConfig struct {
+	DefaultOrganizationID string
+	ProjectLockDir        string
+}
+
+var providerConfigs sync.Map
+
+func SetProviderConfig(apiClient *tk.Client, config ProviderConfig) {
+	providerConfigs.Store(apiClient, config)
+}
+
+func GetProviderConfig(meta interface{}) ProviderConfig {
+	apiClient, ok := meta.(*tk.Client)
+	if !ok {
+		return ProviderConfig{}
+	}
+	if config, ok := providerConfigs.Load(apiClient); ok {
+		return config.(ProviderConfig)
+	}
+	return ProviderConfig{}
+}
+
+// CustomizeDiff for resources with an optional organization_id.
+// When a new resource omits organization_id, the provider's default_organization_id is planned instead, so that it shows up in the plan.
+// Existing resources are left alone, setting a default must never replace resources created before it.
+func SetDefaultOrganizationID(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	defaultOrganizationID := GetProviderConfig(meta).DefaultOrganizationID
+	if defaultOrganizationID == "" || d.Id() != "" {
+		return nil
+	}
+
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() || !rawConfig.GetAttr("organization_id").IsNull() {
+		return nil
+	}
+
+	// Some resources store the organization ID as an integer
+	if _, isInt := d.Get("organization_id").(int); isInt {
+		organizationID, err := strconv.Atoi(defaultOrganizationID)
+		if err != nil {
+			return err
+		}
+		return d.SetNew("organization_id", organizationID)
+	}
+	return d.SetNew("organization_id", defaultOrganizationID)
+}
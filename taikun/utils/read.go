@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func IsNotFound(res *http.Response) bool {
+	return res != nil && res.StatusCode == http.StatusNotFound
+}
+
+// Called by read functions when the resource does not exist in Taikun.
+// Right after create or update, the resource may not be visible yet: its ID is kept and the read is retried.
+// Otherwise it was deleted outside of Terraform: it is removed from the state with a warning, so that the next plan recreates it.
+func ReadNotFound(d *schema.ResourceData, id string, withRetries bool) diag.Diagnostics {
+	if withRetries {
+		d.SetId(id)
+		return diag.Errorf(NotFoundAfterCreateOrUpdateError)
+	}
+
+	d.SetId("")
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Resource not found in Taikun",
+		Detail:   fmt.Sprintf("The object with ID %s does not exist in Taikun anymore, it was probably deleted outside of Terraform. It has been removed from the state.", id),
+	}}
+}
+
+// Handle an error from the API call reading the resource, a 404 means the resource does not exist anymore
+func ReadApiError(d *schema.ResourceData, id string, withRetries bool, res *http.Response, err error) diag.Diagnostics {
+	if IsNotFound(res) {
+		return ReadNotFound(d, id, withRetries)
+	}
+	return DiagnosticsFromApiError(res, err)
+}
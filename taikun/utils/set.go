@@ -3,7 +3,11 @@ package utils
 import (
 	"fmt"
 	"hash/crc32"
+	"sort"
 	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func hashString(s string) int {
@@ -18,53 +22,114 @@ func hashString(s string) int {
 	return 0
 }
 
+// Hash the given attributes of a set element.
+// Nested maps are hashed with their keys sorted and nested sets regardless of their order, so that an element always has the same hash.
 func HashAttributes(keys ...string) func(v interface{}) int {
 	return func(v interface{}) int {
-		stringToHash := ""
-		set := v.(map[string]interface{})
-
-		for _, key := range keys {
-
-			if v, ok := set[key].(string); ok {
-				stringToHash += v
-			}
-
-			if v, ok := set[key].(int); ok {
-				stringToHash += strconv.Itoa(v)
-			}
-
-			if v, ok := set[key].(float64); ok {
-				stringToHash += fmt.Sprintf("%f", v)
-			}
+		return hashString(hashedAttributes(v.(map[string]interface{}), keys, false))
+	}
+}
 
-			if v, ok := set[key].(bool); ok {
-				stringToHash += strconv.FormatBool(v)
-			}
+// Remove the elements of a list which have the same given attributes as a previous element, a set read from an old state may contain some.
+// The keys are those given to the HashAttributes of the set, so that the elements left all hash differently.
+func DeduplicateSetElements(elements []interface{}, keys ...string) []interface{} {
+	seen := map[string]bool{}
+	deduplicated := make([]interface{}, 0, len(elements))
+	for _, element := range elements {
+		elementMap, isMap := element.(map[string]interface{})
+		if !isMap {
+			deduplicated = append(deduplicated, element)
+			continue
+		}
+		// The state stores nested sets as lists, in any order
+		key := hashedAttributes(elementMap, keys, true)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		deduplicated = append(deduplicated, element)
+	}
+	return deduplicated
+}
 
-			if list, ok := set[key].([]interface{}); ok {
-				for _, e := range list {
-					if str, ok2 := e.(string); ok2 {
-						stringToHash += str
-					}
+// Representation of the given attributes of a set element, lists of the element being considered as sets if listsAsSets is true
+func hashedAttributes(element map[string]interface{}, keys []string, listsAsSets bool) string {
+	stringBuilder := strings.Builder{}
+	for _, key := range keys {
+		if list, isList := element[key].([]interface{}); isList && listsAsSets {
+			writeHashedSet(&stringBuilder, list)
+			continue
+		}
+		writeHashedValue(&stringBuilder, element[key])
+	}
+	return stringBuilder.String()
+}
 
-					if label, ok2 := e.(map[string]interface{}); ok2 {
-						for _, value := range label {
-							if str, ok3 := value.(string); ok3 {
-								stringToHash += str
-							}
-						}
-					}
-				}
-			}
+// The order of a set does not matter, its elements are sorted by their representation
+func writeHashedSet(stringBuilder *strings.Builder, elements []interface{}) {
+	representations := make([]string, 0, len(elements))
+	for _, element := range elements {
+		elementBuilder := strings.Builder{}
+		writeHashedValue(&elementBuilder, element)
+		representations = append(representations, elementBuilder.String())
+	}
+	sort.Strings(representations)
+	stringBuilder.WriteString("{")
+	stringBuilder.WriteString(strings.Join(representations, ""))
+	stringBuilder.WriteString("}")
+}
 
-			if amap, ok := set[key].(map[string]interface{}); ok {
-				for _, value := range amap {
-					if str, ok2 := value.(string); ok2 {
-						stringToHash += str
-					}
-				}
-			}
+// Write an unambiguous representation of the value: each value is tagged with its type and strings are prefixed with their length,
+// so that "ab" followed by "c" differs from "a" followed by "bc" and an absent attribute differs from an empty one.
+func writeHashedValue(stringBuilder *strings.Builder, value interface{}) {
+	switch v := value.(type) {
+	case nil:
+		stringBuilder.WriteString("~;")
+	case string:
+		stringBuilder.WriteString("s")
+		stringBuilder.WriteString(strconv.Itoa(len(v)))
+		stringBuilder.WriteString(":")
+		stringBuilder.WriteString(v)
+		stringBuilder.WriteString(";")
+	case int:
+		stringBuilder.WriteString("i")
+		stringBuilder.WriteString(strconv.Itoa(v))
+		stringBuilder.WriteString(";")
+	case float64:
+		stringBuilder.WriteString("f")
+		stringBuilder.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+		stringBuilder.WriteString(";")
+	case bool:
+		stringBuilder.WriteString("b")
+		stringBuilder.WriteString(strconv.FormatBool(v))
+		stringBuilder.WriteString(";")
+	case []interface{}:
+		stringBuilder.WriteString("[")
+		for _, element := range v {
+			writeHashedValue(stringBuilder, element)
+		}
+		stringBuilder.WriteString("]")
+	case []map[string]interface{}:
+		stringBuilder.WriteString("[")
+		for _, element := range v {
+			writeHashedValue(stringBuilder, element)
+		}
+		stringBuilder.WriteString("]")
+	case *schema.Set:
+		writeHashedSet(stringBuilder, v.List())
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		stringBuilder.WriteString("(")
+		for _, key := range keys {
+			writeHashedValue(stringBuilder, key)
+			writeHashedValue(stringBuilder, v[key])
 		}
-		return hashString(stringToHash)
+		stringBuilder.WriteString(")")
+	default:
+		stringBuilder.WriteString(fmt.Sprintf("%T%v;", v, v))
 	}
 }
@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func testLabelSet(labels ...map[string]interface{}) *schema.Set {
+	elements := make([]interface{}, 0, len(labels))
+	for _, label := range labels {
+		elements = append(elements, label)
+	}
+	return schema.NewSet(HashAttributes("key", "value"), elements)
+}
+
+func TestWriteHashedValue(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    interface{}
+		expected string
+	}{
+		{"nil", nil, "~;"},
+		{"empty string", "", "s0:;"},
+		{"string", "ab", "s2:ab;"},
+		{"int", 42, "i42;"},
+		{"float", 1.5, "f1.5;"},
+		{"bool", true, "btrue;"},
+		{"list", []interface{}{"a", 1}, "[s1:a;i1;]"},
+		{"list of maps", []map[string]interface{}{{"a": 1}}, "[(s1:a;i1;)]"},
+		{"map with sorted keys", map[string]interface{}{"b": 2, "a": 1}, "(s1:a;i1;s1:b;i2;)"},
+		{"set", schema.NewSet(schema.HashString, []interface{}{"b", "a"}), "{s1:a;s1:b;}"},
+		{"other", int32(7), "int327;"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			stringBuilder := strings.Builder{}
+			writeHashedValue(&stringBuilder, testCase.value)
+			if stringBuilder.String() != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, stringBuilder.String())
+			}
+		})
+	}
+}
+
+func TestHashAttributes(t *testing.T) {
+	hash := HashAttributes("name", "flavor", "kubernetes_node_label")
+	firstLabel := map[string]interface{}{"key": "zone", "value": "a"}
+	secondLabel := map[string]interface{}{"key": "tier", "value": "b"}
+
+	testCases := []struct {
+		name  string
+		a     map[string]interface{}
+		b     map[string]interface{}
+		equal bool
+	}{
+		{
+			"strings split differently",
+			map[string]interface{}{"name": "ab", "flavor": "c"},
+			map[string]interface{}{"name": "a", "flavor": "bc"},
+			false,
+		},
+		{
+			"absent and empty attributes",
+			map[string]interface{}{"name": "a"},
+			map[string]interface{}{"name": "a", "flavor": ""},
+			false,
+		},
+		{
+			"labels in another order",
+			map[string]interface{}{"name": "a", "kubernetes_node_label": testLabelSet(firstLabel, secondLabel)},
+			map[string]interface{}{"name": "a", "kubernetes_node_label": testLabelSet(secondLabel, firstLabel)},
+			true,
+		},
+		{
+			"other labels",
+			map[string]interface{}{"name": "a", "kubernetes_node_label": testLabelSet(firstLabel)},
+			map[string]interface{}{"name": "a", "kubernetes_node_label": testLabelSet(secondLabel)},
+			false,
+		},
+		{
+			"attributes not hashed",
+			map[string]interface{}{"name": "a", "id": "1"},
+			map[string]interface{}{"name": "a", "id": "2"},
+			true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if equal := hash(testCase.a) == hash(testCase.b); equal != testCase.equal {
+				t.Errorf("expected the hashes to be equal: %t, got %d and %d", testCase.equal, hash(testCase.a), hash(testCase.b))
+			}
+		})
+	}
+}
+
+func TestDeduplicateSetElements(t *testing.T) {
+	firstLabel := map[string]interface{}{"key": "zone", "value": "a"}
+	secondLabel := map[string]interface{}{"key": "tier", "value": "b"}
+	elements := []interface{}{
+		map[string]interface{}{"name": "a", "id": "1", "kubernetes_node_label": []interface{}{firstLabel, secondLabel}},
+		// Same server stored under another hash, with its labels in another order
+		map[string]interface{}{"name": "a", "id": "2", "kubernetes_node_label": []interface{}{secondLabel, firstLabel}},
+		map[string]interface{}{"name": "a", "id": "3", "kubernetes_node_label": []interface{}{firstLabel}},
+		map[string]interface{}{"name": "b", "id": "4", "kubernetes_node_label": []interface{}{firstLabel, secondLabel}},
+	}
+
+	deduplicated := DeduplicateSetElements(elements, "name", "kubernetes_node_label")
+	if len(deduplicated) != 3 {
+		t.Fatalf("expected 3 elements, got %v", deduplicated)
+	}
+	for i, id := range []string{"1", "3", "4"} {
+		if deduplicated[i].(map[string]interface{})["id"] != id {
+			t.Errorf("expected element %d to be %s, got %v", i, id, deduplicated[i])
+		}
+	}
+}
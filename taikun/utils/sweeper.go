@@ -0,0 +1,100 @@
+//go:build sweep
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tk "github.com/itera-io/taikungoclient"
+)
+
+// Test sweepers delete the objects left behind by failed acceptance test runs.
+// They are only built with the sweep tag and are run from the sweep package.
+
+// Returns the client shared by the sweepers, configured from the environment like the provider of the acceptance tests
+type SweeperClientFunc func() (*tk.Client, error)
+
+// Prefix of the names given by RandomTestName and ShortRandomTestName to the objects created by the acceptance tests
+const SweepablePrefix = testNamePrefix
+
+func IsSweepable(name string) bool {
+	return strings.HasPrefix(name, SweepablePrefix)
+}
+
+// Register a sweeper, it runs after the sweepers it depends on.
+// Sweepers are named after the resource whose objects they delete, for example taikun_project.
+func AddTestSweeper(name string, client SweeperClientFunc, dependencies []string, sweep func(ctx context.Context, apiClient *tk.Client) error) {
+	resource.AddTestSweepers(name, &resource.Sweeper{
+		Name:         name,
+		Dependencies: dependencies,
+		F: func(_ string) error {
+			apiClient, err := client()
+			if err != nil {
+				return err
+			}
+			return sweep(context.Background(), apiClient)
+		},
+	})
+}
+
+// One page of the objects of a list endpoint, starting at offset, with the total number of objects
+type SweeperListFunc[T any] func(ctx context.Context, apiClient *tk.Client, offset int32) (page []T, totalCount int, err error)
+
+// Register a sweeper going through the pages of a list endpoint.
+// Every object whose name is sweepable is deleted with sweep, the errors are reported together once all of them were tried.
+func AddListTestSweeper[T any](name string, client SweeperClientFunc, dependencies []string, list SweeperListFunc[T], getName func(object *T) string, sweep func(ctx context.Context, apiClient *tk.Client, object *T) error) {
+	AddTestSweeper(name, client, dependencies, func(ctx context.Context, apiClient *tk.Client) error {
+		var offset int32 = 0
+
+		var objects []T
+		for {
+			page, totalCount, err := list(ctx, apiClient, offset)
+			if err != nil {
+				return err
+			}
+			objects = append(objects, page...)
+			if len(page) == 0 || len(objects) >= totalCount {
+				break
+			}
+			offset = int32(len(objects))
+		}
+
+		var errs []error
+		for i := range objects {
+			if !IsSweepable(getName(&objects[i])) {
+				continue
+			}
+			errs = append(errs, sweep(ctx, apiClient, &objects[i]))
+		}
+		return errors.Join(errs...)
+	})
+}
+
+// Delete an object which may be locked, it is unlocked first with the lock function of its resource
+func SweepLockableResource(ctx context.Context, r *schema.Resource, id int32, isLocked bool, lock func(ctx context.Context, id int32, lock bool, apiClient *tk.Client) error, apiClient *tk.Client) error {
+	if isLocked {
+		if err := lock(ctx, id, false, apiClient); err != nil {
+			return err
+		}
+	}
+	return SweepResource(ctx, r, I32toa(id), nil, apiClient)
+}
+
+// Delete an object with the Delete function of its resource.
+// Attributes read by the Delete function besides the ID, such as the project of an application instance, must be given.
+func SweepResource(ctx context.Context, r *schema.Resource, id string, attributes map[string]interface{}, meta interface{}) error {
+	d := r.Data(nil)
+	d.SetId(id)
+	if err := SetResourceDataFromMap(d, attributes); err != nil {
+		return err
+	}
+	if diagnostics := r.DeleteContext(ctx, d, meta); diagnostics.HasError() {
+		return fmt.Errorf("unable to delete %s: %s", id, strings.TrimSpace(diagnosticsToString(diagnostics)))
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+// Hooks set by the test helpers of utils_testing, the provider itself leaves them nil.
+// They let tests record, replay or fake the requests to Taikun without the provider importing test code.
+var (
+	// Source of the random names and numbers of the tests, acctest and math/rand when nil
+	RandomStringHook func(length int) string
+	RandomIntHook    func(maxInt int) int
+
+	// Called with every wait before it starts, for example to poll without delay when Taikun is not actually waited for
+	WaitForStateHook func(stateConf *retry.StateChangeConf)
+
+	// Wraps the HTTP clients of the Taikun API clients when the provider is configured
+	HTTPClientHook func(client *http.Client) *http.Client
+)
@@ -17,6 +17,22 @@ func GetReadAfterOpTimeout(isUpdate bool) time.Duration {
 	return 2 * time.Minute
 }
 
+// Wait for a resource to reach the target state.
+// Tests replaying or faking the API set WaitForStateHook, Taikun is then not actually waited for.
+func WaitForState(ctx context.Context, stateConf *retry.StateChangeConf) (interface{}, error) {
+	if WaitForStateHook != nil {
+		WaitForStateHook(stateConf)
+	}
+	return stateConf.WaitForStateContext(ctx)
+}
+
+// Poll without delay, for tests whose API answers immediately
+func NoWaitDelay(stateConf *retry.StateChangeConf) {
+	stateConf.Delay = 0
+	stateConf.MinTimeout = 0
+	stateConf.PollInterval = time.Millisecond
+}
+
 func TimedOut(err error) bool {
 	//timeoutErr, ok := err.(*resource.TimeoutError)
 	timeoutErr, ok := err.(*retry.TimeoutError)
@@ -36,7 +52,7 @@ func ReadAfterUpdateWithRetries(readFunc schema.ReadContextFunc, ctx context.Con
 func readAfterOpWithRetries(readFunc schema.ReadContextFunc, ctx context.Context, d *schema.ResourceData, meta interface{}, isUpdate bool) diag.Diagnostics {
 	retryErr := retry.RetryContext(ctx, GetReadAfterOpTimeout(isUpdate), func() *retry.RetryError {
 		readDiagnostics := readFunc(ctx, d, meta)
-		if readDiagnostics != nil {
+		if readDiagnostics.HasError() {
 
 			if readDiagnostics[0].Summary == NotFoundAfterCreateOrUpdateError {
 				return retry.RetryableError(errors.New("failed to read after create/update"))
@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Add the write-only variant of the secret attribute `name` to a resource schema.
+// The secret can then be set either with `name`, stored in the state, or with `name`_wo, sent to Taikun but never stored.
+// Write-only values are not part of the plan, `name`_wo_version must therefore change for a new `name`_wo to be sent.
+func AddWriteOnlySecret(resourceSchema map[string]*schema.Schema, name string) {
+	writeOnlyName := name + "_wo"
+	versionName := name + "_wo_version"
+
+	// The conflict is only declared on the write-only attribute, a secret set from the environment must not conflict with it
+	secret := resourceSchema[name]
+	secret.Required = false
+	secret.Optional = true
+
+	description := fmt.Sprintf("Write-only variant of `%s`, sent to Taikun without being stored in the state. Requires Terraform 1.11 or later.", name)
+	if secret.DefaultFunc != nil {
+		description += fmt.Sprintf(" The environment variable of `%s` must not be set, its value would otherwise be stored in the state.", name)
+	}
+	resourceSchema[writeOnlyName] = &schema.Schema{
+		Description:   description,
+		Type:          schema.TypeString,
+		Optional:      true,
+		WriteOnly:     true,
+		ConflictsWith: []string{name},
+		RequiredWith:  []string{versionName},
+		ValidateFunc:  validation.StringIsNotEmpty,
+	}
+	resourceSchema[versionName] = &schema.Schema{
+		Description:  fmt.Sprintf("Version of `%s`, change it to send a new value of `%s` to Taikun.", writeOnlyName, writeOnlyName),
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ForceNew:     secret.ForceNew,
+		RequiredWith: []string{writeOnlyName},
+	}
+}
+
+// Value of the secret `name`, taken from `name`_wo in the configuration if set, or from `name` otherwise
+func GetSecret(d *schema.ResourceData, name string) (string, diag.Diagnostics) {
+	writeOnly, diags := d.GetRawConfigAt(cty.GetAttrPath(name + "_wo"))
+	if diags.HasError() {
+		return "", diags
+	}
+	if writeOnly.Type().Equals(cty.String) && writeOnly.IsKnown() && !writeOnly.IsNull() {
+		return writeOnly.AsString(), nil
+	}
+	return d.Get(name).(string), nil
+}
+
+// Same as GetSecret, for secrets which must be set with either `name` or `name`_wo
+func GetRequiredSecret(d *schema.ResourceData, name string) (string, diag.Diagnostics) {
+	secret, diags := GetSecret(d, name)
+	if diags.HasError() {
+		return "", diags
+	}
+	if secret == "" {
+		return "", diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Missing %s", name),
+			Detail:        fmt.Sprintf("One of `%s` or `%s_wo` must be set.", name, name),
+			AttributePath: cty.GetAttrPath(name),
+		}}
+	}
+	return secret, nil
+}
+
+// Whether the secret `name` was changed, either directly or by bumping `name`_wo_version
+func SecretHasChange(d *schema.ResourceData, name string) bool {
+	return d.HasChanges(name, name+"_wo_version")
+}
+
+// Fail the plan of an imported resource whose secret `name` is set neither with `name` nor with `name`_wo.
+// Taikun does not return secrets, an imported resource has none in its state until the configuration supplies it.
+func RequireImportedSecret(name string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+		oldSecret, newSecret := d.GetChange(name)
+		if d.Id() == "" || oldSecret.(string) != "" || newSecret.(string) != "" {
+			return nil
+		}
+
+		rawConfig := d.GetRawConfig()
+		if rawConfig.IsNull() || !rawConfig.IsKnown() {
+			return nil
+		}
+		if !rawConfig.GetAttr(name).IsKnown() || !rawConfig.GetAttr(name+"_wo").IsNull() {
+			return nil
+		}
+
+		return fmt.Errorf("`%s` is not returned by Taikun and could not be imported, set `%s` or `%s_wo` in the configuration to send it to Taikun on the next apply", name, name, name)
+	}
+}
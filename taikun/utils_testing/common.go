@@ -1,7 +1,9 @@
 package utils_testing
 
 import (
+	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/itera-io/terraform-provider-taikun/taikun/provider"
 )
@@ -10,6 +12,9 @@ var TestAccProvider *schema.Provider
 var TestAccProviders map[string]*schema.Provider
 var TestAccProviderFactories map[string]func() (*schema.Provider, error)
 
+// Factories serving the SDKv2 and framework providers together, needed by tests of framework resources
+var TestAccProtoV5ProviderFactories map[string]func() (tfprotov5.ProviderServer, error)
+
 func init() {
 	TestAccProvider = provider.Provider()
 	if err := TestAccProvider.InternalValidate(); err != nil {
@@ -24,4 +29,9 @@ func init() {
 			return TestAccProvider, nil
 		},
 	}
+	TestAccProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+		"taikun": func() (tfprotov5.ProviderServer, error) {
+			return provider.NewMuxServer(context.Background(), TestAccProvider)
+		},
+	}
 }
@@ -0,0 +1,111 @@
+package faketaikun
+
+import (
+	"net/http"
+)
+
+const backupCredentials = "s3credentials"
+
+// Emulate the S3 backup credentials endpoints, used by taikun_backup_credential
+func registerBackupCredentials(s *Server) {
+	s.mux.HandleFunc("POST /api/v1/s3credentials", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+
+		organizationID, ok := bodyInt32(body, "organizationId")
+		if !ok {
+			organizationID = DefaultOrganizationID
+		}
+		organizationName := s.organizationName(organizationID)
+		if organizationName == "" {
+			writeNotFound(w, "Organization", organizationID)
+			return
+		}
+
+		backupCredential := Object{
+			"createdBy":        userName,
+			"isDefault":        false,
+			"isLocked":         false,
+			"organizationId":   organizationID,
+			"organizationName": organizationName,
+			"s3AccessKeyId":    bodyString(body, "s3AccessKeyId"),
+			"s3Endpoint":       bodyString(body, "s3Endpoint"),
+			"s3Name":           bodyString(body, "s3Name"),
+			"s3Region":         bodyString(body, "s3Region"),
+		}
+		for key, value := range lastModifiedFields() {
+			backupCredential[key] = value
+		}
+		writeCreated(w, s.Store.Create(backupCredentials, backupCredential))
+	})
+
+	s.mux.HandleFunc("GET /api/v1/s3credentials/list", func(w http.ResponseWriter, r *http.Request) {
+		id, filterByID := queryInt32(r, "Id")
+		organizationID, filterByOrganization := queryInt32(r, "OrganizationId")
+		writeList(w, s.Store.List(backupCredentials, func(backupCredential Object) bool {
+			if filterByID && backupCredential["id"] != id {
+				return false
+			}
+			return !filterByOrganization || backupCredential["organizationId"] == organizationID
+		}))
+	})
+
+	s.mux.HandleFunc("PUT /api/v1/s3credentials", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+		id, _ := bodyInt32(body, "id")
+
+		backupCredential, found := s.Store.Get(backupCredentials, id)
+		if !found {
+			writeNotFound(w, "Backup credential", id)
+			return
+		}
+		if backupCredential["isLocked"] == true {
+			writeError(w, http.StatusBadRequest, "Bad Request", "The backup credential is locked.")
+			return
+		}
+
+		fields := lastModifiedFields()
+		fields["s3AccessKeyId"] = bodyString(body, "s3AccessKeyId")
+		fields["s3Name"] = bodyString(body, "s3Name")
+		s.Store.Update(backupCredentials, id, fields)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	s.mux.HandleFunc("POST /api/v1/s3credentials/lockmanager", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+		id, _ := bodyInt32(body, "id")
+
+		fields := lastModifiedFields()
+		fields["isLocked"] = bodyString(body, "mode") == "lock"
+		if !s.Store.Update(backupCredentials, id, fields) {
+			writeNotFound(w, "Backup credential", id)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	s.mux.HandleFunc("DELETE /api/v1/s3credentials/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := pathInt32(r, "id")
+
+		backupCredential, found := s.Store.Get(backupCredentials, id)
+		if !found {
+			writeNotFound(w, "Backup credential", id)
+			return
+		}
+		if backupCredential["isLocked"] == true {
+			writeError(w, http.StatusBadRequest, "Bad Request", "The backup credential is locked.")
+			return
+		}
+
+		s.Store.Delete(backupCredentials, id)
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,346 @@
+package faketaikun
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	catalogs = "catalogs"
+	packages = "packages"
+)
+
+// Add a package to a repository, with its versions, the first one being the latest.
+// Catalogs can then bind applications of the package, which are created at the latest version unless told otherwise.
+func (s *Server) AddPackage(repository string, packageName string, versions ...string) {
+	s.Store.Create(packages, Object{
+		"repoName":    repository,
+		"packageName": packageName,
+		"_versions":   versions,
+	})
+}
+
+// Emulate the catalog endpoints, used by taikun_catalog, the endpoints of the applications bound to the catalogs and the package list.
+// Applications are created from the packages added with AddPackage.
+func registerCatalogs(s *Server) {
+	s.mux.HandleFunc("POST /api/v1/catalog", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+
+		organizationID, ok := bodyInt32(body, "organizationId")
+		if !ok {
+			organizationID = DefaultOrganizationID
+		}
+		organizationName := s.organizationName(organizationID)
+		if organizationName == "" {
+			writeNotFound(w, "Organization", organizationID)
+			return
+		}
+		name := bodyString(body, "name")
+		if len(s.Store.List(catalogs, func(catalog Object) bool {
+			return catalog["organizationId"] == organizationID && catalog["name"] == name
+		})) != 0 {
+			writeError(w, http.StatusBadRequest, "Bad Request", fmt.Sprintf("Catalog %s already exists in organization %d.", name, organizationID))
+			return
+		}
+
+		catalog := Object{
+			"name":             name,
+			"description":      bodyString(body, "description"),
+			"isDefault":        false,
+			"isLocked":         false,
+			"organizationId":   organizationID,
+			"organizationName": organizationName,
+			"_projectIds":      []int32{},
+		}
+		for key, value := range lastModifiedFields() {
+			catalog[key] = value
+		}
+		s.Store.Create(catalogs, catalog)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	s.mux.HandleFunc("GET /api/v1/catalog/list", func(w http.ResponseWriter, r *http.Request) {
+		id, filterByID := queryInt32(r, "Id")
+		organizationID, filterByOrganization := queryInt32(r, "OrganizationId")
+		search := strings.ToLower(r.URL.Query().Get("Search"))
+		listed := s.Store.List(catalogs, func(catalog Object) bool {
+			if filterByID && catalog["id"] != id {
+				return false
+			}
+			if filterByOrganization && catalog["organizationId"] != organizationID {
+				return false
+			}
+			return strings.Contains(strings.ToLower(catalog["name"].(string)), search)
+		})
+		for _, catalog := range listed {
+			s.addCatalogBindings(catalog)
+		}
+		writeList(w, listed)
+	})
+
+	s.mux.HandleFunc("PUT /api/v1/catalog/edit", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+		id, _ := bodyInt32(body, "id")
+
+		fields := lastModifiedFields()
+		fields["name"] = bodyString(body, "name")
+		fields["description"] = bodyString(body, "description")
+		if !s.Store.Update(catalogs, id, fields) {
+			writeNotFound(w, "Catalog", id)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	s.mux.HandleFunc("POST /api/v1/catalog/lockmanager", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+		id, _ := bodyInt32(body, "id")
+
+		fields := lastModifiedFields()
+		fields["isLocked"] = bodyString(body, "mode") == "lock"
+		if !s.Store.Update(catalogs, id, fields) {
+			writeNotFound(w, "Catalog", id)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// A single catalog of the organization is the default one
+	s.mux.HandleFunc("POST /api/v1/catalog/default", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+		id, _ := bodyInt32(body, "id")
+
+		catalog, found := s.Store.Get(catalogs, id)
+		if !found {
+			writeNotFound(w, "Catalog", id)
+			return
+		}
+		for _, other := range s.Store.List(catalogs, func(other Object) bool {
+			return other["organizationId"] == catalog["organizationId"]
+		}) {
+			s.Store.Update(catalogs, other["id"].(int32), Object{"isDefault": other["id"] == id})
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	s.mux.HandleFunc("PUT /api/v1/catalog/{catalogId}/projects", func(w http.ResponseWriter, r *http.Request) {
+		s.editCatalogProjects(w, r, true)
+	})
+
+	s.mux.HandleFunc("DELETE /api/v1/catalog/{catalogId}/projects", func(w http.ResponseWriter, r *http.Request) {
+		s.editCatalogProjects(w, r, false)
+	})
+
+	// Like Taikun, a catalog cannot be deleted while applications are bound to it
+	s.mux.HandleFunc("DELETE /api/v1/catalog/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := pathInt32(r, "id")
+
+		catalog, found := s.Store.Get(catalogs, id)
+		if !found {
+			writeNotFound(w, "Catalog", id)
+			return
+		}
+		if catalog["isLocked"] == true {
+			writeError(w, http.StatusBadRequest, "Bad Request", "The catalog is locked.")
+			return
+		}
+		if len(s.Store.List(catalogApps, func(catalogApp Object) bool { return catalogApp["catalogId"] == id })) != 0 {
+			writeError(w, http.StatusBadRequest, "Bad Request", "The catalog has applications.")
+			return
+		}
+
+		s.Store.Delete(catalogs, id)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	s.mux.HandleFunc("POST /api/v1/catalogapp", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+
+		catalogID, _ := bodyInt32(body, "catalogId")
+		if _, found := s.Store.Get(catalogs, catalogID); !found {
+			writeNotFound(w, "Catalog", catalogID)
+			return
+		}
+		repository, packageName := bodyString(body, "repoName"), bodyString(body, "packageName")
+		pkg := s.findPackage(repository, packageName)
+		if pkg == nil {
+			writeError(w, http.StatusNotFound, "Not Found", "Package "+repository+"/"+packageName+" not found.")
+			return
+		}
+		if len(s.Store.List(catalogApps, func(catalogApp Object) bool {
+			return catalogApp["catalogId"] == catalogID && catalogApp["repoName"] == repository && catalogApp["packageName"] == packageName
+		})) != 0 {
+			writeError(w, http.StatusBadRequest, "Bad Request", fmt.Sprintf("Application %s/%s is already in catalog %d.", repository, packageName, catalogID))
+			return
+		}
+
+		catalogApp := Object{
+			"catalogId":   catalogID,
+			"name":        packageName,
+			"repoName":    repository,
+			"packageName": packageName,
+			"isLocked":    false,
+			"_versions":   pkg["_versions"],
+		}
+		version := bodyString(body, "version")
+		if version == "" {
+			version = pkg["_versions"].([]string)[0]
+		} else if !catalogAppHasVersion(catalogApp, version) {
+			writeError(w, http.StatusBadRequest, "Bad Request", fmt.Sprintf("Version %s of %s does not exist.", version, packageName))
+			return
+		}
+		catalogApp["version"] = version
+		writeCreated(w, s.Store.Create(catalogApps, catalogApp))
+	})
+
+	s.mux.HandleFunc("PUT /api/v1/catalogapp/version", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+		id, _ := bodyInt32(body, "id")
+
+		catalogApp, found := s.Store.Get(catalogApps, id)
+		if !found {
+			writeNotFound(w, "Catalog app", id)
+			return
+		}
+		if catalogApp["isLocked"] == true {
+			writeError(w, http.StatusBadRequest, "Bad Request", "The catalog app is locked.")
+			return
+		}
+		version := bodyString(body, "version")
+		if !catalogAppHasVersion(catalogApp, version) {
+			writeError(w, http.StatusBadRequest, "Bad Request", fmt.Sprintf("Version %s of %s does not exist.", version, catalogApp["packageName"]))
+			return
+		}
+
+		s.Store.Update(catalogApps, id, Object{"version": version})
+		w.WriteHeader(http.StatusOK)
+	})
+
+	s.mux.HandleFunc("DELETE /api/v1/catalogapp/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := pathInt32(r, "id")
+
+		catalogApp, found := s.Store.Get(catalogApps, id)
+		if !found {
+			writeNotFound(w, "Catalog app", id)
+			return
+		}
+		if catalogApp["isLocked"] == true {
+			writeError(w, http.StatusBadRequest, "Bad Request", "The catalog app is locked.")
+			return
+		}
+
+		s.Store.Delete(catalogApps, id)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// Packages are public, FilterBy matches the names of the repositories and of the packages
+	s.mux.HandleFunc("GET /api/v1/package/list", func(w http.ResponseWriter, r *http.Request) {
+		if queryString(r, "IsPrivate") == "true" {
+			writeList(w, nil)
+			return
+		}
+		filter := strings.ToLower(queryString(r, "FilterBy"))
+		available := []Object{}
+		for _, pkg := range s.Store.List(packages, func(pkg Object) bool {
+			return strings.Contains(strings.ToLower(pkg["repoName"].(string)), filter) || strings.Contains(strings.ToLower(pkg["packageName"].(string)), filter)
+		}) {
+			available = append(available, Object{
+				"packageId":  fmt.Sprint(pkg["id"]),
+				"name":       pkg["packageName"],
+				"version":    pkg["_versions"].([]string)[0],
+				"appVersion": pkg["_versions"].([]string)[0],
+				"category":   "",
+				"repository": Object{"name": pkg["repoName"]},
+			})
+		}
+		writeList(w, available)
+	})
+}
+
+// Add the applications and projects bound to the catalog, as returned by the list endpoint
+func (s *Server) addCatalogBindings(catalog Object) {
+	boundApplications := []Object{}
+	for _, catalogApp := range s.Store.List(catalogApps, func(catalogApp Object) bool { return catalogApp["catalogId"] == catalog["id"] }) {
+		boundApplications = append(boundApplications, Object{
+			"catalogAppId": catalogApp["id"],
+			"name":         catalogApp["name"],
+			"version":      catalogApp["version"],
+			"isLocked":     catalogApp["isLocked"],
+			"repository":   Object{"name": catalogApp["repoName"]},
+		})
+	}
+	catalog["boundApplications"] = boundApplications
+
+	boundProjects := []Object{}
+	for _, projectID := range catalog["_projectIds"].([]int32) {
+		if project, found := s.Store.Get(projects, projectID); found {
+			boundProjects = append(boundProjects, Object{"id": projectID, "name": project["name"]})
+		}
+	}
+	catalog["boundProjects"] = boundProjects
+}
+
+// Bind or unbind the projects listed in the body, projects which do not exist are ignored
+func (s *Server) editCatalogProjects(w http.ResponseWriter, r *http.Request, bind bool) {
+	catalogID, _ := pathInt32(r, "catalogId")
+	var projectIDs []int32
+	if err := json.NewDecoder(r.Body).Decode(&projectIDs); err != nil {
+		writeError(w, http.StatusBadRequest, "Bad Request", fmt.Sprintf("Invalid JSON body: %s", err))
+		return
+	}
+
+	catalog, found := s.Store.Get(catalogs, catalogID)
+	if !found {
+		writeNotFound(w, "Catalog", catalogID)
+		return
+	}
+
+	bound := map[int32]bool{}
+	for _, projectID := range catalog["_projectIds"].([]int32) {
+		bound[projectID] = true
+	}
+	for _, projectID := range projectIDs {
+		if _, found := s.Store.Get(projects, projectID); found || !bind {
+			bound[projectID] = bind
+		}
+	}
+	boundProjectIDs := []int32{}
+	for projectID, isBound := range bound {
+		if isBound {
+			boundProjectIDs = append(boundProjectIDs, projectID)
+		}
+	}
+	s.Store.Update(catalogs, catalogID, Object{"_projectIds": boundProjectIDs})
+	w.WriteHeader(http.StatusOK)
+}
+
+// Package of the repository, nil if it does not exist
+func (s *Server) findPackage(repository string, packageName string) Object {
+	found := s.Store.List(packages, func(pkg Object) bool {
+		return pkg["repoName"] == repository && pkg["packageName"] == packageName
+	})
+	if len(found) == 0 {
+		return nil
+	}
+	return found[0]
+}
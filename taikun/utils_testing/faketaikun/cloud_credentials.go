@@ -0,0 +1,152 @@
+package faketaikun
+
+import (
+	"net/http"
+)
+
+const cloudCredentials = "cloudcredentials"
+
+// Clouds of the cloud credentials, kept by the fake server to serve the list endpoint of each cloud
+const cloudZadara = "Zadara"
+
+// Emulate the Zadara cloud credential endpoints, used by taikun_cloud_credential_zadara, and the lock and delete endpoints
+// shared by all cloud credentials. Cloud credentials of the other clouds are not emulated.
+func registerCloudCredentials(s *Server) {
+	s.mux.HandleFunc("POST /api/v1/zadara/create", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+
+		organizationID, ok := bodyInt32(body, "organizationId")
+		if !ok {
+			organizationID = DefaultOrganizationID
+		}
+		organizationName := s.organizationName(organizationID)
+		if organizationName == "" {
+			writeNotFound(w, "Organization", organizationID)
+			return
+		}
+
+		// Zadara has a single availability zone per region
+		azCount, ok := bodyInt32(body, "azCount")
+		if !ok {
+			azCount = 1
+		}
+		availabilityZones := make([]string, azCount)
+		for i := range availabilityZones {
+			availabilityZones[i] = bodyString(body, "zadaraRegion")
+		}
+
+		cloudCredential := Object{
+			"name":                   bodyString(body, "name"),
+			"createdBy":              userName,
+			"isDefault":              false,
+			"isLocked":               false,
+			"organizationId":         organizationID,
+			"organizationName":       organizationName,
+			"availabilityZones":      availabilityZones,
+			"availabilityZonesCount": azCount,
+			"region":                 bodyString(body, "zadaraRegion"),
+			"zadaraApiUrl":           bodyString(body, "zadaraUrl"),
+			"zadaraVolumeType":       bodyString(body, "zadaraVolumeType"),
+			"_cloud":                 cloudZadara,
+			"_accessKeyId":           bodyString(body, "zadaraAccessKeyId"),
+		}
+		for key, value := range lastModifiedFields() {
+			cloudCredential[key] = value
+		}
+		writeCreated(w, s.Store.Create(cloudCredentials, cloudCredential))
+	})
+
+	s.mux.HandleFunc("GET /api/v1/zadara/list", func(w http.ResponseWriter, r *http.Request) {
+		s.writeCloudCredentialList(w, r, cloudZadara)
+	})
+
+	s.mux.HandleFunc("PUT /api/v1/zadara/update", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+		id, _ := bodyInt32(body, "id")
+
+		if !s.updateCloudCredential(w, id, cloudZadara, Object{
+			"name":         bodyString(body, "name"),
+			"_accessKeyId": bodyString(body, "zadaraAccessKeyId"),
+		}) {
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	s.mux.HandleFunc("POST /api/v1/cloudcredentials/lockmanager", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+		id, _ := bodyInt32(body, "id")
+
+		fields := lastModifiedFields()
+		fields["isLocked"] = bodyString(body, "mode") == "lock"
+		if !s.Store.Update(cloudCredentials, id, fields) {
+			writeNotFound(w, "Cloud credential", id)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	s.mux.HandleFunc("DELETE /api/v1/cloudcredentials/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := pathInt32(r, "id")
+
+		cloudCredential, found := s.Store.Get(cloudCredentials, id)
+		if !found {
+			writeNotFound(w, "Cloud credential", id)
+			return
+		}
+		if cloudCredential["isLocked"] == true {
+			writeError(w, http.StatusBadRequest, "Bad Request", "The cloud credential is locked.")
+			return
+		}
+		if len(s.Store.List(projects, func(project Object) bool { return project["cloudId"] == id })) != 0 {
+			writeError(w, http.StatusBadRequest, "Bad Request", "The cloud credential is used by a project.")
+			return
+		}
+
+		s.Store.Delete(cloudCredentials, id)
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// List the cloud credentials of the cloud
+func (s *Server) writeCloudCredentialList(w http.ResponseWriter, r *http.Request, cloud string) {
+	id, filterByID := queryInt32(r, "Id")
+	organizationID, filterByOrganization := queryInt32(r, "OrganizationId")
+	writeList(w, s.Store.List(cloudCredentials, func(cloudCredential Object) bool {
+		if cloudCredential["_cloud"] != cloud {
+			return false
+		}
+		if filterByID && cloudCredential["id"] != id {
+			return false
+		}
+		return !filterByOrganization || cloudCredential["organizationId"] == organizationID
+	}))
+}
+
+// Set the fields of a cloud credential of the cloud, return false after writing the error if it cannot be updated
+func (s *Server) updateCloudCredential(w http.ResponseWriter, id int32, cloud string, fields Object) bool {
+	cloudCredential, found := s.Store.Get(cloudCredentials, id)
+	if !found || cloudCredential["_cloud"] != cloud {
+		writeNotFound(w, "Cloud credential", id)
+		return false
+	}
+	if cloudCredential["isLocked"] == true {
+		writeError(w, http.StatusBadRequest, "Bad Request", "The cloud credential is locked.")
+		return false
+	}
+
+	for key, value := range lastModifiedFields() {
+		fields[key] = value
+	}
+	s.Store.Update(cloudCredentials, id, fields)
+	return true
+}
@@ -0,0 +1,127 @@
+package faketaikun
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const kubeconfigs = "kubeconfigs"
+
+// Content of the kubeconfig downloaded from the fake server, its user is named after the kubeconfig
+func KubeconfigContent(id int32) string {
+	return fmt.Sprintf(`apiVersion: v1
+clusters:
+- cluster:
+    server: https://kubernetes.example.com
+  name: fake-cluster
+contexts:
+- context:
+    cluster: fake-cluster
+    user: kubeconfig-%d
+  name: fake-context
+current-context: fake-context
+kind: Config
+users:
+- name: kubeconfig-%d
+  user:
+    token: fake-kubeconfig-token-%d
+`, id, id, id)
+}
+
+// Add a personal kubeconfig to the project, as if it was created outside of Terraform
+func (s *Server) AddKubeconfig(projectID int32, name string) int32 {
+	project, _ := s.Store.Get(projects, projectID)
+	return s.Store.Create(kubeconfigs, Object{
+		"displayName":            name,
+		"projectId":              projectID,
+		"projectName":            project["name"],
+		"userId":                 userName,
+		"userName":               userName,
+		"createdBy":              userName,
+		"namespace":              "",
+		"isAccessibleForAll":     false,
+		"isAccessibleForManager": false,
+	})
+}
+
+// Number of kubeconfigs of the project which have not been deleted
+func (s *Server) CountKubeconfigs(projectID int32) int {
+	return len(s.Store.List(kubeconfigs, func(kubeconfig Object) bool { return kubeconfig["projectId"] == projectID }))
+}
+
+// Emulate the kubeconfig endpoints, used by taikun_kubeconfig and its ephemeral resource.
+// Kubeconfigs do not expire, their validity period is kept as given.
+func registerKubeconfigs(s *Server) {
+	s.mux.HandleFunc("POST /api/v1/kubeconfig", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+
+		projectID, _ := bodyInt32(body, "projectId")
+		project, found := s.Store.Get(projects, projectID)
+		if !found {
+			writeNotFound(w, "Project", projectID)
+			return
+		}
+		roleID, _ := bodyInt32(body, "kubeConfigRoleId")
+		ttl, _ := bodyInt32(body, "ttl")
+
+		kubeconfig := Object{
+			"displayName":            bodyString(body, "name"),
+			"projectId":              projectID,
+			"projectName":            project["name"],
+			"userId":                 userName,
+			"userName":               userName,
+			"createdBy":              userName,
+			"namespace":              bodyString(body, "namespace"),
+			"isAccessibleForAll":     body["isAccessibleForAll"] == true,
+			"isAccessibleForManager": body["isAccessibleForManager"] == true,
+			"_roleId":                roleID,
+			"_ttl":                   ttl,
+		}
+		writeCreated(w, s.Store.Create(kubeconfigs, kubeconfig))
+	})
+
+	s.mux.HandleFunc("GET /api/v1/kubeconfig", func(w http.ResponseWriter, r *http.Request) {
+		id, filterByID := queryInt32(r, "Id")
+		projectID, filterByProject := queryInt32(r, "ProjectId")
+		writeList(w, s.Store.List(kubeconfigs, func(kubeconfig Object) bool {
+			if filterByID && kubeconfig["id"] != id {
+				return false
+			}
+			return !filterByProject || kubeconfig["projectId"] == projectID
+		}))
+	})
+
+	// The content is returned as a JSON string
+	s.mux.HandleFunc("POST /api/v1/kubeconfig/download", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+		id, _ := bodyInt32(body, "id")
+		projectID, _ := bodyInt32(body, "projectId")
+
+		kubeconfig, found := s.Store.Get(kubeconfigs, id)
+		if !found || kubeconfig["projectId"] != projectID {
+			writeNotFound(w, "Kubeconfig", id)
+			return
+		}
+		writeJSON(w, http.StatusOK, KubeconfigContent(id))
+	})
+
+	s.mux.HandleFunc("POST /api/v1/kubeconfig/delete", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+		id, _ := bodyInt32(body, "id")
+
+		if !s.Store.Delete(kubeconfigs, id) {
+			writeNotFound(w, "Kubeconfig", id)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,102 @@
+package faketaikun
+
+import (
+	"net/http"
+)
+
+const kubernetesProfiles = "kubernetesprofiles"
+
+// Emulate the Kubernetes profile endpoints, used by taikun_kubernetes_profile
+func registerKubernetesProfiles(s *Server) {
+	s.mux.HandleFunc("POST /api/v1/kubernetesprofiles", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+
+		organizationID, ok := bodyInt32(body, "organizationId")
+		if !ok {
+			organizationID = DefaultOrganizationID
+		}
+		organizationName := s.organizationName(organizationID)
+		if organizationName == "" {
+			writeNotFound(w, "Organization", organizationID)
+			return
+		}
+
+		// Taikun stores the profiles on NFS unless told otherwise
+		proxmoxStorage := bodyString(body, "proxmoxStorage")
+		if proxmoxStorage == "" {
+			proxmoxStorage = "NFS"
+		}
+
+		kubernetesProfile := Object{
+			"name":                     bodyString(body, "name"),
+			"cni":                      "Calico",
+			"createdBy":                userName,
+			"isLocked":                 false,
+			"organizationId":           organizationID,
+			"organizationName":         organizationName,
+			"allowSchedulingOnMaster":  body["allowSchedulingOnMaster"] == true,
+			"exposeNodePortOnBastion":  body["exposeNodePortOnBastion"] == true,
+			"nvidiaGpuOperatorEnabled": body["nvidiaGpuOperatorEnabled"] == true,
+			"octaviaEnabled":           body["octaviaEnabled"] == true,
+			"taikunLBEnabled":          body["taikunLBEnabled"] == true,
+			"uniqueClusterName":        body["uniqueClusterName"] == true,
+			"wasmEnabled":              body["wasmEnabled"] == true,
+			"proxmoxStorage":           proxmoxStorage,
+		}
+		for key, value := range lastModifiedFields() {
+			kubernetesProfile[key] = value
+		}
+		writeCreated(w, s.Store.Create(kubernetesProfiles, kubernetesProfile))
+	})
+
+	s.mux.HandleFunc("GET /api/v1/kubernetesprofiles/list", func(w http.ResponseWriter, r *http.Request) {
+		id, filterByID := queryInt32(r, "Id")
+		organizationID, filterByOrganization := queryInt32(r, "OrganizationId")
+		writeList(w, s.Store.List(kubernetesProfiles, func(kubernetesProfile Object) bool {
+			if filterByID && kubernetesProfile["id"] != id {
+				return false
+			}
+			return !filterByOrganization || kubernetesProfile["organizationId"] == organizationID
+		}))
+	})
+
+	s.mux.HandleFunc("POST /api/v1/kubernetesprofiles/lockmanager", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+		id, _ := bodyInt32(body, "id")
+
+		fields := lastModifiedFields()
+		fields["isLocked"] = bodyString(body, "mode") == "lock"
+		if !s.Store.Update(kubernetesProfiles, id, fields) {
+			writeNotFound(w, "Kubernetes profile", id)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	s.mux.HandleFunc("DELETE /api/v1/kubernetesprofiles/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := pathInt32(r, "id")
+
+		kubernetesProfile, found := s.Store.Get(kubernetesProfiles, id)
+		if !found {
+			writeNotFound(w, "Kubernetes profile", id)
+			return
+		}
+		if kubernetesProfile["isLocked"] == true {
+			writeError(w, http.StatusBadRequest, "Bad Request", "The Kubernetes profile is locked.")
+			return
+		}
+		if len(s.Store.List(projects, func(project Object) bool { return project["kubernetesProfileId"] == id })) != 0 {
+			writeError(w, http.StatusBadRequest, "Bad Request", "The Kubernetes profile is used by a project.")
+			return
+		}
+
+		s.Store.Delete(kubernetesProfiles, id)
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,271 @@
+package faketaikun
+
+import (
+	b64 "encoding/base64"
+	"fmt"
+	"net/http"
+)
+
+const projectApps = "projectapps"
+
+// Statuses of the application instances, as reported by Taikun
+const (
+	appStatusInstalling   = "Installing"
+	appStatusUninstalling = "Uninstalling"
+	appStatusReady        = "Ready"
+	appStatusFailure      = "Failure"
+)
+
+// Emulate the application instance endpoints, used by taikun_app_instance and taikun_app_deployment.
+// Installs, syncs, upgrades and rollbacks go through Installing before the application is Ready, or Failure
+// in the projects given to FailAppInstalls. Deletes go through Uninstalling before the application is gone.
+// Each sync of new values adds a revision to the Helm release, which rollbacks return to.
+func registerProjectApps(s *Server) {
+	s.mux.HandleFunc("POST /api/v1/projectapp/install", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+
+		projectID, _ := bodyInt32(body, "projectId")
+		project, found := s.Store.Get(projects, projectID)
+		if !found {
+			writeNotFound(w, "Project", projectID)
+			return
+		}
+		catalogAppID, _ := bodyInt32(body, "catalogAppId")
+		catalogApp, found := s.Store.Get(catalogApps, catalogAppID)
+		if !found {
+			writeNotFound(w, "Catalog app", catalogAppID)
+			return
+		}
+		name, namespace := bodyString(body, "name"), bodyString(body, "namespace")
+		if len(s.Store.List(projectApps, func(projectApp Object) bool {
+			return projectApp["projectId"] == projectID && projectApp["name"] == name
+		})) != 0 {
+			writeError(w, http.StatusBadRequest, "Bad Request", fmt.Sprintf("Application %s already exists in project %d.", name, projectID))
+			return
+		}
+
+		version := bodyString(body, "version")
+		if version == "" {
+			version = catalogApp["_versions"].([]string)[0]
+		} else if !catalogAppHasVersion(catalogApp, version) {
+			writeError(w, http.StatusBadRequest, "Bad Request", fmt.Sprintf("Version %s of %s does not exist.", version, catalogApp["packageName"]))
+			return
+		}
+		values, ok := decodeExtraValues(w, body)
+		if !ok {
+			return
+		}
+
+		projectApp := Object{
+			"name":              name,
+			"namespace":         namespace,
+			"projectId":         projectID,
+			"projectName":       project["name"],
+			"catalogAppId":      catalogAppID,
+			"repoName":          catalogApp["repoName"],
+			"packageName":       catalogApp["packageName"],
+			"version":           version,
+			"values":            values,
+			"_revisions":        []string{values},
+			"autoSync":          body["autoSync"] == true,
+			"taikunLinkEnabled": body["taikunLinkEnabled"] == true,
+			"taikunLinkUrl":     "",
+			"status":            "NotReady",
+			"healthStatus":      "",
+			"message":           "",
+		}
+		for key, value := range lastModifiedFields() {
+			projectApp[key] = value
+		}
+		id := s.Store.Create(projectApps, projectApp)
+		s.queueProjectAppSync(id, project)
+		writeCreated(w, id)
+	})
+
+	s.mux.HandleFunc("GET /api/v1/projectapp/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := pathInt32(r, "id")
+		projectApp, found := s.Store.Get(projectApps, id)
+		if !found {
+			writeNotFound(w, "Project app", id)
+			return
+		}
+		writeJSON(w, http.StatusOK, apiObject(projectApp))
+	})
+
+	s.mux.HandleFunc("GET /api/v1/projectapp/list", func(w http.ResponseWriter, r *http.Request) {
+		id, filterByID := queryInt32(r, "Id")
+		projectID, filterByProject := queryInt32(r, "ProjectId")
+		writeList(w, s.Store.List(projectApps, func(projectApp Object) bool {
+			if filterByID && projectApp["id"] != id {
+				return false
+			}
+			return !filterByProject || projectApp["projectId"] == projectID
+		}))
+	})
+
+	s.mux.HandleFunc("GET /api/v1/projectapp/{id}/events", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := pathInt32(r, "id")
+		if _, found := s.Store.Get(projectApps, id); !found {
+			writeNotFound(w, "Project app", id)
+			return
+		}
+		writeJSON(w, http.StatusOK, []Object{})
+	})
+
+	s.mux.HandleFunc("PUT /api/v1/projectapp/extra-values", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+		id, _ := bodyInt32(body, "projectAppId")
+		values, ok := decodeExtraValues(w, body)
+		if !ok {
+			return
+		}
+
+		fields := lastModifiedFields()
+		fields["values"] = values
+		if !s.Store.Update(projectApps, id, fields) {
+			writeNotFound(w, "Project app", id)
+			return
+		}
+		projectApp, _ := s.Store.Get(projectApps, id)
+		if projectApp["autoSync"] == true {
+			s.syncProjectApp(id, projectApp)
+		}
+		writeJSON(w, http.StatusOK, Object{})
+	})
+
+	s.mux.HandleFunc("POST /api/v1/projectapp/sync", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+		id, _ := bodyInt32(body, "projectAppId")
+		projectApp, found := s.Store.Get(projectApps, id)
+		if !found {
+			writeNotFound(w, "Project app", id)
+			return
+		}
+		s.syncProjectApp(id, projectApp)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	s.mux.HandleFunc("POST /api/v1/projectapp/autosync", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+		id, _ := bodyInt32(body, "id")
+
+		fields := lastModifiedFields()
+		fields["autoSync"] = bodyString(body, "mode") == "enable"
+		if !s.Store.Update(projectApps, id, fields) {
+			writeNotFound(w, "Project app", id)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	s.mux.HandleFunc("PUT /api/v1/projectapp/edit/version", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+		id, _ := bodyInt32(body, "projectAppId")
+		projectApp, found := s.Store.Get(projectApps, id)
+		if !found {
+			writeNotFound(w, "Project app", id)
+			return
+		}
+		catalogApp, _ := s.Store.Get(catalogApps, projectApp["catalogAppId"].(int32))
+		version := bodyString(body, "version")
+		if !catalogAppHasVersion(catalogApp, version) {
+			writeError(w, http.StatusBadRequest, "Bad Request", fmt.Sprintf("Version %s of %s does not exist.", version, catalogApp["packageName"]))
+			return
+		}
+
+		fields := lastModifiedFields()
+		fields["version"] = version
+		s.Store.Update(projectApps, id, fields)
+		s.syncProjectApp(id, projectApp)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	s.mux.HandleFunc("POST /api/v1/projectapp/rollback", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+		id, _ := bodyInt32(body, "projectAppId")
+		projectApp, found := s.Store.Get(projectApps, id)
+		if !found {
+			writeNotFound(w, "Project app", id)
+			return
+		}
+		revisions := projectApp["_revisions"].([]string)
+		revision, _ := bodyInt32(body, "revision")
+		if revision < 1 || int(revision) > len(revisions) {
+			writeError(w, http.StatusBadRequest, "Bad Request", fmt.Sprintf("Revision %d of application %d does not exist.", revision, id))
+			return
+		}
+
+		// Like helm rollback, the values of the revision are deployed as a new revision
+		fields := lastModifiedFields()
+		fields["values"] = revisions[revision-1]
+		s.Store.Update(projectApps, id, fields)
+		projectApp["values"] = revisions[revision-1]
+		s.syncProjectApp(id, projectApp)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	s.mux.HandleFunc("DELETE /api/v1/projectapp/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := pathInt32(r, "id")
+		if !s.Store.Update(projectApps, id, Object{"status": appStatusUninstalling}) {
+			writeNotFound(w, "Project app", id)
+			return
+		}
+		// The uninstall replaces any sync in progress
+		s.Store.DropChanges(projectApps, id)
+		s.Store.QueueChanges(projectApps, id, Object{"status": appStatusUninstalling})
+		s.Store.QueueDelete(projectApps, id)
+		writeJSON(w, http.StatusOK, Object{})
+	})
+}
+
+// Deploy the values of the application as a new revision of its release
+func (s *Server) syncProjectApp(id int32, projectApp Object) {
+	revisions := projectApp["_revisions"].([]string)
+	s.Store.Update(projectApps, id, Object{"_revisions": append(revisions[:len(revisions):len(revisions)], projectApp["values"].(string))})
+
+	project, _ := s.Store.Get(projects, projectApp["projectId"].(int32))
+	s.queueProjectAppSync(id, project)
+}
+
+// Statuses of the application while its release is deployed in the project
+func (s *Server) queueProjectAppSync(id int32, project Object) {
+	if project["_failAppInstalls"] == true {
+		s.Store.QueueChanges(projectApps, id,
+			Object{"status": appStatusInstalling},
+			Object{"status": appStatusFailure, "healthStatus": "Degraded", "message": "release failed: context deadline exceeded"},
+		)
+		return
+	}
+	s.Store.QueueChanges(projectApps, id,
+		Object{"status": appStatusInstalling, "healthStatus": "Progressing"},
+		Object{"status": appStatusReady, "healthStatus": "Healthy", "message": ""},
+	)
+}
+
+// Values of the release, given base64 encoded in extraValues
+func decodeExtraValues(w http.ResponseWriter, body Object) (string, bool) {
+	values, err := b64.StdEncoding.DecodeString(bodyString(body, "extraValues"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Bad Request", fmt.Sprintf("Invalid extraValues: %s", err))
+		return "", false
+	}
+	return string(values), true
+}
@@ -0,0 +1,309 @@
+package faketaikun
+
+import (
+	"net/http"
+	"slices"
+)
+
+const (
+	projects    = "projects"
+	catalogApps = "catalogapps"
+)
+
+// Statuses of the projects, as reported by Taikun
+const (
+	projectStatusPending  = "Pending"
+	projectStatusUpdating = "Updating"
+	projectStatusReady    = "Ready"
+)
+
+// Kubernetes version of the projects created without one
+const defaultKubernetesVersion = "v1.30.2"
+
+// Add a Kubernetes project of the default organization, ready for applications to be installed.
+// Tests which do not need taikun_project seed their projects with AddProject.
+func (s *Server) AddProject(name string) int32 {
+	return s.Store.Create(projects, newProject(name, DefaultOrganizationID, DefaultOrganizationName))
+}
+
+// Project without servers, as created by Taikun
+func newProject(name string, organizationID int32, organizationName string) Object {
+	project := Object{
+		"name":                name,
+		"organizationId":      organizationID,
+		"organizationName":    organizationName,
+		"status":              projectStatusReady,
+		"health":              "Healthy",
+		"isLocked":            false,
+		"isMonitoringEnabled": false,
+		"deleteOnExpiration":  false,
+		"kubernetesVersion":   defaultKubernetesVersion,
+		"_flavors":            []string{},
+		// The quotas of Taikun projects are the ones taikun_project defaults to
+		"_quota": Object{
+			"serverCpu":      int64(300),
+			"serverRam":      int64(500) << 30,
+			"serverDiskSize": int64(2048) << 30,
+			"vmCpu":          int64(300),
+			"vmRam":          int64(500) << 30,
+			"vmVolumeSize":   int64(2048),
+		},
+	}
+	for key, value := range lastModifiedFields() {
+		project[key] = value
+	}
+	return project
+}
+
+// Make the applications installed in the project fail, after going through Installing
+func (s *Server) FailAppInstalls(projectID int32) {
+	s.Store.Update(projects, projectID, Object{"_failAppInstalls": true})
+}
+
+// Change the values of an application instance in Taikun, outside of Terraform, without syncing it
+func (s *Server) EditAppValues(projectAppID int32, values string) {
+	s.Store.Update(projectApps, projectAppID, Object{"values": values})
+}
+
+// Add an application of a catalog, with the versions of its package, the first one being installed by default.
+// Tests which do not need taikun_catalog seed their catalog applications with AddCatalogApp.
+func (s *Server) AddCatalogApp(repository string, packageName string, versions ...string) int32 {
+	return s.Store.Create(catalogApps, Object{
+		"name":        packageName,
+		"repoName":    repository,
+		"packageName": packageName,
+		"_versions":   versions,
+	})
+}
+
+// Make listing the versions of the package of the catalog application fail
+func (s *Server) FailPackageVersions(catalogAppID int32) {
+	s.Store.Update(catalogApps, catalogAppID, Object{"_failVersions": true})
+}
+
+// Emulate the project endpoints, used by taikun_project and to select projects, and the versions of the packages.
+// Projects are created empty; their servers are emulated by registerServers. Virtual machines, images, autoscaling,
+// spots, backups, monitoring and policy profiles are not emulated.
+func registerProjects(s *Server) {
+	s.mux.HandleFunc("POST /api/v1/projects", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+
+		organizationID, ok := bodyInt32(body, "organizationId")
+		if !ok {
+			organizationID = DefaultOrganizationID
+		}
+		organizationName := s.organizationName(organizationID)
+		if organizationName == "" {
+			writeNotFound(w, "Organization", organizationID)
+			return
+		}
+		cloudCredentialID, _ := bodyInt32(body, "cloudCredentialId")
+		if _, found := s.Store.Get(cloudCredentials, cloudCredentialID); !found {
+			writeNotFound(w, "Cloud credential", cloudCredentialID)
+			return
+		}
+		kubernetesProfileID, ok := bodyInt32(body, "kubernetesProfileId")
+		if _, found := s.Store.Get(kubernetesProfiles, kubernetesProfileID); ok && !found {
+			writeNotFound(w, "Kubernetes profile", kubernetesProfileID)
+			return
+		}
+
+		project := newProject(bodyString(body, "name"), organizationID, organizationName)
+		project["cloudId"] = cloudCredentialID
+		project["kubernetesProfileId"] = kubernetesProfileID
+		project["accessProfileId"], _ = bodyInt32(body, "accessProfileId")
+		project["alertingProfileId"], _ = bodyInt32(body, "alertingProfileId")
+		project["isMonitoringEnabled"] = body["isMonitoringEnabled"] == true
+		project["deleteOnExpiration"] = body["deleteOnExpiration"] == true
+		if expiredAt := bodyString(body, "expiredAt"); expiredAt != "" {
+			project["expiredAt"] = expiredAt
+		}
+		if kubernetesVersion := bodyString(body, "kubernetesVersion"); kubernetesVersion != "" {
+			project["kubernetesVersion"] = kubernetesVersion
+		}
+		flavors := []string{}
+		if flavorsData, ok := body["flavors"].([]interface{}); ok {
+			for _, flavor := range flavorsData {
+				flavors = append(flavors, flavor.(string))
+			}
+		}
+		project["_flavors"] = flavors
+		writeCreated(w, s.Store.Create(projects, project))
+	})
+
+	// Details of the project with its servers
+	s.mux.HandleFunc("GET /api/v1/servers/{projectId}", func(w http.ResponseWriter, r *http.Request) {
+		projectID, _ := pathInt32(r, "projectId")
+
+		project, found := s.Store.Get(projects, projectID)
+		if !found {
+			writeNotFound(w, "Project", projectID)
+			return
+		}
+		projectServers := []Object{}
+		for _, server := range s.Store.List(servers, func(server Object) bool { return server["projectId"] == projectID }) {
+			projectServers = append(projectServers, apiObject(server))
+		}
+		writeJSON(w, http.StatusOK, Object{
+			"project": apiObject(project),
+			"data":    projectServers,
+		})
+	})
+
+	// Details of the project with its virtual machines, which are not emulated
+	s.mux.HandleFunc("GET /api/v1/standalone/{projectId}", func(w http.ResponseWriter, r *http.Request) {
+		projectID, _ := pathInt32(r, "projectId")
+
+		project, found := s.Store.Get(projects, projectID)
+		if !found {
+			writeNotFound(w, "Project", projectID)
+			return
+		}
+		writeJSON(w, http.StatusOK, Object{
+			"project": apiObject(project),
+			"data":    []Object{},
+		})
+	})
+
+	s.mux.HandleFunc("GET /api/v1/flavors/projects/list", func(w http.ResponseWriter, r *http.Request) {
+		projectID, _ := queryInt32(r, "ProjectId")
+
+		boundFlavors := []Object{}
+		if project, found := s.Store.Get(projects, projectID); found {
+			for _, flavor := range project["_flavors"].([]string) {
+				boundFlavors = append(boundFlavors, Object{"name": flavor, "projectId": projectID})
+			}
+		}
+		writeList(w, boundFlavors)
+	})
+
+	s.mux.HandleFunc("GET /api/v1/images/projects/list", func(w http.ResponseWriter, r *http.Request) {
+		writeList(w, []Object{})
+	})
+
+	// Each project has its own quotas, with the ID of the project
+	s.mux.HandleFunc("GET /api/v1/projectquotas/list", func(w http.ResponseWriter, r *http.Request) {
+		projectID, _ := queryInt32(r, "Id")
+
+		quotas := []Object{}
+		if project, found := s.Store.Get(projects, projectID); found {
+			quota := copyObject(project["_quota"].(Object))
+			quota["id"] = projectID
+			quota["projectId"] = projectID
+			quota["projectName"] = project["name"]
+			quotas = append(quotas, quota)
+		}
+		writeList(w, quotas)
+	})
+
+	s.mux.HandleFunc("PUT /api/v1/projectquotas/update", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+		projectID, _ := bodyInt32(body, "quotaId")
+
+		project, found := s.Store.Get(projects, projectID)
+		if !found {
+			writeNotFound(w, "Project quota", projectID)
+			return
+		}
+		quota := copyObject(project["_quota"].(Object))
+		for _, field := range []string{"serverCpu", "serverRam", "serverDiskSize", "vmCpu", "vmRam", "vmVolumeSize"} {
+			if value, ok := body[field].(float64); ok {
+				quota[field] = int64(value)
+			}
+		}
+		s.Store.Update(projects, projectID, Object{"_quota": quota})
+		w.WriteHeader(http.StatusOK)
+	})
+
+	s.mux.HandleFunc("POST /api/v1/projects/lockmanager", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+		id, _ := bodyInt32(body, "id")
+
+		fields := lastModifiedFields()
+		fields["isLocked"] = bodyString(body, "mode") == "lock"
+		if !s.Store.Update(projects, id, fields) {
+			writeNotFound(w, "Project", id)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// Deleting a project deletes the servers left in it
+	s.mux.HandleFunc("POST /api/v1/projects/delete", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+		projectID, _ := bodyInt32(body, "projectId")
+
+		project, found := s.Store.Get(projects, projectID)
+		if !found {
+			writeNotFound(w, "Project", projectID)
+			return
+		}
+		if project["isLocked"] == true {
+			writeError(w, http.StatusBadRequest, "Bad Request", "The project is locked.")
+			return
+		}
+
+		for _, server := range s.Store.List(servers, func(server Object) bool { return server["projectId"] == projectID }) {
+			s.Store.Delete(servers, server["id"].(int32))
+		}
+		s.Store.Delete(projects, projectID)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	s.mux.HandleFunc("GET /api/v1/projects/list", func(w http.ResponseWriter, r *http.Request) {
+		id, filterByID := queryInt32(r, "Id")
+		organizationID, filterByOrganization := queryInt32(r, "OrganizationId")
+		writeList(w, s.Store.List(projects, func(project Object) bool {
+			if filterByID && project["id"] != id {
+				return false
+			}
+			return !filterByOrganization || project["organizationId"] == organizationID
+		}))
+	})
+
+	s.mux.HandleFunc("GET /api/v1/package/versions/{repositoryName}/{packageName}", func(w http.ResponseWriter, r *http.Request) {
+		repository, packageName := r.PathValue("repositoryName"), r.PathValue("packageName")
+		// Packages added with AddPackage, or seeded with their catalog application by AddCatalogApp
+		pkg := s.findPackage(repository, packageName)
+		if pkg == nil {
+			pkg = s.findCatalogApp(repository, packageName)
+		}
+		if pkg == nil {
+			writeError(w, http.StatusNotFound, "Not Found", "Package "+repository+"/"+packageName+" not found.")
+			return
+		}
+		if pkg["_failVersions"] == true {
+			writeError(w, http.StatusBadRequest, "Bad Request", "The repository "+repository+" cannot be read.")
+			return
+		}
+		writeJSON(w, http.StatusOK, pkg["_versions"])
+	})
+}
+
+// Catalog application of the package, nil if it does not exist
+func (s *Server) findCatalogApp(repository string, packageName string) Object {
+	found := s.Store.List(catalogApps, func(catalogApp Object) bool {
+		return catalogApp["repoName"] == repository && catalogApp["packageName"] == packageName
+	})
+	if len(found) == 0 {
+		return nil
+	}
+	return found[0]
+}
+
+// Whether the package of the catalog application has the version
+func catalogAppHasVersion(catalogApp Object, version string) bool {
+	return slices.Contains(catalogApp["_versions"].([]string), version)
+}
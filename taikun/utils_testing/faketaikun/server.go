@@ -0,0 +1,236 @@
+package faketaikun
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+)
+
+// Bearer token expected by the fake server, set in TAIKUN_TOKEN by Start
+const Token = "fake-taikun-token"
+
+// Organization existing in every fake server, used by resources which do not specify organization_id
+const (
+	DefaultOrganizationID   int32 = 1
+	DefaultOrganizationName       = "fake-organization"
+)
+
+// User reported as creator and last modifier of the objects
+const userName = "fake-user"
+
+// Fake Taikun API keeping its state in memory.
+// It lets resources be tested with resource.UnitTest, without a Taikun account nor cloud credentials.
+// Each kind of object is served by a register function, endpoints which are not emulated answer 501 Not Implemented.
+// The fake server emulates backup credentials, Zadara cloud credentials, Kubernetes profiles, catalogs, projects with their
+// Kubernetes servers, application instances and kubeconfigs. Virtual machines, the other clouds and the other profiles are not emulated.
+type Server struct {
+	*httptest.Server
+	Store *Store
+	mux   *http.ServeMux
+}
+
+func NewServer() *Server {
+	server := &Server{
+		Store: NewStore(),
+		mux:   http.NewServeMux(),
+	}
+	server.Store.collections["organizations"] = map[int32]Object{
+		DefaultOrganizationID: {"id": DefaultOrganizationID, "name": DefaultOrganizationName},
+	}
+	server.Store.lastID = DefaultOrganizationID
+
+	server.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotImplemented, "Not implemented", fmt.Sprintf("%s %s is not emulated by the fake Taikun server.", r.Method, r.URL.Path))
+	})
+	registerBackupCredentials(server)
+	registerCloudCredentials(server)
+	registerKubernetesProfiles(server)
+	registerCatalogs(server)
+	registerProjects(server)
+	registerServers(server)
+	registerProjectApps(server)
+	registerKubeconfigs(server)
+
+	server.Server = httptest.NewServer(server.authenticate(server.mux))
+	return server
+}
+
+// Start a fake server for the duration of the test and configure the provider to use it through the environment.
+// The test cannot run in parallel, as it changes the environment.
+func Start(t *testing.T) *Server {
+	t.Helper()
+
+	server := NewServer()
+	t.Cleanup(server.Close)
+
+	// Statuses progress with each read of the fake server, there is nothing to wait for between the polls
+	waitForStateHook := utils.WaitForStateHook
+	utils.WaitForStateHook = utils.NoWaitDelay
+	t.Cleanup(func() { utils.WaitForStateHook = waitForStateHook })
+
+	t.Setenv("TAIKUN_API_HOST", server.URL)
+	t.Setenv("TAIKUN_TOKEN", Token)
+	for _, variable := range []string{
+		"TAIKUN_EMAIL",
+		"TAIKUN_PASSWORD",
+		"TAIKUN_KEYCLOAK_EMAIL",
+		"TAIKUN_KEYCLOAK_PASSWORD",
+		"TAIKUN_ACCESS_KEY",
+		"TAIKUN_SECRET_KEY",
+		"TAIKUN_TOKEN_FILE",
+		"TAIKUN_OIDC_TOKEN_URL",
+		"TAIKUN_OIDC_CLIENT_ID",
+		"TAIKUN_OIDC_CLIENT_SECRET",
+		"TAIKUN_OIDC_AUDIENCE",
+		"TAIKUN_DEFAULT_ORGANIZATION_ID",
+		// Zadara cloud credentials default to the environment
+		"ZADARA_ACCESS_KEY_ID",
+		"ZADARA_SECRET_ACCESS_KEY",
+		"ZADARA_DEFAULT_REGION",
+		"ZADARA_AZ_COUNT",
+		"ZADARA_AUTH_URL",
+		"ZADARA_VOLUME_TYPE",
+	} {
+		t.Setenv(variable, "")
+	}
+
+	return server
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+Token {
+			writeError(w, http.StatusUnauthorized, "Unauthorized", "Missing or invalid bearer token.")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Name of the given organization, empty if it does not exist
+func (s *Server) organizationName(organizationID int32) string {
+	organization, found := s.Store.Get("organizations", organizationID)
+	if !found {
+		return ""
+	}
+	return organization["name"].(string)
+}
+
+// Fields set on every object when it is created or modified
+func lastModifiedFields() Object {
+	return Object{
+		"lastModified":   time.Now().UTC().Format(time.RFC3339),
+		"lastModifiedBy": userName,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// Errors have the problem details format of the Taikun API
+func writeError(w http.ResponseWriter, status int, title string, detail string) {
+	writeJSON(w, status, Object{
+		"title":  title,
+		"status": status,
+		"detail": detail,
+	})
+}
+
+func writeNotFound(w http.ResponseWriter, kind string, id int32) {
+	writeError(w, http.StatusNotFound, "Not Found", fmt.Sprintf("%s with ID %d not found.", kind, id))
+}
+
+// Response of the create endpoints
+func writeCreated(w http.ResponseWriter, id int32) {
+	writeJSON(w, http.StatusOK, Object{
+		"id":      strconv.Itoa(int(id)),
+		"isError": false,
+		"message": "Created successfully",
+	})
+}
+
+// Object as returned by the API.
+// Fields starting with an underscore are kept by the fake server for itself, such as the revisions of a release, Taikun does not return them.
+func apiObject(object Object) Object {
+	returned := Object{}
+	for key, value := range object {
+		if !strings.HasPrefix(key, "_") {
+			returned[key] = value
+		}
+	}
+	return returned
+}
+
+// Response of the list endpoints
+func writeList(w http.ResponseWriter, objects []Object) {
+	data := make([]Object, 0, len(objects))
+	for _, object := range objects {
+		data = append(data, apiObject(object))
+	}
+	writeJSON(w, http.StatusOK, Object{
+		"data":       data,
+		"totalCount": len(objects),
+	})
+}
+
+func readBody(w http.ResponseWriter, r *http.Request) (Object, bool) {
+	body := Object{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "Bad Request", fmt.Sprintf("Invalid JSON body: %s", err))
+		return nil, false
+	}
+	return body, true
+}
+
+// Integer query parameter, the generated client does not always use the same case as the API documentation
+func queryInt32(r *http.Request, name string) (int32, bool) {
+	for key, values := range r.URL.Query() {
+		if strings.EqualFold(key, name) && len(values) != 0 {
+			value, err := strconv.ParseInt(values[0], 10, 32)
+			if err != nil {
+				return 0, false
+			}
+			return int32(value), true
+		}
+	}
+	return 0, false
+}
+
+// String query parameter, empty if it is not given
+func queryString(r *http.Request, name string) string {
+	for key, values := range r.URL.Query() {
+		if strings.EqualFold(key, name) && len(values) != 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+func pathInt32(r *http.Request, name string) (int32, bool) {
+	value, err := strconv.ParseInt(r.PathValue(name), 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return int32(value), true
+}
+
+// JSON numbers are decoded as float64
+func bodyInt32(body Object, name string) (int32, bool) {
+	value, ok := body[name].(float64)
+	return int32(value), ok
+}
+
+func bodyString(body Object, name string) string {
+	value, _ := body[name].(string)
+	return value
+}
@@ -0,0 +1,137 @@
+package faketaikun
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const servers = "servers"
+
+// Statuses of the servers, as reported by Taikun
+const (
+	serverStatusPending  = "Pending"
+	serverStatusUpdating = "Updating"
+	serverStatusReady    = "Ready"
+	serverStatusDeleting = "Deleting"
+)
+
+// Emulate the Kubernetes servers of the projects, used by taikun_project.
+// Servers are created Pending and deployed by a commit: the project then goes through Pending and Updating before it is
+// Ready again, and its servers through Updating. Deleted servers go through Deleting before they are gone, the project
+// stays Ready. Autoscaling is not emulated, no server belongs to an autoscaling group.
+func registerServers(s *Server) {
+	s.mux.HandleFunc("POST /api/v1/servers/create", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+
+		projectID, _ := bodyInt32(body, "projectId")
+		project, found := s.Store.Get(projects, projectID)
+		if !found {
+			writeNotFound(w, "Project", projectID)
+			return
+		}
+		if project["isLocked"] == true {
+			writeError(w, http.StatusBadRequest, "Bad Request", "The project is locked.")
+			return
+		}
+		name := bodyString(body, "name")
+		if len(s.Store.List(servers, func(server Object) bool {
+			return server["projectId"] == projectID && server["name"] == name
+		})) != 0 {
+			writeError(w, http.StatusBadRequest, "Bad Request", fmt.Sprintf("Server %s already exists in project %d.", name, projectID))
+			return
+		}
+
+		kubernetesNodeLabels, ok := body["kubernetesNodeLabels"].([]interface{})
+		if !ok {
+			kubernetesNodeLabels = []interface{}{}
+		}
+		diskSize, _ := body["diskSize"].(float64)
+
+		server := Object{
+			"projectId":            projectID,
+			"projectName":          project["name"],
+			"name":                 name,
+			"role":                 body["role"],
+			"flavor":               bodyString(body, "flavor"),
+			"diskSize":             int64(diskSize),
+			"hypervisor":           bodyString(body, "hypervisor"),
+			"availabilityZone":     bodyString(body, "availabilityZone"),
+			"wasmEnabled":          body["wasmEnabled"] == true,
+			"spotInstance":         body["spotInstance"] == true,
+			"kubernetesNodeLabels": kubernetesNodeLabels,
+			"ipAddress":            "",
+			"status":               serverStatusPending,
+			"createdBy":            userName,
+		}
+		for key, value := range lastModifiedFields() {
+			server[key] = value
+		}
+		writeCreated(w, s.Store.Create(servers, server))
+	})
+
+	s.mux.HandleFunc("GET /api/v1/servers/list", func(w http.ResponseWriter, r *http.Request) {
+		writeList(w, []Object{})
+	})
+
+	// Deploy the servers added to the project since the last commit
+	s.mux.HandleFunc("POST /api/v1/projectdeployment/commit", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+		projectID, _ := bodyInt32(body, "projectId")
+
+		if _, found := s.Store.Get(projects, projectID); !found {
+			writeNotFound(w, "Project", projectID)
+			return
+		}
+
+		s.Store.QueueChanges(projects, projectID,
+			Object{"status": projectStatusPending},
+			Object{"status": projectStatusUpdating},
+			Object{"status": projectStatusReady},
+		)
+		for _, server := range s.Store.List(servers, func(server Object) bool {
+			return server["projectId"] == projectID && server["status"] == serverStatusPending
+		}) {
+			s.Store.QueueChanges(servers, server["id"].(int32),
+				Object{"status": serverStatusUpdating},
+				Object{"status": serverStatusReady},
+			)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	s.mux.HandleFunc("POST /api/v1/projectdeployment/delete", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := readBody(w, r)
+		if !ok {
+			return
+		}
+		projectID, _ := bodyInt32(body, "projectId")
+
+		if _, found := s.Store.Get(projects, projectID); !found {
+			writeNotFound(w, "Project", projectID)
+			return
+		}
+		serverIDs, _ := body["serverIds"].([]interface{})
+		for _, serverIDData := range serverIDs {
+			serverID := int32(serverIDData.(float64))
+			server, found := s.Store.Get(servers, serverID)
+			if !found || server["projectId"] != projectID {
+				writeNotFound(w, "Server", serverID)
+				return
+			}
+		}
+
+		for _, serverIDData := range serverIDs {
+			serverID := int32(serverIDData.(float64))
+			s.Store.DropChanges(servers, serverID)
+			s.Store.QueueChanges(servers, serverID, Object{"status": serverStatusDeleting})
+			s.Store.QueueDelete(servers, serverID)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,161 @@
+package faketaikun
+
+import (
+	"sort"
+	"sync"
+)
+
+// JSON object as sent and returned by the Taikun API, keyed by the API's camelCase field names
+type Object map[string]interface{}
+
+// In-memory state of the fake Taikun API.
+// Objects are grouped by kind (for example "s3credentials") and share a single sequence of IDs, like Taikun IDs they are never reused.
+type Store struct {
+	mutex       sync.Mutex
+	lastID      int32
+	collections map[string]map[int32]Object
+	// Changes applied to an object one by one, each time the object is read, to emulate statuses progressing on the Taikun side.
+	// A nil change deletes the object.
+	pendingChanges map[string]map[int32][]Object
+}
+
+func NewStore() *Store {
+	return &Store{
+		collections:    map[string]map[int32]Object{},
+		pendingChanges: map[string]map[int32][]Object{},
+	}
+}
+
+// Add an object of the given kind and return its ID, which is also set in the object's id field
+func (s *Store) Create(kind string, object Object) int32 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.lastID++
+	id := s.lastID
+	stored := copyObject(object)
+	stored["id"] = id
+	if s.collections[kind] == nil {
+		s.collections[kind] = map[int32]Object{}
+	}
+	s.collections[kind][id] = stored
+	return id
+}
+
+// Return a copy of the object, after applying its next pending change
+func (s *Store) Get(kind string, id int32) (Object, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	object, found := s.collections[kind][id]
+	if !found {
+		return nil, false
+	}
+	if !s.applyNextChange(kind, id, object) {
+		return nil, false
+	}
+	return copyObject(object), true
+}
+
+// Return a copy of the objects of the given kind matching the filter, ordered by ID
+func (s *Store) List(kind string, filter func(Object) bool) []Object {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ids := make([]int32, 0, len(s.collections[kind]))
+	for id := range s.collections[kind] {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	objects := []Object{}
+	for _, id := range ids {
+		object := s.collections[kind][id]
+		if !s.applyNextChange(kind, id, object) {
+			continue
+		}
+		if filter == nil || filter(object) {
+			objects = append(objects, copyObject(object))
+		}
+	}
+	return objects
+}
+
+// Set the given fields of an object, return false if it does not exist
+func (s *Store) Update(kind string, id int32, fields Object) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	object, found := s.collections[kind][id]
+	if !found {
+		return false
+	}
+	for key, value := range fields {
+		object[key] = value
+	}
+	return true
+}
+
+// Remove an object and its pending changes, return false if it does not exist
+func (s *Store) Delete(kind string, id int32) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, found := s.collections[kind][id]; !found {
+		return false
+	}
+	delete(s.collections[kind], id)
+	delete(s.pendingChanges[kind], id)
+	return true
+}
+
+// Queue changes applied one per read of the object, for example the statuses a project goes through before being ready.
+// Waiters polling the object then see the same transitions as with Taikun.
+func (s *Store) QueueChanges(kind string, id int32, changes ...Object) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.pendingChanges[kind] == nil {
+		s.pendingChanges[kind] = map[int32][]Object{}
+	}
+	s.pendingChanges[kind][id] = append(s.pendingChanges[kind][id], changes...)
+}
+
+// Drop the changes queued for the object, for example when an operation is interrupted by another one
+func (s *Store) DropChanges(kind string, id int32) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.pendingChanges[kind], id)
+}
+
+// Delete the object once its queued changes have been read, for example after an application went through Uninstalling
+func (s *Store) QueueDelete(kind string, id int32) {
+	s.QueueChanges(kind, id, nil)
+}
+
+// Return false if the change deleted the object
+func (s *Store) applyNextChange(kind string, id int32, object Object) bool {
+	changes := s.pendingChanges[kind][id]
+	if len(changes) == 0 {
+		return true
+	}
+	s.pendingChanges[kind][id] = changes[1:]
+	if changes[0] == nil {
+		delete(s.collections[kind], id)
+		delete(s.pendingChanges[kind], id)
+		return false
+	}
+	for key, value := range changes[0] {
+		object[key] = value
+	}
+	return true
+}
+
+func copyObject(object Object) Object {
+	copied := make(Object, len(object))
+	for key, value := range object {
+		copied[key] = value
+	}
+	return copied
+}
@@ -0,0 +1,81 @@
+package faketaikun
+
+import (
+	"testing"
+)
+
+func TestStoreQueueChanges(t *testing.T) {
+	store := NewStore()
+	id := store.Create(projectApps, Object{"status": "NotReady"})
+	store.QueueChanges(projectApps, id, Object{"status": appStatusInstalling}, Object{"status": appStatusReady})
+
+	for _, expected := range []string{appStatusInstalling, appStatusReady, appStatusReady} {
+		projectApp, found := store.Get(projectApps, id)
+		if !found {
+			t.Fatalf("object %d not found", id)
+		}
+		if projectApp["status"] != expected {
+			t.Fatalf("expected status %s, got %v", expected, projectApp["status"])
+		}
+	}
+}
+
+func TestStoreQueueChangesAppliedByList(t *testing.T) {
+	store := NewStore()
+	id := store.Create(projectApps, Object{"status": "NotReady"})
+	store.QueueChanges(projectApps, id, Object{"status": appStatusInstalling})
+
+	listed := store.List(projectApps, nil)
+	if len(listed) != 1 || listed[0]["status"] != appStatusInstalling {
+		t.Fatalf("expected the listed object to be %s, got %v", appStatusInstalling, listed)
+	}
+}
+
+func TestStoreQueueDelete(t *testing.T) {
+	store := NewStore()
+	id := store.Create(projectApps, Object{"status": appStatusReady})
+	store.Update(projectApps, id, Object{"status": appStatusUninstalling})
+	store.QueueChanges(projectApps, id, Object{"status": appStatusUninstalling})
+	store.QueueDelete(projectApps, id)
+
+	projectApp, found := store.Get(projectApps, id)
+	if !found || projectApp["status"] != appStatusUninstalling {
+		t.Fatalf("expected the object to be %s before it is deleted, got %v", appStatusUninstalling, projectApp)
+	}
+	if listed := store.List(projectApps, nil); len(listed) != 0 {
+		t.Fatalf("expected the object to be deleted, got %v", listed)
+	}
+	if _, found := store.Get(projectApps, id); found {
+		t.Fatalf("expected object %d to be deleted", id)
+	}
+}
+
+func TestStoreGetReturnsCopy(t *testing.T) {
+	store := NewStore()
+	id := store.Create(projects, Object{"name": "project"})
+
+	project, _ := store.Get(projects, id)
+	project["name"] = "changed"
+	if stored, _ := store.Get(projects, id); stored["name"] != "project" {
+		t.Fatalf("expected the stored object to be unchanged, got %v", stored)
+	}
+}
+
+func TestStoreIDsAreNotReused(t *testing.T) {
+	store := NewStore()
+	first := store.Create(projects, Object{})
+	store.Delete(projects, first)
+	if second := store.Create(projectApps, Object{}); second == first {
+		t.Fatalf("expected a new ID, got %d again", second)
+	}
+}
+
+func TestAPIObjectHidesInternalFields(t *testing.T) {
+	object := apiObject(Object{"id": int32(1), "_revisions": []string{""}})
+	if _, found := object["_revisions"]; found {
+		t.Fatalf("expected internal fields to be hidden, got %v", object)
+	}
+	if object["id"] != int32(1) {
+		t.Fatalf("expected id to be kept, got %v", object)
+	}
+}
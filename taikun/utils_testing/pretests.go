@@ -4,8 +4,27 @@ import (
 	"fmt"
 	"os"
 	"testing"
+
+	"github.com/itera-io/terraform-provider-taikun/taikun/recorder"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
 )
 
+// Tests read the environment when building their configuration, the recorded one must be restored before they run.
+// Recorded tests send the requests through the recorder and draw their random names from it, so that replays send the same requests.
+func init() {
+	if err := recorder.LoadEnvironment(); err != nil {
+		panic(fmt.Errorf("unable to load the recorded environment: %s", err))
+	}
+	if recorder.Enabled() {
+		utils.RandomStringHook = recorder.RandomString
+		utils.RandomIntHook = recorder.RandomInt
+		utils.HTTPClientHook = recorder.WrapClient
+	}
+	if recorder.Replaying() {
+		utils.WaitForStateHook = utils.NoWaitDelay
+	}
+}
+
 func TestAccPreCheck(t *testing.T) {
 	// What enviroment variables do we require to be set
 	requiredEnvSlice := []string{
@@ -177,6 +196,10 @@ func TestAccPreCheckVsphere(t *testing.T) {
 }
 
 func checkEnvVariables(requiredEnvSlice []string, t *testing.T) {
+	// Record or replay the requests of the test to Taikun, if TAIKUN_RECORD or TAIKUN_REPLAY is set
+	recorder.Start(t)
+	recorder.RequireEnv(requiredEnvSlice...)
+
 	// Iterate through the required enviroment variables and check if all are set.
 	for _, requiredEnv := range requiredEnvSlice {
 		if err := os.Getenv(requiredEnv); err == "" {
@@ -35,7 +35,10 @@ func dataSourceTaikunVirtualClusterRead(ctx context.Context, d *schema.ResourceD
 	apiClient := meta.(*tk.Client)
 	data, response, err := apiClient.Client.ProjectsAPI.ProjectsList(ctx).Id(virtualClusterId).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(response, err))
+		return utils.DiagnosticsFromApiError(response, err)
+	}
+	if data.GetTotalCount() == 0 {
+		return diag.Errorf("virtual cluster with ID %d not found", virtualClusterId)
 	}
 	if data.GetTotalCount() != 1 {
 		return diag.Errorf("There should be one, but we found %d virtual projects with ID %d.", data.GetTotalCount(), virtualClusterId)
@@ -46,5 +49,10 @@ func dataSourceTaikunVirtualClusterRead(ctx context.Context, d *schema.ResourceD
 	}
 
 	// Use normal function to read and flatten the virtual cluster.
-	return generateResourceTaikunVirtualClusterRead()(ctx, d, meta)
+	// It drops a virtual cluster which does not exist from the state with a warning, a data source reports it as an error instead.
+	diags := generateResourceTaikunVirtualClusterReadWithoutRetries()(ctx, d, meta)
+	if d.Id() == "" && !diags.HasError() {
+		return diag.Errorf("virtual cluster with ID %d not found in project %s", virtualClusterId, d.Get("parent_id").(string))
+	}
+	return diags
 }
@@ -54,7 +54,7 @@ func dataSourceTaikunVirtualClustersRead(ctx context.Context, d *schema.Resource
 	for {
 		response, res, err := params.Offset(offset).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 
 		// Is it a virtual cluster?
@@ -67,7 +67,7 @@ func dataSourceTaikunVirtualClustersRead(ctx context.Context, d *schema.Resource
 				// Get Virtual cluster details
 				data, response, err := apiClient.Client.VirtualClusterAPI.VirtualClusterList(ctx, virtualProjectParentId).Id(virtualProjectId).Execute()
 				if err != nil {
-					return diag.FromErr(tk.CreateError(response, err))
+					return utils.DiagnosticsFromApiError(response, err)
 				}
 				// Append virtual cluster
 				virtualClustersList = append(virtualClustersList, data.GetData()[0])
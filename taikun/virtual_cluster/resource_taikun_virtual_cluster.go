@@ -82,19 +82,55 @@ func ResourceTaikunVirtualCluster() *schema.Resource {
 	return &schema.Resource{
 		Description:   "Virtual Cluster project in Taikun.",
 		CreateContext: resourceTaikunVirtualClusterCreate,
-		ReadContext:   generateResourceTaikunVirtualClusterRead(),
+		ReadContext:   generateResourceTaikunVirtualClusterReadWithoutRetries(),
 		UpdateContext: resourceTaikunVirtualClusterUpdate,
 		DeleteContext: resourceTaikunVirtualClusterDelete,
 		Schema:        resourceTaikunVirtualClusterSchema(),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTaikunVirtualClusterImport,
+		},
 	}
 }
 
+// Virtual clusters are listed by their parent project, they are imported with the ID parent_id/id
+func resourceTaikunVirtualClusterImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts, err := utils.ParseImportID(d.Id(), "parent_id", "id")
+	if err != nil {
+		return nil, err
+	}
+	for i, name := range []string{"parent_id", "id"} {
+		if _, err := utils.Atoi32(parts[i]); err != nil {
+			return nil, fmt.Errorf("%s isn't valid: %s", name, parts[i])
+		}
+	}
+
+	if err := d.Set("parent_id", parts[0]); err != nil {
+		return nil, err
+	}
+	if err := d.Set("id", parts[1]); err != nil {
+		return nil, err
+	}
+	d.SetId(parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceTaikunVirtualClusterUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(*tk.Client)
 	virtualClusterId, err := utils.Atoi32(d.Get("id").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
+	// Operations on the virtual clusters of a project are serialized on the parent project, as in Create and Delete
+	parentId, err := utils.Atoi32(d.Get("parent_id").(string))
+	if err != nil {
+		return utils.DiagnosticsFromError(err)
+	}
+	unlock, err := utils.LockProject(ctx, meta, parentId)
+	if err != nil {
+		return utils.DiagnosticsFromError(err)
+	}
+	defer unlock()
 
 	if d.HasChange("expiration_date") || d.HasChange("delete_on_expiration") {
 		body := tkcore.ProjectExtendLifeTimeCommand{}
@@ -115,11 +151,11 @@ func resourceTaikunVirtualClusterUpdate(ctx context.Context, d *schema.ResourceD
 
 		res, err := apiClient.Client.ProjectsAPI.ProjectsExtendLifetime(ctx).ProjectExtendLifeTimeCommand(body).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(res, err))
+			return utils.DiagnosticsFromApiError(res, err)
 		}
 	}
 
-	return utils.ReadAfterUpdateWithRetries(generateResourceTaikunVirtualClusterRead(), ctx, d, meta)
+	return utils.ReadAfterUpdateWithRetries(generateResourceTaikunVirtualClusterReadWithRetries(), ctx, d, meta)
 }
 
 func resourceTaikunVirtualClusterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -127,12 +163,22 @@ func resourceTaikunVirtualClusterDelete(ctx context.Context, d *schema.ResourceD
 	deleteCommand := tkcore.DeleteVirtualClusterCommand{}
 	virtualClusterId, err := utils.Atoi32(d.Get("id").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
+	}
+	parentId, err := utils.Atoi32(d.Get("parent_id").(string))
+	if err != nil {
+		return utils.DiagnosticsFromError(err)
 	}
+	unlock, err := utils.LockProject(ctx, meta, parentId)
+	if err != nil {
+		return utils.DiagnosticsFromError(err)
+	}
+	defer unlock()
+
 	deleteCommand.SetProjectId(virtualClusterId)
 	response, err2 := apiClient.Client.VirtualClusterAPI.VirtualClusterDelete(ctx).DeleteVirtualClusterCommand(deleteCommand).Execute()
 	if err2 != nil {
-		return diag.FromErr(tk.CreateError(response, err2))
+		return utils.DiagnosticsFromApiError(response, err2)
 	}
 	d.SetId("")
 	return nil
@@ -146,8 +192,14 @@ func resourceTaikunVirtualClusterCreate(ctx context.Context, d *schema.ResourceD
 	name := d.Get("name").(string)
 	parentId, err := utils.Atoi32(d.Get("parent_id").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
+	unlock, err := utils.LockProject(ctx, meta, parentId)
+	if err != nil {
+		return utils.DiagnosticsFromError(err)
+	}
+	defer unlock()
+
 	bodyCreate.SetName(name)
 	bodyCreate.SetProjectId(parentId)
 	bodyCreate.SetDeleteOnExpiration(false)
@@ -167,34 +219,34 @@ func resourceTaikunVirtualClusterCreate(ctx context.Context, d *schema.ResourceD
 	if alertingProfileId != "" {
 		alertingProfileIdInt32, err := utils.Atoi32(alertingProfileId)
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 		bodyCreate.SetAlertingProfileId(alertingProfileIdInt32)
 	}
 
 	response, err := apiClient.Client.VirtualClusterAPI.VirtualClusterCreate(ctx).CreateVirtualClusterCommand(*bodyCreate).Execute()
 	if err != nil {
-		return diag.FromErr(tk.CreateError(response, err))
+		return utils.DiagnosticsFromApiError(response, err)
 	}
 
 	// Get ID of newly created project
 	err = loadVirtualClusterId(ctx, d, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 	virtualClusterId, err := utils.Atoi32(d.Get("id").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	// Monitor project state with ID
 	err = resourceTaikunVirtualClusterWaitForReady(virtualClusterId, ctx, meta)
 	if err != nil {
-		return diag.FromErr(err)
+		return utils.DiagnosticsFromError(err)
 	}
 
 	// Once project is ready, read all information about it.
-	return utils.ReadAfterCreateWithRetries(generateResourceTaikunVirtualClusterRead(), ctx, d, meta)
+	return utils.ReadAfterCreateWithRetries(generateResourceTaikunVirtualClusterReadWithRetries(), ctx, d, meta)
 }
 
 // From [Parent Project ID] and [Virtual Cluster Name] find the ID of the Virtual project and load it into schema (d)
@@ -213,7 +265,7 @@ func loadVirtualClusterId(ctx context.Context, d *schema.ResourceData, meta inte
 	for {
 		response, res, err := params.Offset(offset).Execute()
 		if err != nil {
-			return tk.CreateError(res, err)
+			return utils.NewApiError(res, err)
 		}
 		virtualClustersList = append(virtualClustersList, response.GetData()...)
 		if len(virtualClustersList) == int(response.GetTotalCount()) {
@@ -240,23 +292,30 @@ func loadVirtualClusterId(ctx context.Context, d *schema.ResourceData, meta inte
 	return nil
 }
 
+func generateResourceTaikunVirtualClusterReadWithRetries() schema.ReadContextFunc {
+	return generateResourceTaikunVirtualClusterRead(true)
+}
+func generateResourceTaikunVirtualClusterReadWithoutRetries() schema.ReadContextFunc {
+	return generateResourceTaikunVirtualClusterRead(false)
+}
+
 // For this to work, [Parent Project ID] and [Virtual Project ID] muset be set in schema (d).
-func generateResourceTaikunVirtualClusterRead() schema.ReadContextFunc {
+func generateResourceTaikunVirtualClusterRead(withRetries bool) schema.ReadContextFunc {
 	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 		apiClient := meta.(*tk.Client)
 
 		parentId, err := utils.Atoi32(d.Get("parent_id").(string))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 		virtualClusterId, err := utils.Atoi32(d.Get("id").(string))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		data, response, err := apiClient.Client.VirtualClusterAPI.VirtualClusterList(ctx, parentId).Id(virtualClusterId).Execute()
 		if err != nil {
-			return diag.FromErr(tk.CreateError(response, err))
+			return utils.ReadApiError(d, d.Id(), withRetries, response, err)
 		}
 
 		foundMatch := false
@@ -269,15 +328,14 @@ func generateResourceTaikunVirtualClusterRead() schema.ReadContextFunc {
 		// The Created virtual project was not found on the server. This probably means it got deleted from Taikun.
 		// Do not delete, just create it again.
 		if !foundMatch {
-			d.SetId("")
-			return nil
+			return utils.ReadNotFound(d, d.Id(), withRetries)
 		}
 		// Project was found in Failed state.
 		// Delete project and create it again.
 		if foundMatch && rawVirtualProject.GetStatus() == tkcore.PROJECTSTATUS_FAILURE {
 			err = d.Set("status", "Failed")
 			if err != nil {
-				return diag.FromErr(err)
+				return utils.DiagnosticsFromError(err)
 			}
 			return nil
 		}
@@ -285,7 +343,7 @@ func generateResourceTaikunVirtualClusterRead() schema.ReadContextFunc {
 		// Load all the found data to the local object
 		err = utils.SetResourceDataFromMap(d, flattenTaikunVirtualCluster(&rawVirtualProject))
 		if err != nil {
-			return diag.FromErr(err)
+			return utils.DiagnosticsFromError(err)
 		}
 
 		d.SetId(d.Get("id").(string)) // We need to tell provider that object was created
@@ -327,7 +385,7 @@ func resourceTaikunVirtualClusterWaitForReady(virtualClusterId int32, ctx contex
 		Refresh: func() (interface{}, string, error) {
 			data, response, err := apiClient.Client.ProjectsAPI.ProjectsList(ctx).Id(virtualClusterId).Execute()
 			if err != nil {
-				return nil, "", tk.CreateError(response, err)
+				return nil, "", utils.NewApiError(response, err)
 			}
 			if data.GetTotalCount() != 1 {
 				return nil, "", fmt.Errorf("could not find virtual cluster by id")
@@ -344,7 +402,7 @@ func resourceTaikunVirtualClusterWaitForReady(virtualClusterId int32, ctx contex
 		MinTimeout: 10 * time.Second,
 	}
 
-	_, err := createStateConf.WaitForStateContext(ctx)
+	_, err := utils.WaitForState(ctx, createStateConf)
 	if err != nil {
 		return fmt.Errorf("error waiting for virtual cluster (%d) to be read: %s", virtualClusterId, err)
 	}
@@ -0,0 +1,47 @@
+//go:build sweep
+
+package virtual_cluster
+
+import (
+	"context"
+	"errors"
+
+	tk "github.com/itera-io/taikungoclient"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+)
+
+// Delete the virtual clusters left behind by the acceptance tests, before their parent projects are deleted
+func AddTestSweepers(client utils.SweeperClientFunc) {
+	utils.AddTestSweeper("taikun_virtual_cluster", client, []string{"taikun_app_instance"}, sweepVirtualClusters)
+}
+
+func sweepVirtualClusters(ctx context.Context, apiClient *tk.Client) error {
+	var offset int32 = 0
+
+	// Virtual clusters are listed with the projects, the ID of their parent is needed to delete them
+	parentIDs := map[int32]int32{}
+	for {
+		response, res, err := apiClient.Client.ProjectsAPI.ProjectsList(ctx).Offset(offset).Execute()
+		if err != nil {
+			return tk.CreateError(res, err)
+		}
+		for _, project := range response.GetData() {
+			if project.GetIsVirtualCluster() && utils.IsSweepable(project.GetName()) {
+				parentIDs[project.GetId()] = project.GetParentProjectId()
+			}
+		}
+		offset += int32(len(response.GetData()))
+		if len(response.GetData()) == 0 || int(offset) >= int(response.GetTotalCount()) {
+			break
+		}
+	}
+
+	var errs []error
+	for id, parentID := range parentIDs {
+		attributes := map[string]interface{}{
+			"parent_id": utils.I32toa(parentID),
+		}
+		errs = append(errs, utils.SweepResource(ctx, ResourceTaikunVirtualCluster(), utils.I32toa(id), attributes, apiClient))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,150 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	tk "github.com/itera-io/taikungoclient"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils"
+	"github.com/itera-io/terraform-provider-taikun/taikun/utils_testing"
+)
+
+const testAccResourceTaikunVirtualClusterConfig = `
+resource "taikun_virtual_cluster" "foo" {
+  name                 = "%s"
+  parent_id            = "%s"
+  expiration_date      = "20/01/2050"
+  delete_on_expiration = true
+}
+`
+
+// Virtual clusters are created in an existing k8s project (TAIKUN_PROJECT_ID)
+func TestAccResourceTaikunVirtualCluster(t *testing.T) {
+	parentID := os.Getenv("TAIKUN_PROJECT_ID")
+	virtualClusterName := strings.ToLower(utils.ShortRandomTestName())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			utils_testing.TestAccPreCheck(t)
+			if parentID == "" {
+				t.Skip("TAIKUN_PROJECT_ID must be set to a running k8s project ID")
+			}
+		},
+		ProviderFactories: utils_testing.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckTaikunVirtualClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccResourceTaikunVirtualClusterConfig,
+					virtualClusterName,
+					parentID,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckTaikunVirtualClusterExists,
+					resource.TestCheckResourceAttrSet("taikun_virtual_cluster.foo", "id"),
+					resource.TestCheckResourceAttr("taikun_virtual_cluster.foo", "name", virtualClusterName),
+					resource.TestCheckResourceAttr("taikun_virtual_cluster.foo", "parent_id", parentID),
+					resource.TestCheckResourceAttr("taikun_virtual_cluster.foo", "expiration_date", "20/01/2050"),
+					resource.TestCheckResourceAttr("taikun_virtual_cluster.foo", "delete_on_expiration", "true"),
+					resource.TestCheckResourceAttrSet("taikun_virtual_cluster.foo", "hostname_generated"),
+				),
+			},
+			{
+				ResourceName:      "taikun_virtual_cluster.foo",
+				ImportState:       true,
+				ImportStateIdFunc: testAccResourceTaikunVirtualClusterImportStateId,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"hostname",
+					"status",
+				},
+			},
+			{
+				ResourceName:  "taikun_virtual_cluster.foo",
+				ImportState:   true,
+				ImportStateId: parentID,
+				ExpectError:   regexp.MustCompile(`unexpected format of ID .*expected parent_id/id`),
+			},
+			{
+				ResourceName:  "taikun_virtual_cluster.foo",
+				ImportState:   true,
+				ImportStateId: parentID + "/",
+				ExpectError:   regexp.MustCompile(`id is empty`),
+			},
+			{
+				ResourceName:  "taikun_virtual_cluster.foo",
+				ImportState:   true,
+				ImportStateId: parentID + "/" + virtualClusterName,
+				ExpectError:   regexp.MustCompile(`id isn't valid`),
+			},
+		},
+	})
+}
+
+// Import the virtual cluster with the ID parent_id/id
+func testAccResourceTaikunVirtualClusterImportStateId(state *terraform.State) (string, error) {
+	rs, found := state.RootModule().Resources["taikun_virtual_cluster.foo"]
+	if !found {
+		return "", errors.New("taikun_virtual_cluster.foo not found in state")
+	}
+	return fmt.Sprintf("%s/%s", rs.Primary.Attributes["parent_id"], rs.Primary.ID), nil
+}
+
+func testAccCheckTaikunVirtualClusterExists(state *terraform.State) error {
+	client := utils_testing.TestAccProvider.Meta().(*tk.Client)
+
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "taikun_virtual_cluster" {
+			continue
+		}
+
+		parentId, _ := utils.Atoi32(rs.Primary.Attributes["parent_id"])
+		id, _ := utils.Atoi32(rs.Primary.ID)
+
+		response, _, err := client.Client.VirtualClusterAPI.VirtualClusterList(context.TODO(), parentId).Id(id).Execute()
+		if err != nil || response.GetTotalCount() != 1 {
+			return fmt.Errorf("virtual cluster doesn't exist (id = %s)", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckTaikunVirtualClusterDestroy(state *terraform.State) error {
+	client := utils_testing.TestAccProvider.Meta().(*tk.Client)
+
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "taikun_virtual_cluster" {
+			continue
+		}
+
+		parentId, _ := utils.Atoi32(rs.Primary.Attributes["parent_id"])
+		id, _ := utils.Atoi32(rs.Primary.ID)
+
+		retryErr := retry.RetryContext(context.Background(), utils.GetReadAfterOpTimeout(false), func() *retry.RetryError {
+			response, _, err := client.Client.VirtualClusterAPI.VirtualClusterList(context.TODO(), parentId).Id(id).Execute()
+			if err != nil {
+				return retry.NonRetryableError(err)
+			}
+			if response.GetTotalCount() != 0 {
+				return retry.RetryableError(errors.New("virtual cluster still exists"))
+			}
+			return nil
+		})
+		if utils.TimedOut(retryErr) {
+			return errors.New("virtual cluster still exists (timed out)")
+		}
+		if retryErr != nil {
+			return retryErr
+		}
+	}
+
+	return nil
+}